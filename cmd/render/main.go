@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/akosgarai/opengl_playground/pkg/capture"
+	"github.com/akosgarai/opengl_playground/pkg/framebuffer"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/scene"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	width          = flag.Int("width", 800, "The width of the frames.")
+	height         = flag.Int("height", 800, "The height of the frames.")
+	fps            = flag.Int("fps", 25, "The number of the simulated frames per second.")
+	frames         = flag.Int("frames", 50, "The number of the frames. It's ignored if the duration is set.")
+	duration       = flag.Float64("duration", 0, "The duration of the animation in seconds.")
+	output         = flag.String("out", "frames", "The output. If it has '.gif' extension, an animated gif is written, otherwise png frames to the directory.")
+	cameraPosition = flag.String("camera", "", "The camera position as 'x,y,z'. If it's empty, the camera of the scene is used.")
+	cameraYaw      = flag.Float64("yaw", -90, "The yaw of the camera. It's used with the camera flag.")
+	cameraPitch    = flag.Float64("pitch", 0, "The pitch of the camera. It's used with the camera flag.")
+	cameraFov      = flag.Float64("fov", 45, "The field of view of the camera. It's used with the camera flag.")
+)
+
+// parseVec3 returns the vector from the 'x,y,z' format.
+func parseVec3(value string) (mgl32.Vec3, error) {
+	var v mgl32.Vec3
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("Invalid vector '%s'.", value)
+	}
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return v, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] scene.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *fps <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	numberOfFrames := *frames
+	if *duration > 0 {
+		numberOfFrames = int(*duration * float64(*fps))
+	}
+	// the delta time of the update functions is in seconds.
+	timestep := 1.0 / float64(*fps)
+
+	sceneFile, err := scene.LoadFile(flag.Arg(0))
+	if err != nil {
+		panic(err)
+	}
+
+	runtime.LockOSThread()
+	window.InitHiddenGlfw(*width, *height, "Render")
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app, err := sceneFile.Build(scene.NewShader)
+	if err != nil {
+		panic(err)
+	}
+	if *cameraPosition != "" || sceneFile.Camera == nil {
+		position := mgl32.Vec3{0, 0, 10}
+		if *cameraPosition != "" {
+			if position, err = parseVec3(*cameraPosition); err != nil {
+				panic(err)
+			}
+		}
+		c := camera.NewCamera(position, mgl32.Vec3{0, 1, 0}, float32(*cameraYaw), float32(*cameraPitch))
+		c.SetupProjection(float32(*cameraFov), float32(*width)/float32(*height), 0.1, 1000.0)
+		app.SetCamera(c)
+	}
+
+	target, err := framebuffer.New(*width, *height)
+	if err != nil {
+		panic(err)
+	}
+	defer target.Delete()
+	writer, err := capture.New(*output, *fps)
+	if err != nil {
+		panic(err)
+	}
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.0, 0.0, 0.0, 1.0)
+
+	target.Bind()
+	for i := 0; i < numberOfFrames; i++ {
+		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
+		sceneFile.UpdateViewPosition()
+		app.DrawWithUniforms()
+		if err := writer.AddFrame(target.ReadPixels()); err != nil {
+			panic(err)
+		}
+		app.Update(timestep)
+	}
+	target.UnBind()
+	if err := writer.Close(); err != nil {
+		panic(err)
+	}
+	fmt.Printf("%d frames have been written to '%s'.\n", numberOfFrames, *output)
+}
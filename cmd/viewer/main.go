@@ -0,0 +1,458 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/model"
+	"github.com/akosgarai/opengl_playground/pkg/overlay"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	"github.com/akosgarai/opengl_playground/pkg/scene"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	WindowTitle = "Viewer"
+
+	moveSpeed            = 5.0
+	lookSpeed            = 90.0
+	cameraDirectionSpeed = float32(0.100)
+	cameraDistance       = 0.1
+	// normalLength is the length of the normal segments relative to the
+	// radius of the item.
+	normalLength = 0.1
+)
+
+var (
+	app       *application.Application
+	viewer    *sceneViewer
+	sceneFile *scene.Scene
+	wireframe bool
+
+	windowWidth  = flag.Int("width", 800, "The width of the window.")
+	windowHeight = flag.Int("height", 800, "The height of the window.")
+	shaderDir    = flag.String("shaders", "cmd/viewer/shaders", "The directory of the viewer shaders.")
+	inputFile    = flag.String("input", "", "The input config file. The default bindings are used if it's empty.")
+	fullscreen   = flag.Bool("fullscreen", false, "Borderless fullscreen window on the primary monitor.")
+	vsync        = flag.Int("vsync", 1, "The swap interval, 0 disables the vsync.")
+	msaa         = flag.Int("msaa", 4, "The number of the MSAA samples, 0 disables the multisampling.")
+	debug        = flag.Bool("debug", false, "Debug gl context, the gl errors are printed with the call sites.")
+
+	// three-point lighting: the key light is the strongest one from the front-left,
+	// the fill light is from the front-right, the back light is behind the model.
+	KeyLightDirection  = (mgl32.Vec3{1, -1, -1}).Normalize()
+	FillLightDirection = (mgl32.Vec3{-1, -0.5, -1}).Normalize()
+	BackLightDirection = (mgl32.Vec3{0, -1, 1}).Normalize()
+	KeyLightColor      = mgl32.Vec3{0.8, 0.8, 0.8}
+	FillLightColor     = mgl32.Vec3{0.4, 0.4, 0.4}
+	BackLightColor     = mgl32.Vec3{0.5, 0.5, 0.5}
+	AmbientColor       = mgl32.Vec3{0.1, 0.1, 0.1}
+	// FlatColor is the color of the items if the lighting is off.
+	FlatColor = mgl32.Vec3{0.8, 0.8, 0.8}
+)
+
+// sceneViewer draws the items of the scene or the model. If the lighting is
+// on, the items are drawn with their own shaders, sorted by a render queue
+// like in the application. Otherwise the pickable items are drawn with flat
+// color, the models with a flat copy that shares their mesh. The normal
+// vectors are drawn as line segments on top of them.
+type sceneViewer struct {
+	items []application.Drawable
+	queue *application.RenderQueue
+
+	lighting    bool
+	showNormals bool
+	// lightShader gets the view position, if it's set. The shaders of the
+	// scene files are updated by the scene.
+	lightShader *shader.Shader
+	colorShader *shader.Shader
+	// the flat copies of the models and the normal segments of the meshes in
+	// model space. They are generated for the first draw.
+	flatModels  map[*model.Model]*model.Model
+	meshNormals map[*model.Mesh][]float32
+}
+
+func newSceneViewer(items []application.Drawable, colorShader *shader.Shader) *sceneViewer {
+	return &sceneViewer{
+		items:       items,
+		queue:       application.NewRenderQueue(),
+		lighting:    true,
+		colorShader: colorShader,
+		flatModels:  make(map[*model.Model]*model.Model),
+		meshNormals: make(map[*model.Mesh][]float32),
+	}
+}
+func (v *sceneViewer) Draw() {
+	v.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+}
+func (v *sceneViewer) DrawWithUniforms(view, projection mgl32.Mat4) {
+	if v.lighting {
+		v.drawLit(view, projection)
+	} else {
+		v.drawFlat(view, projection)
+	}
+	if v.showNormals {
+		v.drawNormals(view, projection)
+	}
+}
+func (v *sceneViewer) Update(dt float64) {
+	for _, item := range v.items {
+		item.Update(dt)
+	}
+}
+func (v *sceneViewer) Log() string {
+	logString := ""
+	for _, item := range v.items {
+		logString += item.Log()
+	}
+	return logString
+}
+
+// drawLit draws the opaque queueable items first, then the other items, then
+// the transparent ones.
+func (v *sceneViewer) drawLit(view, projection mgl32.Mat4) {
+	if v.lightShader != nil {
+		v.lightShader.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
+	}
+	v.queue.Clear()
+	var others []application.Drawable
+	for _, item := range v.items {
+		if q, ok := item.(application.Queueable); ok {
+			v.queue.Add(application.NewDrawCommand(q))
+		} else {
+			others = append(others, item)
+		}
+	}
+	v.queue.Sort(app.GetCamera().GetPosition())
+	v.queue.SubmitOpaque(view, projection)
+	for _, item := range others {
+		item.DrawWithUniforms(view, projection)
+	}
+	v.queue.SubmitTransparent(view, projection)
+}
+
+// drawFlat draws the pickable items with flat color. The other items (eg:
+// points) are drawn with their own shaders.
+func (v *sceneViewer) drawFlat(view, projection mgl32.Mat4) {
+	var data []float32
+	for _, item := range v.items {
+		switch i := item.(type) {
+		case *model.Model:
+			v.flatModel(i).DrawWithUniforms(view, projection)
+		case picking.Pickable:
+			for _, t := range i.GetTriangles() {
+				for _, p := range t {
+					data = append(data, p.X(), p.Y(), p.Z(), FlatColor.X(), FlatColor.Y(), FlatColor.Z())
+				}
+			}
+		default:
+			item.DrawWithUniforms(view, projection)
+		}
+	}
+	v.drawColored(data, mgl32.Ident4(), view, projection, false)
+}
+
+// flatModel returns the flat copy of the model with the current transformation.
+func (v *sceneViewer) flatModel(m *model.Model) *model.Model {
+	flat, ok := v.flatModels[m]
+	if !ok || flat.GetMesh() != m.GetMesh() {
+		flat = model.New(m.GetMesh(), v.colorShader)
+		flat.SetColor(FlatColor)
+		v.flatModels[m] = flat
+	}
+	flat.SetPosition(m.GetPosition())
+	flat.SetScale(m.GetScale())
+	flat.SetAngle(m.GetAngle())
+	flat.SetAxis(m.GetAxis())
+	return flat
+}
+
+// drawNormals draws the vertex normals of the models and the face normals of
+// the other pickable items. The length of the segments is proportional to the
+// size of the item.
+func (v *sceneViewer) drawNormals(view, projection mgl32.Mat4) {
+	var data []float32
+	for _, item := range v.items {
+		switch i := item.(type) {
+		case *model.Model:
+			v.drawColored(v.modelNormals(i.GetMesh()), i.GetTransform(), view, projection, true)
+		case picking.Pickable:
+			length := normalLength * i.GetBoundingBox().BoundingSphere().Radius
+			for _, t := range i.GetTriangles() {
+				normal := t[1].Sub(t[0]).Cross(t[2].Sub(t[0]))
+				if normal.Len() == 0 {
+					continue
+				}
+				center := t[0].Add(t[1]).Add(t[2]).Mul(1.0 / 3.0)
+				data = appendNormal(data, center, normal.Normalize(), length)
+			}
+		}
+	}
+	v.drawColored(data, mgl32.Ident4(), view, projection, true)
+}
+
+// modelNormals returns the vertex normal segments of the mesh in model space.
+func (v *sceneViewer) modelNormals(mesh *model.Mesh) []float32 {
+	if data, ok := v.meshNormals[mesh]; ok {
+		return data
+	}
+	min, max := mesh.Bounds()
+	length := normalLength * max.Sub(min).Len() / 2
+	data := make([]float32, 0, len(mesh.Vertices)*12)
+	for i, vertex := range mesh.Vertices {
+		data = appendNormal(data, vertex, mesh.Normals[i], length)
+	}
+	v.meshNormals[mesh] = data
+	return data
+}
+
+// drawColored draws the position, color vertices with the color shader as
+// line segments or triangles.
+func (v *sceneViewer) drawColored(data []float32, M, view, projection mgl32.Mat4, lines bool) {
+	if len(data) == 0 {
+		return
+	}
+	v.colorShader.Use()
+	v.colorShader.SetUniformMat4("model", M)
+	v.colorShader.SetUniformMat4("view", view)
+	v.colorShader.SetUniformMat4("projection", projection)
+	v.colorShader.BindBufferData(data)
+	v.colorShader.BindVertexArray()
+	v.colorShader.VertexAttribPointer(0, 3, 4*6, 0)
+	v.colorShader.VertexAttribPointer(1, 3, 4*6, 4*3)
+	if lines {
+		v.colorShader.DrawLines(int32(len(data) / 6))
+	} else {
+		v.colorShader.DrawTriangles(int32(len(data) / 6))
+	}
+	v.colorShader.Close(2)
+}
+
+// appendNormal appends the segment of the normal vector from the point. The
+// color is calculated from the direction, like in the normal draw mode of the models.
+func appendNormal(data []float32, point, normal mgl32.Vec3, length float32) []float32 {
+	end := point.Add(normal.Mul(length))
+	color := normal.Mul(0.5).Add(mgl32.Vec3{0.5, 0.5, 0.5})
+	return append(data,
+		point.X(), point.Y(), point.Z(), color.X(), color.Y(), color.Z(),
+		end.X(), end.Y(), end.Z(), color.X(), color.Y(), color.Z())
+}
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 0, 3}, mgl32.Vec3{0, 1, 0}, -90.0, 0.0)
+	camera.SetupProjection(45, float32(*windowWidth)/float32(*windowHeight), 0.01, 1000.0)
+	return camera
+}
+
+// NewColorShader returns the shader of the flat items and the normal segments.
+func NewColorShader() *shader.Shader {
+	return shader.NewShader(filepath.Join(*shaderDir, "color.vert"), filepath.Join(*shaderDir, "color.frag"))
+}
+
+// LoadModel loads the mesh and creates the viewer of the lit model. The mesh
+// is scaled to the unit sphere around the origo.
+func LoadModel(path string) (*sceneViewer, error) {
+	mesh, err := model.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("%s: %d triangles\n", path, mesh.TriangleCount())
+	min, max := mesh.Bounds()
+	center := min.Add(max).Mul(0.5)
+	scale := float32(1.0)
+	if radius := max.Sub(min).Len() / 2; radius > 0 {
+		scale = 1 / radius
+	}
+
+	lightShader := shader.NewShader(filepath.Join(*shaderDir, "light.vert"), filepath.Join(*shaderDir, "light.frag"))
+	lights := [][3]mgl32.Vec3{
+		{KeyLightDirection, AmbientColor, KeyLightColor},
+		{FillLightDirection, mgl32.Vec3{}, FillLightColor},
+		{BackLightDirection, mgl32.Vec3{}, BackLightColor},
+	}
+	for i, l := range lights {
+		prefix := "dirLight[" + trans.IntegerToString(i) + "]"
+		lightShader.AddDirectionalLightSource(light.NewDirectionalLight([4]mgl32.Vec3{l[0], l[1], l[2], l[2]}),
+			[4]string{prefix + ".direction", prefix + ".ambient", prefix + ".diffuse", prefix + ".specular"})
+	}
+
+	lit := model.New(mesh, lightShader)
+	lit.SetMaterial(material.Silver)
+	lit.DrawMode(model.DRAW_MODE_LIGHT)
+	lit.SetScale(scale)
+	lit.SetPosition(center.Mul(-scale))
+	viewer := newSceneViewer([]application.Drawable{lit}, NewColorShader())
+	viewer.lightShader = lightShader
+	return viewer, nil
+}
+
+// CreateInputMap returns the input map of the config file, or the default
+// bindings: the camera movement axes and the "wireframe" (F), "normals" (N),
+// "lighting" (L) toggle actions.
+func CreateInputMap() (*input.Map, error) {
+	if *inputFile != "" {
+		return input.LoadFile(*inputFile)
+	}
+	m := input.NewCameraMap()
+	m.BindAction("wireframe", input.KeyBinding(glfw.KeyF, 0))
+	m.BindAction("normals", input.KeyBinding(glfw.KeyN, 0))
+	m.BindAction("lighting", input.KeyBinding(glfw.KeyL, 0))
+	return m, nil
+}
+
+// HandleToggles handles the toggle actions.
+func HandleToggles(m *input.Map) {
+	if m.Pressed("wireframe") {
+		wireframe = !wireframe
+		if wireframe {
+			wrapper.PolygonMode(wrapper.FRONT_AND_BACK, wrapper.LINE)
+		} else {
+			wrapper.PolygonMode(wrapper.FRONT_AND_BACK, wrapper.FILL)
+		}
+	}
+	if viewer != nil && m.Pressed("normals") {
+		viewer.showNormals = !viewer.showNormals
+	}
+	if viewer != nil && m.Pressed("lighting") {
+		viewer.lighting = !viewer.lighting
+	}
+}
+
+// Update handles the toggles and moves the camera based on the input map and
+// the mouse position. It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	HandleToggles(m)
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if sceneFile != nil {
+		sceneFile.UpdateViewPosition()
+	}
+	currX, currY := app.GetWindow().GetCursorPos()
+	x, y := app.MouseCoordinates(currX, currY)
+	dX := float32(0.0)
+	dY := float32(0.0)
+	if y > 1.0-cameraDistance && y < 1.0 {
+		dY = -cameraDirectionSpeed
+	} else if y < -1.0+cameraDistance && y > -1.0 {
+		dY = cameraDirectionSpeed
+	}
+	if x < -1.0+cameraDistance && x > -1.0 {
+		dX = cameraDirectionSpeed
+	} else if x > 1.0-cameraDistance && x < 1.0 {
+		dX = -cameraDirectionSpeed
+	}
+	app.GetCamera().UpdateDirection(dX, dY)
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] scene.json|model.obj|model.stl|model.gltf|model.glb\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	runtime.LockOSThread()
+	config := window.DefaultConfig(*windowWidth, *windowHeight, WindowTitle)
+	config.Borderless = *fullscreen
+	config.VSync = *vsync
+	config.Samples = *msaa
+	config.Debug = *debug
+	w, err := window.Create(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+	if *debug {
+		wrapper.EnableDebug(wrapper.DebugOptions{MinSeverity: wrapper.SEVERITY_LOW})
+	}
+	if *msaa > 0 {
+		wrapper.Enable(wrapper.MULTISAMPLE)
+	}
+
+	app = application.New()
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		var err error
+		if sceneFile, err = scene.LoadFile(path); err != nil {
+			panic(err)
+		}
+		// the items of the built application are drawn by the viewer, so
+		// that the lighting could be toggled.
+		built, err := sceneFile.Build(scene.NewShader)
+		if err != nil {
+			panic(err)
+		}
+		if sceneFile.Camera != nil {
+			app.SetCamera(built.GetCamera())
+		} else {
+			app.SetCamera(CreateCamera())
+		}
+		viewer = newSceneViewer(sceneFile.GetItems(), NewColorShader())
+	} else {
+		var err error
+		if viewer, err = LoadModel(path); err != nil {
+			panic(err)
+		}
+		app.SetCamera(CreateCamera())
+	}
+	app.AddItem(viewer)
+	app.SetWindow(w)
+	inputMap, err := CreateInputMap()
+	if err != nil {
+		panic(err)
+	}
+	app.SetInputMap(inputMap)
+	// the frame time graph is hidden by default, it could be displayed with the STATS key.
+	app.SetGPUTiming(true)
+	app.SetOverlay(overlay.New(overlay.NewShader()))
+	app.ToggleOverlay()
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.2, 0.2, 0.2, 1.0)
+
+	// register keyboard button callback
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	// register mouse button and scroll callbacks for the input map and the zoom
+	app.GetWindow().SetMouseButtonCallback(app.MouseButtonCallback)
+	app.GetWindow().SetScrollCallback(app.ScrollCallback)
+	app.SetScrollZoom(2, application.DEFAULT_MIN_FOV, application.DEFAULT_MAX_FOV)
+	// the gamepads also move the camera, the right stick rotates it.
+	app.SetJoystickSource(application.GLFWJoysticks{})
+
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
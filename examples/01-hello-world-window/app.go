@@ -39,9 +39,5 @@ func main() {
 
 	wrapper.ClearColor(ClearColor[0], ClearColor[1], ClearColor[2], ClearColor[3])
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT)
-		glfw.PollEvents()
-		app.GetWindow().SwapBuffers()
-	}
+	app.Run()
 }
@@ -52,10 +52,5 @@ func main() {
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		app.Draw()
-		glfw.PollEvents()
-		app.GetWindow().SwapBuffers()
-	}
+	app.Run()
 }
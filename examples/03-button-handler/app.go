@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
@@ -19,8 +18,7 @@ const (
 	WindowWidth  = 800
 	WindowHeight = 600
 	WindowTitle  = "Example - static button handler"
-	epsilon      = 30
-	speed        = float32(0.15) / float32(1000.0)
+	speed        = float32(0.15)
 
 	FORWARD  = glfw.KeyW
 	BACKWARD = glfw.KeyS
@@ -53,8 +51,6 @@ var (
 		mgl32.Vec3{0, 1, 0},
 		mgl32.Vec3{0, 1, 0},
 	}
-	lastUpdate = time.Now().UnixNano() / 1000000
-
 	app *application.Application
 
 	item   *triangle.Triangle
@@ -72,7 +68,10 @@ func SetupKeyMap() map[glfw.Key]bool {
 
 	return keyDowns
 }
-func Update() {
+
+// Update sets the direction of the items based on the pressed keys.
+// It's called in every fixed update step.
+func Update(dt float64) {
 	if app.GetKeyState(SWAP) {
 		item.SetColor(mgl32.Vec3{0, 1, 0})
 		square.SetColor(mgl32.Vec3{1, 0, 0})
@@ -80,9 +79,6 @@ func Update() {
 		item.SetColor(mgl32.Vec3{1, 0, 0})
 		square.SetColor(mgl32.Vec3{0, 1, 0})
 	}
-	// now in milisec.
-	nowUnixM := time.Now().UnixNano() / 1000000
-	delta := nowUnixM - lastUpdate
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
 		square.SetIndexDirection(1, 1.0)
 		item.SetIndexDirection(1, -1.0)
@@ -103,11 +99,6 @@ func Update() {
 		square.SetIndexDirection(0, 0.0)
 		item.SetIndexDirection(0, 0.0)
 	}
-	if epsilon > delta {
-		return
-	}
-	lastUpdate = nowUnixM
-	app.Update(float64(delta))
 }
 
 func main() {
@@ -134,11 +125,6 @@ func main() {
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		app.Draw()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
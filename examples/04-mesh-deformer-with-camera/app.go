@@ -2,12 +2,10 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/triangle"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
@@ -20,7 +18,7 @@ const (
 	WindowWidth  = 800
 	WindowHeight = 800
 	WindowTitle  = "Example - mesh deformer - with moving camera"
-	moveSpeed    = 0.005
+	moveSpeed    = 5.0
 
 	rows   = 10
 	cols   = 10
@@ -40,8 +38,6 @@ var (
 	triangleColorFront = mgl32.Vec3{0, 0, 1}
 	triangleColorBack  = mgl32.Vec3{0, 0.5, 1}
 
-	lastUpdate int64
-
 	cameraDistance       = 0.1
 	cameraDirectionSpeed = float32(0.500)
 )
@@ -82,7 +78,7 @@ func GenerateTriangles(shaderProgram *shader.Shader) {
 			colors := [3]mgl32.Vec3{triangleColorFront, triangleColorFront, triangleColorFront}
 			item := triangle.New(coords, colors, shaderProgram)
 			item.SetDirection(mgl32.Vec3{0, 0, 1})
-			item.SetSpeed(float32(1.0) / float32(1000000000.0))
+			item.SetSpeed(float32(1.0))
 			app.AddItem(item)
 
 			coords = [3]mgl32.Vec3{
@@ -93,50 +89,45 @@ func GenerateTriangles(shaderProgram *shader.Shader) {
 			colors = [3]mgl32.Vec3{triangleColorBack, triangleColorBack, triangleColorBack}
 			item = triangle.New(coords, colors, shaderProgram)
 			item.SetDirection(mgl32.Vec3{0, 0, 1})
-			item.SetSpeed(float32(1.0) / float32(1000000000.0))
+			item.SetSpeed(float32(1.0))
 			app.AddItem(item)
 		}
 	}
 }
 
-// Update the z coordinates of the vectors.
-func Update() {
-	nowNano := time.Now().UnixNano()
-	delta := float64(nowNano - lastUpdate)
-	moveTime := delta / float64(time.Millisecond)
-	app.Update(delta)
-	lastUpdate = nowNano
-
+// Update moves the camera based on the pressed keys and the mouse position.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = moveSpeed * moveTime
+		forward = moveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -moveSpeed * moveTime
+		forward = -moveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horisontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horisontal = -moveSpeed * moveTime
+		horisontal = -moveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horisontal = moveSpeed * moveTime
+		horisontal = moveSpeed * dt
 	}
 	if horisontal != 0 {
 		app.GetCamera().Strafe(float32(horisontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -moveSpeed * moveTime
+		vertical = -moveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = moveSpeed * moveTime
+		vertical = moveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
 
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -192,18 +183,12 @@ func main() {
 
 	GenerateTriangles(shaderProgram)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
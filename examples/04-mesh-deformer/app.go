@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
@@ -30,8 +29,6 @@ var (
 
 	triangleColorFront = mgl32.Vec3{0, 0, 1}
 	triangleColorBack  = mgl32.Vec3{0, 0.5, 1}
-
-	lastUpdate int64
 )
 
 // It creates a new camera with the necessary setup
@@ -57,7 +54,7 @@ func GenerateTriangles(shaderProgram *shader.Shader) {
 			colors := [3]mgl32.Vec3{triangleColorFront, triangleColorFront, triangleColorFront}
 			item := triangle.New(coords, colors, shaderProgram)
 			item.SetDirection(mgl32.Vec3{0, 0, 1})
-			item.SetSpeed(float32(1.0) / float32(1000000000.0))
+			item.SetSpeed(float32(1.0))
 			app.AddItem(item)
 
 			coords = [3]mgl32.Vec3{
@@ -68,21 +65,12 @@ func GenerateTriangles(shaderProgram *shader.Shader) {
 			colors = [3]mgl32.Vec3{triangleColorBack, triangleColorBack, triangleColorBack}
 			item = triangle.New(coords, colors, shaderProgram)
 			item.SetDirection(mgl32.Vec3{0, 0, 1})
-			item.SetSpeed(float32(1.0) / float32(1000000000.0))
+			item.SetSpeed(float32(1.0))
 			app.AddItem(item)
 		}
 	}
 }
 
-// Update the z coordinates of the vectors.
-func Update() {
-	now := time.Now().UnixNano()
-	delta := float64(now - lastUpdate)
-	app.Update(delta)
-	lastUpdate = now
-
-}
-
 func main() {
 	runtime.LockOSThread()
 
@@ -97,18 +85,11 @@ func main() {
 
 	GenerateTriangles(shaderProgram)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+	app.Run()
 }
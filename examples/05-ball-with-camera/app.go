@@ -2,14 +2,12 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -22,8 +20,8 @@ const (
 	WindowHeight = 800
 	WindowTitle  = "Example - plane with ball"
 
-	moveSpeed = 1.0 / 100.0
-	ballSpeed = float32(1.0 / 10000000.0 / 5.0)
+	moveSpeed = 10.0
+	ballSpeed = float32(20.0)
 
 	FORWARD  = glfw.KeyW
 	BACKWARD = glfw.KeyS
@@ -38,7 +36,6 @@ var (
 	ball          *sphere.Sphere
 	BallPrecision = 10
 
-	lastUpdate           int64
 	cameraDistance       = 0.1
 	cameraDirectionSpeed = float32(0.005)
 
@@ -78,11 +75,9 @@ func GenerateSquare(shaderProgram *shader.Shader) {
 	app.AddItem(square)
 }
 
-// Update the z coordinates of the vectors.
-func Update() {
-	nowNano := time.Now().UnixNano()
-	delta := float64(nowNano - lastUpdate)
-	moveTime := delta / float64(time.Millisecond)
+// Update turns back the ball at the top and bottom positions and moves the camera.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	// handle ball
 	if ball.GetCenter().Y() <= BallTopPosition {
 		ball.SetCenter(mgl32.Vec3{ball.GetCenter().X(), BallTopPosition, ball.GetCenter().Z()})
@@ -92,39 +87,37 @@ func Update() {
 		ball.SetCenter(mgl32.Vec3{ball.GetCenter().X(), BallBottomPosition, ball.GetCenter().Z()})
 		ball.SetDirection(BallInitialDirection)
 	}
-	app.Update(delta)
-	lastUpdate = nowNano
 
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = moveSpeed * moveTime
+		forward = moveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -moveSpeed * moveTime
+		forward = -moveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horisontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horisontal = -moveSpeed * moveTime
+		horisontal = -moveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horisontal = moveSpeed * moveTime
+		horisontal = moveSpeed * dt
 	}
 	if horisontal != 0 {
 		app.GetCamera().Strafe(float32(horisontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -moveSpeed * moveTime
+		vertical = -moveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = moveSpeed * moveTime
+		vertical = moveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
 
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -184,15 +177,9 @@ func main() {
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		Update()
-		app.DrawWithUniforms()
-		glfw.PollEvents()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
@@ -19,8 +18,8 @@ const (
 	WindowWidth  = 800
 	WindowHeight = 800
 	WindowTitle  = "Example - the house"
-	moveSpeed    = 1.0 / 100.0
-	epsilon      = 1000.0
+	moveSpeed    = 10.0
+	epsilon      = 1.0
 	precision    = 20
 	// buttons
 	FORWARD  = glfw.KeyW // Go forward
@@ -30,7 +29,7 @@ const (
 )
 
 var (
-	cameraLastUpdate int64
+	cameraUpdateTime float64
 	app              *application.Application
 )
 
@@ -202,16 +201,15 @@ func RoomRight3(shaderProg *shader.Shader) {
 	addRect(coordinates, rectColor, shaderProg)
 }
 
-func Update() {
-	//calculate delta
-	nowUnix := time.Now().UnixNano()
-	delta := nowUnix - cameraLastUpdate
-	moveTime := float64(delta / int64(time.Millisecond))
-
-	if epsilon > moveTime {
+// Update moves the camera based on the pressed keys. The camera is updated at most
+// once in every epsilon seconds. It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	cameraUpdateTime += dt
+	if epsilon > cameraUpdateTime {
 		return
 	}
-	cameraLastUpdate = nowUnix
+	moveTime := cameraUpdateTime
+	cameraUpdateTime = 0
 
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
@@ -245,7 +243,6 @@ func main() {
 	shaderProgram := shader.NewShader("examples/05-house-with-camera/vertexshader.vert", "examples/05-house-with-camera/fragmentshader.frag")
 
 	app.SetCamera(CreateCamera())
-	cameraLastUpdate = time.Now().UnixNano()
 
 	app.SetKeys(SetupKeyMap())
 	Path(shaderProgram)
@@ -272,11 +269,6 @@ func main() {
 	// register mouse button callback
 	app.GetWindow().SetMouseButtonCallback(window.DummyMouseButtonCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
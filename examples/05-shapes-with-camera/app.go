@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
@@ -10,7 +9,6 @@ import (
 	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -30,13 +28,12 @@ const (
 	UP       = glfw.KeyQ
 	DOWN     = glfw.KeyE
 
-	moveSpeed = 0.005
+	moveSpeed = 5.0
 )
 
 var (
 	app *application.Application
 
-	lastUpdate           int64
 	cameraDistance       = 0.1
 	cameraDirectionSpeed = float32(0.005)
 )
@@ -61,44 +58,39 @@ func SetupKeyMap() map[glfw.Key]bool {
 	return keyDowns
 }
 
-// Update the z coordinates of the vectors.
-func Update() {
-	nowNano := time.Now().UnixNano()
-	delta := float64(nowNano - lastUpdate)
-	moveTime := delta / float64(time.Millisecond)
-	app.Update(delta)
-	lastUpdate = nowNano
-
+// Update moves the camera based on the pressed keys and the mouse position.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = moveSpeed * moveTime
+		forward = moveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -moveSpeed * moveTime
+		forward = -moveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horisontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horisontal = -moveSpeed * moveTime
+		horisontal = -moveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horisontal = moveSpeed * moveTime
+		horisontal = moveSpeed * dt
 	}
 	if horisontal != 0 {
 		app.GetCamera().Strafe(float32(horisontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -moveSpeed * moveTime
+		vertical = -moveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = moveSpeed * moveTime
+		vertical = moveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
 
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -195,15 +187,9 @@ func main() {
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
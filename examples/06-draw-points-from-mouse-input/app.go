@@ -7,7 +7,6 @@ import (
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/point"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -30,9 +29,11 @@ var (
 	points *point.Points
 )
 
-func Update() {
+// Update adds a new point to the clicked position after the mouse button is released.
+// It's called in every fixed update step.
+func Update(dt float64) {
 	if !app.GetMouseButtonState(LEFT_MOUSE_BUTTON) && addPoint {
-		mX, mY := trans.MouseCoordinates(app.MousePosX, app.MousePosY, WindowWidth, WindowHeight)
+		mX, mY := app.MouseCoordinates(app.MousePosX, app.MousePosY)
 		coords := mgl32.Vec3{float32(mX), float32(mY), 0.0}
 		color := mgl32.Vec3{rand.Float32(), rand.Float32(), rand.Float32()}
 		size := float32(3 + rand.Intn(17))
@@ -57,13 +58,6 @@ func main() {
 
 	wrapper.Enable(wrapper.PROGRAM_POINT_SIZE)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		if points.Count() > 0 {
-			app.Draw()
-		}
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
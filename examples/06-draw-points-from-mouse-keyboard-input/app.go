@@ -1,13 +1,14 @@
 package main
 
 import (
+	"flag"
 	"math/rand"
+	"os"
 	"runtime"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/point"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -32,9 +33,14 @@ var (
 	BLUE  = glfw.KeyB // blue color component
 
 	LEFT_MOUSE_BUTTON = glfw.MouseButtonLeft
+
+	recordFile = flag.String("record", "", "The input events are recorded to this file.")
+	replayFile = flag.String("replay", "", "The input events are replayed from this file.")
 )
 
-func Update() {
+// Update adds a new point to the clicked position after the mouse button is released.
+// It's called in every fixed update step.
+func Update(dt float64) {
 	if !app.GetMouseButtonState(LEFT_MOUSE_BUTTON) && addPoint {
 		var r, g, b float32
 		if app.GetKeyState(RED) {
@@ -52,7 +58,7 @@ func Update() {
 		} else {
 			b = 0
 		}
-		mX, mY := trans.MouseCoordinates(app.MousePosX, app.MousePosY, WindowWidth, WindowHeight)
+		mX, mY := app.MouseCoordinates(app.MousePosX, app.MousePosY)
 		coords := mgl32.Vec3{float32(mX), float32(mY), 0.0}
 		color := mgl32.Vec3{r, g, b}
 		size := float32(3 + rand.Intn(17))
@@ -63,7 +69,39 @@ func Update() {
 	}
 }
 
+// SetupRecording starts the recording or the replay based on the flags. It
+// returns the function that closes the recording file.
+func SetupRecording() func() {
+	if *replayFile != "" {
+		f, err := os.Open(*replayFile)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		events, err := application.LoadRecording(f)
+		if err != nil {
+			panic(err)
+		}
+		app.Replay(events)
+	}
+	if *recordFile != "" {
+		f, err := os.Create(*recordFile)
+		if err != nil {
+			panic(err)
+		}
+		app.StartRecording(f)
+		return func() {
+			if err := app.StopRecording(); err != nil {
+				panic(err)
+			}
+			f.Close()
+		}
+	}
+	return func() {}
+}
+
 func main() {
+	flag.Parse()
 	runtime.LockOSThread()
 	app = application.New()
 	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
@@ -79,13 +117,7 @@ func main() {
 	wrapper.Enable(wrapper.PROGRAM_POINT_SIZE)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		if points.Count() > 0 {
-			app.Draw()
-		}
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	defer SetupRecording()()
+	app.Run()
 }
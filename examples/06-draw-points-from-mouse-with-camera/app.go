@@ -3,13 +3,11 @@ package main
 import (
 	"math/rand"
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/point"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -26,9 +24,9 @@ const (
 var (
 	addPoint = false
 
-	cameraLastUpdate int64
-	moveSpeed        = 1.0 / 100.0
-	epsilon          = 100.0
+	cameraUpdateTime float64
+	moveSpeed        = 10.0
+	epsilon          = 0.1
 
 	app    *application.Application
 	points *point.Points
@@ -71,7 +69,7 @@ func updatePointState() {
 		} else {
 			b = 0
 		}
-		mX, mY := trans.MouseCoordinates(app.MousePosX, app.MousePosY, WindowWidth, WindowHeight)
+		mX, mY := app.MouseCoordinates(app.MousePosX, app.MousePosY)
 		// to calculate the coordinate of the point, we have to apply the inverse of the camera transformations.
 		V := app.GetCamera().GetViewMatrix()
 		P := app.GetCamera().GetProjectionMatrix()
@@ -85,16 +83,13 @@ func updatePointState() {
 		addPoint = true
 	}
 }
-func updateCameraState() {
-	//calculate delta
-	nowUnix := time.Now().UnixNano()
-	delta := nowUnix - cameraLastUpdate
-	moveTime := float64(delta / int64(time.Millisecond))
-
-	if epsilon > moveTime {
+func updateCameraState(dt float64) {
+	cameraUpdateTime += dt
+	if epsilon > cameraUpdateTime {
 		return
 	}
-	cameraLastUpdate = nowUnix
+	moveTime := cameraUpdateTime
+	cameraUpdateTime = 0
 
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
@@ -116,9 +111,11 @@ func updateCameraState() {
 		app.GetCamera().UpdateDirection(dX, dY)
 	}
 }
-func Update() {
+
+// Update is called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	updatePointState()
-	updateCameraState()
+	updateCameraState(dt)
 }
 func main() {
 	runtime.LockOSThread()
@@ -141,13 +138,6 @@ func main() {
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
-		Update()
-		if points.Count() > 0 {
-			app.DrawWithUniforms()
-		}
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
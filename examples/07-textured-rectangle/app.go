@@ -38,10 +38,6 @@ func GenerateSquare(shaderProgram *shader.Shader) {
 	app.AddItem(square)
 }
 
-// Update the z coordinates of the vectors.
-func Update() {
-}
-
 func main() {
 	runtime.LockOSThread()
 
@@ -58,11 +54,5 @@ func main() {
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		Update()
-		app.Draw()
-		glfw.PollEvents()
-		app.GetWindow().SwapBuffers()
-	}
+	app.Run()
 }
@@ -2,21 +2,22 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/gui"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/text"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
 	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
 )
 
 const (
@@ -31,17 +32,15 @@ const (
 	UP       = glfw.KeyQ
 	DOWN     = glfw.KeyE
 
-	CameraMoveSpeed      = 0.005
 	CameraDirectionSpeed = float32(0.00500)
 
-	LightSourceRoundSpeed = 3000.0
+	// the light source takes a round in LightSourceRoundSpeed seconds.
+	LightSourceRoundSpeed = 3.0
 )
 
 var (
 	app *application.Application
 
-	lastUpdate int64
-
 	cameraDistance  = 0.1
 	LightSource     *light.Light
 	LightSourceCube *cuboid.Cuboid
@@ -49,6 +48,12 @@ var (
 
 	InitialCenterPointLight = mgl32.Vec3{-3, 0, -3}
 	CenterPointObject       = mgl32.Vec3{0, 0, 0}
+
+	// the parameters below could be tuned with the gui.
+	CameraMoveSpeed = float32(5.0)
+	RotateLight     = true
+	JadeMaterial    = material.New(material.Jade.GetAmbient(), material.Jade.GetDiffuse(), material.Jade.GetSpecular(), material.Jade.GetShininess())
+	Gui             *gui.Context
 )
 
 // It creates a new camera with the necessary setup
@@ -102,7 +107,7 @@ func GenerateJadeCube(shaderProgram *shader.Shader) {
 	bottomRect := rectangle.NewSquare(mgl32.Vec3{0.5, -0.5, -0.5}, mgl32.Vec3{-0.5, -0.5, 0.5}, mgl32.Vec3{0, 1, 0}, mgl32.Vec3{0.0, 1.0, 1.0}, shaderProgram)
 	JadeCube = cuboid.New(bottomRect, 1.0, shaderProgram)
 	JadeCube.SetPrecision(5)
-	JadeCube.SetMaterial(material.Jade)
+	JadeCube.SetMaterial(JadeMaterial)
 	JadeCube.DrawMode(cuboid.DRAW_MODE_LIGHT)
 	app.AddItem(JadeCube)
 }
@@ -147,51 +152,55 @@ func GenerateSilverCube(shaderProgram *shader.Shader) {
 	app.AddItem(redPlasticCube)
 }
 
-func Update() {
-	nowNano := time.Now().UnixNano()
-	moveTime := float64(nowNano-lastUpdate) / float64(time.Millisecond)
-	lastUpdate = nowNano
+// Update rotates the light source around the cube and moves the camera.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	// Calculate the  rotation matrix. Get the current one, rotate it with a calculated angle around the Y axis. (HomogRotate3D(angle float32, axis Vec3) Mat4)
 	// angle calculation: (360 / LightSourceRoundSpeed) * delta) -> in radian: mat32.DegToRad()
 	// Then we can transform the current direction vector to the new one. (TransformNormal(v Vec3, m Mat4) Vec3)
 	// after it we can set the new direction vector of the light source.
-	lightSourceRotationAngleRadian := mgl32.DegToRad(float32((360 / LightSourceRoundSpeed) * moveTime))
-	lightDirectionRotationMatrix := mgl32.HomogRotate3D(lightSourceRotationAngleRadian, mgl32.Vec3{0, -1, 0})
-	currentLightSourceDirection := LightSourceCube.GetDirection()
-	LightSourceCube.SetDirection(mgl32.TransformNormal(currentLightSourceDirection, lightDirectionRotationMatrix))
-	LightSource.SetPosition(LightSourceCube.GetCenterPoint())
-
-	app.Update(moveTime)
+	if RotateLight {
+		lightSourceRotationAngleRadian := mgl32.DegToRad(float32((360 / LightSourceRoundSpeed) * dt))
+		lightDirectionRotationMatrix := mgl32.HomogRotate3D(lightSourceRotationAngleRadian, mgl32.Vec3{0, -1, 0})
+		currentLightSourceDirection := LightSourceCube.GetDirection()
+		LightSourceCube.SetDirection(mgl32.TransformNormal(currentLightSourceDirection, lightDirectionRotationMatrix))
+		LightSource.SetPosition(LightSourceCube.GetCenterPoint())
+	}
 
+	moveSpeed := float64(CameraMoveSpeed)
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = CameraMoveSpeed * moveTime
+		forward = moveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -CameraMoveSpeed * moveTime
+		forward = -moveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horisontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horisontal = -CameraMoveSpeed * moveTime
+		horisontal = -moveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horisontal = CameraMoveSpeed * moveTime
+		horisontal = moveSpeed * dt
 	}
 	if horisontal != 0 {
 		app.GetCamera().Strafe(float32(horisontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -CameraMoveSpeed * moveTime
+		vertical = -moveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = CameraMoveSpeed * moveTime
+		vertical = moveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
+	// the camera isn't rotated while the gui is used.
+	if Gui.WantsMouse() {
+		return
+	}
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -232,6 +241,42 @@ func Update() {
 	}
 	app.GetCamera().UpdateDirection(dX, dY)
 }
+
+// DrawGui displays the panels of the light, the jade cube and the camera.
+func DrawGui() {
+	// the window could be resized, the gui follows its size.
+	width, height := app.GetWindowSize()
+	Gui.SetScreenSize(float32(width), float32(height))
+	Gui.Begin(app)
+	Gui.Panel("Light", 10, 10, 220)
+	ambient, diffuse, specular := LightSource.GetAmbient(), LightSource.GetDiffuse(), LightSource.GetSpecular()
+	if Gui.ColorPicker("Ambient", &ambient) {
+		LightSource.SetAmbient(ambient)
+	}
+	if Gui.ColorPicker("Diffuse", &diffuse) {
+		LightSource.SetDiffuse(diffuse)
+	}
+	if Gui.ColorPicker("Specular", &specular) {
+		LightSource.SetSpecular(specular)
+	}
+	Gui.Checkbox("Rotate", &RotateLight)
+
+	Gui.Panel("Jade cube", float32(width)-230, 10, 220)
+	shininess := JadeMaterial.GetShininess()
+	if Gui.Slider("Shininess", &shininess, 1, 256) {
+		JadeMaterial.SetShininess(shininess)
+	}
+	if Gui.Button("Reset") {
+		JadeMaterial.SetAmbient(material.Jade.GetAmbient())
+		JadeMaterial.SetDiffuse(material.Jade.GetDiffuse())
+		JadeMaterial.SetSpecular(material.Jade.GetSpecular())
+		JadeMaterial.SetShininess(material.Jade.GetShininess())
+	}
+
+	Gui.Panel("Camera", float32(width)-230, 120, 220)
+	Gui.Slider("Speed", &CameraMoveSpeed, 0.5, 20)
+	Gui.End()
+}
 func main() {
 	runtime.LockOSThread()
 
@@ -254,21 +299,26 @@ func main() {
 	shaderProgramWhite.AddPointLightSource(LightSource, [7]string{"light.position", "light.ambient", "light.diffuse", "light.specular", "", "", ""})
 	GenerateWhiteCube(shaderProgramWhite)
 
+	font, err := text.LoadTTF(goregular.TTF, 14, text.DEFAULT_CHARSET)
+	if err != nil {
+		panic(err)
+	}
+	Gui = gui.New(gui.NewShader(font), font)
+
 	wrapper.Enable(wrapper.DEPTH_TEST)
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	// register mouse button callback
+	app.GetWindow().SetMouseButtonCallback(app.MouseButtonCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
+	app.SetRenderCallback(func(alpha float64) {
 		shaderProgramColored.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
 		shaderProgramWhite.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+		DrawGui()
+	})
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
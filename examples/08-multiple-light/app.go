@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	"github.com/akosgarai/opengl_playground/pkg/composite/bug"
@@ -13,7 +12,6 @@ import (
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -33,9 +31,9 @@ const (
 	UP       = glfw.KeyQ
 	DOWN     = glfw.KeyE
 
-	moveSpeed            = 0.005
+	moveSpeed            = 5.0
 	cameraDirectionSpeed = float32(0.100)
-	CameraMoveSpeed      = 0.005
+	CameraMoveSpeed      = 5.0
 	cameraDistance       = 0.1
 )
 
@@ -43,8 +41,7 @@ var (
 	app                       *application.Application
 	Bug1                      *bug.Bug
 	Bug2                      *bug.Bug
-	BugOneLastRotate          int64
-	lastUpdate                int64
+	BugOneLastRotate          float64
 	ShaderProgramsWithViewPos []*shader.Shader
 	DirectionalLightSource    *light.Light
 	PointLightSource_1        *light.Light
@@ -52,7 +49,7 @@ var (
 	SpotLightSource_1         *light.Light
 	SpotLightSource_2         *light.Light
 
-	BugOneForwardMove         = float64(1000)
+	BugOneForwardMove         = float64(1.0)
 	DirectionalLightDirection = (mgl32.Vec3{0.7, 0.7, 0.7}).Normalize()
 	DirectionalLightAmbient   = mgl32.Vec3{0.1, 0.1, 0.1}
 	DirectionalLightDiffuse   = mgl32.Vec3{0.1, 0.1, 0.1}
@@ -152,9 +149,11 @@ func CreateCamera() *camera.Camera {
 	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 1000.0)
 	return camera
 }
-func RotateBugOne(now int64) {
-	moveTime := float64(now-BugOneLastRotate) / float64(time.Millisecond)
-	if moveTime > BugOneForwardMove {
+
+// RotateBugOne turns the bug after every BugOneForwardMove seconds. The input is the
+// elapsed time of the application in seconds.
+func RotateBugOne(now float64) {
+	if now-BugOneLastRotate > BugOneForwardMove {
 		BugOneLastRotate = now
 		// rotate 45 deg
 		rotationAngleRadian := mgl32.DegToRad(-45)
@@ -164,47 +163,45 @@ func RotateBugOne(now int64) {
 		Bug1.SetDirection(mgl32.TransformNormal(currenDirection, directionRotationMatrix))
 	}
 }
-func Update() {
-	nowNano := time.Now().UnixNano()
-	moveTime := float64(nowNano-lastUpdate) / float64(time.Millisecond)
-	lastUpdate = nowNano
+
+// Update moves the bugs and the camera. It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	for index, _ := range ShaderProgramsWithViewPos {
 		ShaderProgramsWithViewPos[index].SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
 	}
-	RotateBugOne(nowNano)
+	RotateBugOne(app.GetElapsedTime())
 	PointLightSource_1.SetPosition(Bug1.GetCenterPoint())
 	PointLightSource_2.SetPosition(Bug2.GetCenterPoint())
-	app.Update(moveTime)
 
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = moveSpeed * moveTime
+		forward = moveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -moveSpeed * moveTime
+		forward = -moveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horizontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horizontal = -moveSpeed * moveTime
+		horizontal = -moveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horizontal = moveSpeed * moveTime
+		horizontal = moveSpeed * dt
 	}
 	if horizontal != 0 {
 		app.GetCamera().Strafe(float32(horizontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -moveSpeed * moveTime
+		vertical = -moveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = moveSpeed * moveTime
+		vertical = moveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -330,16 +327,9 @@ func main() {
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.0, 0.0, 0.0, 1.0)
 
-	lastUpdate = time.Now().UnixNano()
-	BugOneLastRotate = lastUpdate
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		Update()
-		app.DrawWithUniforms()
-		glfw.PollEvents()
-		app.GetWindow().SwapBuffers()
-	}
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
@@ -2,7 +2,6 @@ package main
 
 import (
 	"runtime"
-	"time"
 
 	"github.com/akosgarai/opengl_playground/pkg/application"
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
@@ -10,7 +9,6 @@ import (
 	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
-	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/window"
 
@@ -30,17 +28,16 @@ const (
 	UP       = glfw.KeyQ
 	DOWN     = glfw.KeyE
 
-	CameraMoveSpeed      = 0.005
+	CameraMoveSpeed      = 5.0
 	CameraDirectionSpeed = float32(0.00500)
 
-	LightSourceRoundSpeed = 3000.0
+	// the light source takes a round in LightSourceRoundSpeed seconds.
+	LightSourceRoundSpeed = 3.0
 )
 
 var (
 	app *application.Application
 
-	lastUpdate int64
-
 	cameraDistance    = 0.1
 	LightSource       *light.Light
 	LightSourceSphere *sphere.Sphere
@@ -88,51 +85,48 @@ func GenerateRedPlasticSphere(shaderProgram *shader.Shader) {
 	app.AddItem(sp)
 }
 
-func Update() {
-	nowNano := time.Now().UnixNano()
-	moveTime := float64(nowNano-lastUpdate) / float64(time.Millisecond)
-	lastUpdate = nowNano
+// Update rotates the light source around the spheres and moves the camera.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
 	// Calculate the  rotation matrix. Get the current one, rotate it with a calculated angle around the Y axis. (HomogRotate3D(angle float32, axis Vec3) Mat4)
 	// angle calculation: (360 / LightSourceRoundSpeed) * delta) -> in radian: mat32.DegToRad()
 	// Then we can transform the current direction vector to the new one. (TransformNormal(v Vec3, m Mat4) Vec3)
 	// after it we can set the new direction vector of the light source.
-	lightSourceRotationAngleRadian := mgl32.DegToRad(float32((360 / LightSourceRoundSpeed) * moveTime))
+	lightSourceRotationAngleRadian := mgl32.DegToRad(float32((360 / LightSourceRoundSpeed) * dt))
 	lightDirectionRotationMatrix := mgl32.HomogRotate3D(lightSourceRotationAngleRadian, mgl32.Vec3{0, -1, 0})
 	currentLightSourceDirection := LightSourceSphere.GetDirection()
 	LightSourceSphere.SetDirection(mgl32.TransformNormal(currentLightSourceDirection, lightDirectionRotationMatrix))
 	LightSource.SetPosition(LightSourceSphere.GetCenterPoint())
 
-	app.Update(moveTime)
-
 	forward := 0.0
 	if app.GetKeyState(FORWARD) && !app.GetKeyState(BACKWARD) {
-		forward = CameraMoveSpeed * moveTime
+		forward = CameraMoveSpeed * dt
 	} else if app.GetKeyState(BACKWARD) && !app.GetKeyState(FORWARD) {
-		forward = -CameraMoveSpeed * moveTime
+		forward = -CameraMoveSpeed * dt
 	}
 	if forward != 0 {
 		app.GetCamera().Walk(float32(forward))
 	}
 	horisontal := 0.0
 	if app.GetKeyState(LEFT) && !app.GetKeyState(RIGHT) {
-		horisontal = -CameraMoveSpeed * moveTime
+		horisontal = -CameraMoveSpeed * dt
 	} else if app.GetKeyState(RIGHT) && !app.GetKeyState(LEFT) {
-		horisontal = CameraMoveSpeed * moveTime
+		horisontal = CameraMoveSpeed * dt
 	}
 	if horisontal != 0 {
 		app.GetCamera().Strafe(float32(horisontal))
 	}
 	vertical := 0.0
 	if app.GetKeyState(UP) && !app.GetKeyState(DOWN) {
-		vertical = -CameraMoveSpeed * moveTime
+		vertical = -CameraMoveSpeed * dt
 	} else if app.GetKeyState(DOWN) && !app.GetKeyState(UP) {
-		vertical = CameraMoveSpeed * moveTime
+		vertical = CameraMoveSpeed * dt
 	}
 	if vertical != 0 {
 		app.GetCamera().Lift(float32(vertical))
 	}
 	currX, currY := app.GetWindow().GetCursorPos()
-	x, y := trans.MouseCoordinates(currX, currY, WindowWidth, WindowHeight)
+	x, y := app.MouseCoordinates(currX, currY)
 	KeyDowns := make(map[string]bool)
 	// dUp
 	if y > 1.0-cameraDistance && y < 1.0 {
@@ -196,17 +190,13 @@ func main() {
 	wrapper.DepthFunc(wrapper.LESS)
 	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
 
-	lastUpdate = time.Now().UnixNano()
 	// register keyboard button callback
 	app.GetWindow().SetKeyCallback(app.KeyCallback)
 
-	for !app.GetWindow().ShouldClose() {
-		wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
-		glfw.PollEvents()
+	app.SetRenderCallback(func(alpha float64) {
 		shaderProgramColored.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
 		shaderProgramWhite.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
-		Update()
-		app.DrawWithUniforms()
-		app.GetWindow().SwapBuffers()
-	}
+	})
+	app.SetUpdateCallback(Update)
+	app.Run()
 }
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/text"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - text rendering"
+
+	moveSpeed = 5.0
+	lookSpeed = 90.0
+	fontSize  = 32
+)
+
+var (
+	app *application.Application
+
+	cameraDistance       = 0.1
+	cameraDirectionSpeed = float32(0.00500)
+
+	statsText *text.Text
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 0, 10.0}, mgl32.Vec3{0, 1, 0}, -90.0, 0.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 100.0)
+	return camera
+}
+
+// It generates a cube.
+func GenerateCube(shaderProgram *shader.Shader) {
+	colors := [6]mgl32.Vec3{
+		mgl32.Vec3{1.0, 0.0, 0.0},
+		mgl32.Vec3{1.0, 1.0, 0.0},
+		mgl32.Vec3{0.0, 1.0, 0.0},
+		mgl32.Vec3{0.0, 1.0, 1.0},
+		mgl32.Vec3{0.0, 0.0, 1.0},
+		mgl32.Vec3{1.0, 0.0, 1.0},
+	}
+	bottomCoordinates := [4]mgl32.Vec3{
+		mgl32.Vec3{-0.5, -0.5, -0.5},
+		mgl32.Vec3{-0.5, -0.5, 0.5},
+		mgl32.Vec3{0.5, -0.5, 0.5},
+		mgl32.Vec3{0.5, -0.5, -0.5},
+	}
+	bottomColor := [4]mgl32.Vec3{colors[0], colors[0], colors[0], colors[0]}
+	bottomRect := rectangle.New(bottomCoordinates, bottomColor, shaderProgram)
+	cube := cuboid.New(bottomRect, 1.0, shaderProgram)
+	for i := 0; i < 6; i++ {
+		cube.SetSideColor(i, colors[i])
+	}
+	app.AddItem(cube)
+}
+
+// It generates the label of the cube and the stats text.
+func GenerateTexts(font *text.Font, shaderProgram *shader.Shader) {
+	label := text.New("The cube", font, shaderProgram)
+	label.DrawMode(text.DRAW_MODE_BILLBOARD)
+	label.SetScale(0.02)
+	width, _ := font.Measure(label.GetText())
+	label.SetPosition(mgl32.Vec3{-width * 0.02 / 2, 1.5, 0})
+	label.SetColor(mgl32.Vec3{1, 1, 0})
+	app.AddItem(label)
+
+	statsText = text.New("", font, shaderProgram)
+	statsText.SetScreenSize(WindowWidth, WindowHeight)
+	statsText.SetPosition(mgl32.Vec3{10, 10, 0})
+	statsText.SetScale(0.5)
+	app.AddItem(statsText)
+}
+
+// UpdateStats writes the average frame statistics to the screen text.
+func UpdateStats(alpha float64) {
+	stats := app.GetAverageFrameStats()
+	fps := 0.0
+	if stats.Frame > 0 {
+		fps = 1.0 / stats.Frame.Seconds()
+	}
+	statsText.SetText(fmt.Sprintf("FPS: %.1f\nDraw: %s\nDraw calls: %d\nTriangles: %d\nSkipped state changes: %d\nCulled objects: %d", fps, stats.Draw, stats.DrawCalls, stats.Triangles, stats.SkippedStateChanges, stats.CulledObjects))
+}
+
+// Update moves the camera based on the input map and the mouse position. The
+// C key toggles the cursor capture, the right stick of the gamepad rotates the camera.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if m.Pressed("capture") {
+		app.SetCursorCaptured(!app.IsCursorCaptured())
+	}
+	// the captured cursor rotates the camera with the mouse look.
+	if app.IsCursorCaptured() {
+		return
+	}
+	currX, currY := app.GetWindow().GetCursorPos()
+	x, y := app.MouseCoordinates(currX, currY)
+	dX := float32(0.0)
+	dY := float32(0.0)
+	if y > 1.0-cameraDistance && y < 1.0 {
+		dY = cameraDirectionSpeed
+	} else if y < -1.0+cameraDistance && y > -1.0 {
+		dY = -cameraDirectionSpeed
+	}
+	if x < -1.0+cameraDistance && x > -1.0 {
+		dX = -cameraDirectionSpeed
+	} else if x > 1.0-cameraDistance && x < 1.0 {
+		dX = cameraDirectionSpeed
+	}
+	app.GetCamera().UpdateDirection(dX, dY)
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app.SetCamera(CreateCamera())
+	inputMap := input.NewCameraMap()
+	inputMap.BindAction("capture", input.KeyBinding(glfw.KeyC, 0))
+	app.SetInputMap(inputMap)
+	app.SetMouseLook(0.1)
+	app.SetScrollZoom(2, application.DEFAULT_MIN_FOV, application.DEFAULT_MAX_FOV)
+	// the gamepads also move the camera, the right stick rotates it.
+	app.SetJoystickSource(application.GLFWJoysticks{})
+
+	shaderProgram := shader.NewShader("examples/09-text/vertexshader.vert", "examples/09-text/fragmentshader.frag")
+	GenerateCube(shaderProgram)
+
+	font, err := text.LoadTTF(goregular.TTF, fontSize, text.DEFAULT_CHARSET)
+	if err != nil {
+		panic(err)
+	}
+	GenerateTexts(font, text.NewShader(font))
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard button callback
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	// register the mouse callbacks
+	app.GetWindow().SetCursorPosCallback(app.CursorPosCallback)
+	app.GetWindow().SetScrollCallback(app.ScrollCallback)
+
+	// the screen text follows the size of the window.
+	app.SetResizeCallback(func(int, int) {
+		width, height := app.GetWindowSize()
+		statsText.SetScreenSize(float32(width), float32(height))
+	})
+	app.SetUpdateCallback(Update)
+	app.SetRenderCallback(UpdateStats)
+	app.Run()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - transparent windows"
+
+	moveSpeed = 5.0
+	lookSpeed = 90.0
+)
+
+var (
+	app *application.Application
+
+	cameraDistance       = 0.1
+	cameraDirectionSpeed = float32(0.00500)
+
+	LightPosition = mgl32.Vec3{-3, 5, 8}
+
+	// the B key switches between these blend modes.
+	blendModes     = []int{wrapper.BLEND_MODE_ALPHA, wrapper.BLEND_MODE_ADDITIVE, wrapper.BLEND_MODE_PREMULTIPLIED}
+	blendModeNames = []string{"alpha", "additive", "premultiplied"}
+	blendModeIndex = 0
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 0, 12.0}, mgl32.Vec3{0, 1, 0}, -90.0, 0.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 100.0)
+	return camera
+}
+
+// It generates the opaque spheres behind the windows and the glass sphere
+// between the windows.
+func GenerateSpheres(shaderProgram *shader.Shader) {
+	jade := sphere.New(mgl32.Vec3{-1.5, 0, -4}, mgl32.Vec3{0, 1, 0}, 1.0, shaderProgram)
+	jade.SetMaterial(material.Jade)
+	jade.SetPrecision(20)
+	jade.DrawMode(sphere.DRAW_MODE_LIGHT)
+	app.AddItem(jade)
+
+	ruby := sphere.New(mgl32.Vec3{1.5, 0, -4}, mgl32.Vec3{1, 0, 0}, 1.0, shaderProgram)
+	ruby.SetMaterial(material.Ruby)
+	ruby.SetPrecision(20)
+	ruby.DrawMode(sphere.DRAW_MODE_LIGHT)
+	app.AddItem(ruby)
+
+	// the presets are shared, so the glass material is a copy.
+	glassMaterial := material.New(material.Pearl.GetAmbient(), material.Pearl.GetDiffuse(), material.Pearl.GetSpecular(), 32.0)
+	glassMaterial.SetAlpha(0.35)
+	glass := sphere.New(mgl32.Vec3{0, -0.5, 1}, mgl32.Vec3{1, 1, 1}, 0.75, shaderProgram)
+	glass.SetMaterial(glassMaterial)
+	glass.SetPrecision(20)
+	glass.DrawMode(sphere.DRAW_MODE_LIGHT)
+	app.AddItem(glass)
+}
+
+// It generates the colored window panes. The panes are added in front to back
+// order, the application sorts them back to front.
+func GenerateWindows(shaderProgram *shader.Shader) {
+	colors := []mgl32.Vec3{
+		mgl32.Vec3{1, 0, 0},
+		mgl32.Vec3{0, 1, 0},
+		mgl32.Vec3{0, 0, 1},
+	}
+	for i, color := range colors {
+		x := float32(i-1) * 1.2
+		z := float32(3 - 2*i)
+		pane := rectangle.NewSquare(mgl32.Vec3{x - 1, -1, z}, mgl32.Vec3{x + 1, 1, z}, mgl32.Vec3{0, 0, 1}, color, shaderProgram)
+		pane.SetAlpha(0.5)
+		app.AddItem(pane)
+	}
+}
+
+// Update moves the camera based on the input map and the mouse position. The
+// B key switches the blend mode of the transparent items.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if m.Pressed("blend") {
+		blendModeIndex = (blendModeIndex + 1) % len(blendModes)
+		app.GetRenderQueue().SetBlendMode(blendModes[blendModeIndex])
+		fmt.Printf("Blend mode: %s\n", blendModeNames[blendModeIndex])
+	}
+	currX, currY := app.GetWindow().GetCursorPos()
+	x, y := app.MouseCoordinates(currX, currY)
+	dX := float32(0.0)
+	dY := float32(0.0)
+	if y > 1.0-cameraDistance && y < 1.0 {
+		dY = cameraDirectionSpeed
+	} else if y < -1.0+cameraDistance && y > -1.0 {
+		dY = -cameraDirectionSpeed
+	}
+	if x < -1.0+cameraDistance && x > -1.0 {
+		dX = -cameraDirectionSpeed
+	} else if x > 1.0-cameraDistance && x < 1.0 {
+		dX = cameraDirectionSpeed
+	}
+	app.GetCamera().UpdateDirection(dX, dY)
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app.SetCamera(CreateCamera())
+	inputMap := input.NewCameraMap()
+	inputMap.BindAction("blend", input.KeyBinding(glfw.KeyB, 0))
+	app.SetInputMap(inputMap)
+
+	lightSource := light.NewPointLight([4]mgl32.Vec3{LightPosition, mgl32.Vec3{0.5, 0.5, 0.5}, mgl32.Vec3{1, 1, 1}, mgl32.Vec3{1, 1, 1}}, [3]float32{1.0, 1.0, 1.0})
+	lightShader := shader.NewShader("examples/10-transparent-window/light.vert", "examples/10-transparent-window/fragmentshader.frag")
+	lightShader.AddPointLightSource(lightSource, [7]string{"light.position", "light.ambient", "light.diffuse", "light.specular", "", "", ""})
+	GenerateSpheres(lightShader)
+
+	colorShader := shader.NewShader("examples/10-transparent-window/color.vert", "examples/10-transparent-window/fragmentshader.frag")
+	GenerateWindows(colorShader)
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard button callback
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+
+	app.SetRenderCallback(func(alpha float64) {
+		lightShader.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
+	})
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
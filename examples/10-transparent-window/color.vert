@@ -0,0 +1,12 @@
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec4 vColor;
+smooth out vec4 vSmoothColor;
+uniform mat4 model;
+uniform mat4 view;
+uniform mat4 projection;
+void main()
+{
+    vSmoothColor = vColor;
+    gl_Position = projection * view * model * vec4(vVertex,1);
+}
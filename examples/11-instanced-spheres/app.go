@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/overlay"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/text"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - instanced spheres"
+
+	moveSpeed = 10.0
+	lookSpeed = 90.0
+	fontSize  = 32
+
+	// the spheres are placed to a GridSize x GridSize x GridHeight grid.
+	GridSize   = 20
+	GridHeight = 10
+	GridStep   = 1.5
+	Radius     = 0.4
+	// the spheres are moving up and down with this amplitude.
+	Amplitude = 0.3
+)
+
+var (
+	app *application.Application
+
+	cameraDistance       = 0.1
+	cameraDirectionSpeed = float32(0.00500)
+
+	Spheres   *sphere.InstancedSphere
+	statsText *text.Text
+	// the base centers of the instances and the elapsed time in seconds.
+	centers []mgl32.Vec3
+	elapsed float64
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 5, 40.0}, mgl32.Vec3{0, 1, 0}, -90.0, -10.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 200.0)
+	return camera
+}
+
+// It generates the instances. The color depends on the position in the grid.
+func GenerateSpheres(shaderProgram *shader.Shader) {
+	Spheres = sphere.NewInstanced(shaderProgram)
+	Spheres.SetPrecision(12)
+	Spheres.SetMaterial(material.New(mgl32.Vec3{1, 1, 1}, mgl32.Vec3{1, 1, 1}, mgl32.Vec3{0.5, 0.5, 0.5}, 32.0))
+	offset := float32(GridSize-1) * GridStep / 2
+	for x := 0; x < GridSize; x++ {
+		for y := 0; y < GridHeight; y++ {
+			for z := 0; z < GridSize; z++ {
+				center := mgl32.Vec3{float32(x)*GridStep - offset, float32(y) * GridStep, float32(z)*GridStep - offset}
+				color := mgl32.Vec4{float32(x) / GridSize, float32(y) / GridHeight, float32(z) / GridSize, 1}
+				Spheres.AddInstance(center, Radius, color)
+				centers = append(centers, center)
+			}
+		}
+	}
+	app.AddItem(Spheres)
+}
+
+// It generates the stats text.
+func GenerateStatsText(font *text.Font, shaderProgram *shader.Shader) {
+	statsText = text.New("", font, shaderProgram)
+	statsText.SetScreenSize(WindowWidth, WindowHeight)
+	statsText.SetPosition(mgl32.Vec3{10, 10, 0})
+	statsText.SetScale(0.5)
+	app.AddItem(statsText)
+}
+
+// UpdateStats writes the average frame statistics to the screen text.
+func UpdateStats(alpha float64) {
+	stats := app.GetAverageFrameStats()
+	fps := 0.0
+	if stats.Frame > 0 {
+		fps = 1.0 / stats.Frame.Seconds()
+	}
+	statsText.SetText(fmt.Sprintf("Spheres: %d\nFPS: %.1f\nDraw calls: %d\nTriangles: %d", Spheres.InstanceCount(), fps, stats.DrawCalls, stats.Triangles))
+}
+
+// Update moves the spheres and the camera.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	elapsed += dt
+	for i, center := range centers {
+		instance := Spheres.GetInstance(i)
+		phase := float64(center.X()+center.Z()) * 0.3
+		instance.Center = center.Add(mgl32.Vec3{0, float32(math.Sin(elapsed*2+phase)) * Amplitude, 0})
+		Spheres.SetInstance(i, instance)
+	}
+
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	currX, currY := app.GetWindow().GetCursorPos()
+	x, y := app.MouseCoordinates(currX, currY)
+	dX := float32(0.0)
+	dY := float32(0.0)
+	if y > 1.0-cameraDistance && y < 1.0 {
+		dY = cameraDirectionSpeed
+	} else if y < -1.0+cameraDistance && y > -1.0 {
+		dY = -cameraDirectionSpeed
+	}
+	if x < -1.0+cameraDistance && x > -1.0 {
+		dX = -cameraDirectionSpeed
+	} else if x > 1.0-cameraDistance && x < 1.0 {
+		dX = cameraDirectionSpeed
+	}
+	app.GetCamera().UpdateDirection(dX, dY)
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app.SetCamera(CreateCamera())
+	app.SetInputMap(input.NewCameraMap())
+
+	shaderProgram := shader.NewShader("examples/11-instanced-spheres/vertexshader.vert", "examples/11-instanced-spheres/fragmentshader.frag")
+	GenerateSpheres(shaderProgram)
+
+	font, err := text.LoadTTF(goregular.TTF, fontSize, text.DEFAULT_CHARSET)
+	if err != nil {
+		panic(err)
+	}
+	GenerateStatsText(font, text.NewShader(font))
+	app.SetOverlay(overlay.New(overlay.NewShader()))
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard button callback
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+
+	app.SetResizeCallback(func(int, int) {
+		width, height := app.GetWindowSize()
+		statsText.SetScreenSize(float32(width), float32(height))
+	})
+	app.SetRenderCallback(func(alpha float64) {
+		position := app.GetCamera().GetPosition()
+		shaderProgram.Use()
+		shaderProgram.SetUniform3f("viewPosition", position.X(), position.Y(), position.Z())
+		shaderProgram.SetUniform3f("lightDirection", -0.3, -1.0, -0.5)
+		UpdateStats(alpha)
+	})
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
@@ -0,0 +1,43 @@
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec3 vNormal;
+layout(location = 2) in mat4 instanceModel;
+layout(location = 6) in vec4 instanceColor;
+
+smooth out vec4 vSmoothColor;
+
+struct Material {
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+    float shininess;
+    float alpha;
+};
+
+uniform mat4 view;
+uniform mat4 projection;
+
+uniform Material material;
+
+uniform vec3 lightDirection;
+uniform vec3 viewPosition;
+void main()
+{
+    vec3 worldPosition = vec3(instanceModel * vec4(vVertex,1));
+    // the instances are scaled uniformly, so that the normal vector could be transformed with the model matrix.
+    vec3 normal = normalize(mat3(instanceModel) * vNormal);
+    vec3 lightDir = normalize(-lightDirection);
+
+    // the color of the instance is used as ambient and diffuse color.
+    vec3 ambientColor = 0.2 * instanceColor.rgb;
+    float diff = max(dot(normal, lightDir), 0.0);
+    vec3 diffuseColor = diff * instanceColor.rgb;
+
+    vec3 viewDirection = normalize(viewPosition - worldPosition);
+    vec3 reflectDir = reflect(-lightDir, normal);
+    float spec = pow(max(dot(viewDirection, reflectDir), 0.0), material.shininess);
+    vec3 specularColor = spec * material.specular;
+
+    vSmoothColor = vec4(ambientColor + diffuseColor + specularColor, instanceColor.a);
+    gl_Position = projection * view * vec4(worldPosition,1);
+}
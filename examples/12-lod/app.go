@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/text"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - level of detail"
+
+	moveSpeed = 10.0
+	lookSpeed = 90.0
+	fontSize  = 32
+
+	// the spheres are placed to Rows x Columns grid, the rows are going far from the camera.
+	Rows    = 40
+	Columns = 5
+	Step    = 3.0
+	// the precision of the spheres without level of detail.
+	MaxPrecision = 40
+)
+
+var (
+	app *application.Application
+
+	cameraDistance       = 0.1
+	cameraDirectionSpeed = float32(0.00500)
+
+	LightPosition = mgl32.Vec3{0, 20, 10}
+
+	// the levels of the spheres, the MinSize is the projected height in pixels.
+	Levels = []lod.Level{
+		{MinSize: 200, Precision: MaxPrecision},
+		{MinSize: 80, Precision: 20},
+		{MinSize: 30, Precision: 10},
+		{MinSize: 0, Precision: 5},
+	}
+	Spheres    []*sphere.Sphere
+	lodEnabled = true
+	statsText  *text.Text
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 2, 10.0}, mgl32.Vec3{0, 1, 0}, -90.0, -5.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 200.0)
+	return camera
+}
+
+// It generates the spheres. Every sphere has its own selector, because the
+// selector stores the current level.
+func GenerateSpheres(shaderProgram *shader.Shader) {
+	offset := float32(Columns-1) * Step / 2
+	for row := 0; row < Rows; row++ {
+		for column := 0; column < Columns; column++ {
+			center := mgl32.Vec3{float32(column)*Step - offset, 0, -float32(row) * Step}
+			s := sphere.New(center, mgl32.Vec3{1, 1, 1}, 1.0, shaderProgram)
+			s.SetMaterial(material.Jade)
+			s.DrawMode(sphere.DRAW_MODE_LIGHT)
+			s.SetLOD(lod.NewSelector(Levels...))
+			app.AddItem(s)
+			Spheres = append(Spheres, s)
+		}
+	}
+}
+
+// ToggleLOD enables or disables the level of detail selection. Without it
+// every sphere is drawn with the highest precision.
+func ToggleLOD() {
+	lodEnabled = !lodEnabled
+	for _, s := range Spheres {
+		if lodEnabled {
+			s.SetLOD(lod.NewSelector(Levels...))
+		} else {
+			s.SetLOD(nil)
+			s.SetPrecision(MaxPrecision)
+		}
+	}
+	fmt.Printf("Level of detail: %t\n", lodEnabled)
+}
+
+// It generates the stats text.
+func GenerateStatsText(font *text.Font, shaderProgram *shader.Shader) {
+	statsText = text.New("", font, shaderProgram)
+	statsText.SetScreenSize(WindowWidth, WindowHeight)
+	statsText.SetPosition(mgl32.Vec3{10, 10, 0})
+	statsText.SetScale(0.5)
+	app.AddItem(statsText)
+}
+
+// UpdateStats writes the average frame statistics to the screen text.
+func UpdateStats() {
+	stats := app.GetAverageFrameStats()
+	statsText.SetText(fmt.Sprintf("Level of detail: %t\nTriangles: %d\nCulled objects: %d", lodEnabled, stats.Triangles, stats.CulledObjects))
+}
+
+// Update moves the camera based on the input map and the mouse position. The
+// L key toggles the level of detail.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if m.Pressed("lod") {
+		ToggleLOD()
+	}
+	currX, currY := app.GetWindow().GetCursorPos()
+	x, y := app.MouseCoordinates(currX, currY)
+	dX := float32(0.0)
+	dY := float32(0.0)
+	if y > 1.0-cameraDistance && y < 1.0 {
+		dY = cameraDirectionSpeed
+	} else if y < -1.0+cameraDistance && y > -1.0 {
+		dY = -cameraDirectionSpeed
+	}
+	if x < -1.0+cameraDistance && x > -1.0 {
+		dX = -cameraDirectionSpeed
+	} else if x > 1.0-cameraDistance && x < 1.0 {
+		dX = cameraDirectionSpeed
+	}
+	app.GetCamera().UpdateDirection(dX, dY)
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app.SetCamera(CreateCamera())
+	inputMap := input.NewCameraMap()
+	inputMap.BindAction("lod", input.KeyBinding(glfw.KeyL, 0))
+	app.SetInputMap(inputMap)
+
+	lightSource := light.NewPointLight([4]mgl32.Vec3{LightPosition, mgl32.Vec3{0.5, 0.5, 0.5}, mgl32.Vec3{1, 1, 1}, mgl32.Vec3{1, 1, 1}}, [3]float32{1.0, 1.0, 1.0})
+	shaderProgram := shader.NewShader("examples/12-lod/vertexshader.vert", "examples/12-lod/fragmentshader.frag")
+	shaderProgram.AddPointLightSource(lightSource, [7]string{"light.position", "light.ambient", "light.diffuse", "light.specular", "", "", ""})
+	GenerateSpheres(shaderProgram)
+
+	font, err := text.LoadTTF(goregular.TTF, fontSize, text.DEFAULT_CHARSET)
+	if err != nil {
+		panic(err)
+	}
+	GenerateStatsText(font, text.NewShader(font))
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard button callback
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+
+	app.SetResizeCallback(func(int, int) {
+		width, height := app.GetWindowSize()
+		statsText.SetScreenSize(float32(width), float32(height))
+	})
+	app.SetRenderCallback(func(alpha float64) {
+		shaderProgram.SetViewPosition(app.GetCamera().GetPosition(), "viewPosition")
+		UpdateStats()
+	})
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - picking"
+
+	moveSpeed = 5.0
+	lookSpeed = 90.0
+)
+
+// Selectable is a pickable item with color, so that it could be highlighted.
+type Selectable interface {
+	picking.Pickable
+	SetColor(mgl32.Vec3)
+}
+
+var (
+	app *application.Application
+
+	HighlightColor = mgl32.Vec3{1, 1, 0}
+	// the original colors of the items and the selected item.
+	colors   = make(map[Selectable]mgl32.Vec3)
+	selected Selectable
+	cam      *camera.Camera
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 3, 12.0}, mgl32.Vec3{0, 1, 0}, -90.0, -10.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 100.0)
+	return camera
+}
+
+// addSelectable inserts the item to the application and stores its color.
+func addSelectable(item interface {
+	Selectable
+	application.Drawable
+}, color mgl32.Vec3) {
+	colors[item] = color
+	app.AddItem(item)
+}
+
+// It generates the floor, the cubes and the spheres.
+func GenerateItems(shaderProgram *shader.Shader) {
+	floorColor := mgl32.Vec3{0.4, 0.4, 0.4}
+	floor := rectangle.NewSquare(mgl32.Vec3{-6, -1, -6}, mgl32.Vec3{6, -1, 6}, mgl32.Vec3{0, 1, 0}, floorColor, shaderProgram)
+	addSelectable(floor, floorColor)
+	for i := 0; i < 3; i++ {
+		x := float32(i-1) * 3
+		cubeColor := mgl32.Vec3{float32(i) / 2, 0, 1 - float32(i)/2}
+		bottom := rectangle.NewSquare(mgl32.Vec3{x - 0.5, -1, -2.5}, mgl32.Vec3{x + 0.5, -1, -1.5}, mgl32.Vec3{0, -1, 0}, cubeColor, shaderProgram)
+		cube := cuboid.New(bottom, 1, shaderProgram)
+		addSelectable(cube, cubeColor)
+
+		sphereColor := mgl32.Vec3{0, 1 - float32(i)/2, float32(i) / 2}
+		s := sphere.New(mgl32.Vec3{x, 0, 1}, sphereColor, 0.8, shaderProgram)
+		s.SetPrecision(20)
+		addSelectable(s, sphereColor)
+	}
+}
+
+// Select highlights the item under the cursor and restores the color of the
+// previously selected item.
+func Select() {
+	if selected != nil {
+		selected.SetColor(colors[selected])
+		selected = nil
+	}
+	hit, ok := app.PickCursor()
+	if !ok {
+		fmt.Println("Nothing is selected.")
+		return
+	}
+	selected = hit.Item.(Selectable)
+	selected.SetColor(HighlightColor)
+	fmt.Printf("Selected: point: %v, normal: %v, distance: %.2f\n", hit.Point, hit.Normal, hit.Distance)
+}
+
+// Update moves the camera based on the input map. The left mouse button
+// selects the item under the cursor.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if m.Pressed("select") {
+		Select()
+	}
+	if m.Pressed("projection") {
+		ToggleProjection()
+	}
+}
+
+// ToggleProjection switches between the perspective and the orthographic
+// projection. The framing of the center of the scene is kept.
+func ToggleProjection() {
+	distance := cam.GetPosition().Len()
+	if cam.IsOrthographic() {
+		cam.SetProjectionMode(camera.PROJECTION_PERSPECTIVE, distance)
+		return
+	}
+	cam.SetProjectionMode(camera.PROJECTION_ORTHOGRAPHIC, distance)
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	cam = CreateCamera()
+	app.SetCamera(cam)
+	inputMap := input.NewCameraMap()
+	inputMap.BindAction("select", input.MouseButtonBinding(glfw.MouseButtonLeft, 0))
+	inputMap.BindAction("projection", input.KeyBinding(glfw.KeyO, 0))
+	app.SetInputMap(inputMap)
+
+	shaderProgram := shader.NewShader("examples/13-picking/vertexshader.vert", "examples/13-picking/fragmentshader.frag")
+	GenerateItems(shaderProgram)
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard and mouse button callbacks
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	app.GetWindow().SetMouseButtonCallback(app.MouseButtonCallback)
+
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
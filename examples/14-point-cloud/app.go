@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	"github.com/akosgarai/opengl_playground/pkg/framebuffer"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/point"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - point cloud picking"
+
+	moveSpeed = 5.0
+	lookSpeed = 90.0
+	// the number of the points of the spiral.
+	pointCount = 50000
+)
+
+var (
+	app *application.Application
+
+	HighlightColor = mgl32.Vec3{1, 1, 0}
+	cloud          *point.Points
+	ball           *sphere.Sphere
+	// the original color of the selected point.
+	selected      *point.Point
+	selectedColor mgl32.Vec3
+)
+
+// It creates a new camera with the necessary setup
+func CreateCamera() *camera.Camera {
+	camera := camera.NewCamera(mgl32.Vec3{0, 0, 10.0}, mgl32.Vec3{0, 1, 0}, -90.0, 0.0)
+	camera.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 100.0)
+	return camera
+}
+
+// GenerateItems creates the point cloud (a spiral around a sphere) and the sphere.
+func GenerateItems(shaderProgram *shader.Shader) {
+	cloud = point.New(shaderProgram)
+	for i := 0; i < pointCount; i++ {
+		t := float64(i) / pointCount
+		angle := t * 200 * math.Pi
+		radius := 1.5 + 2.5*t
+		position := mgl32.Vec3{float32(radius * math.Cos(angle)), float32(6*t - 3), float32(radius * math.Sin(angle))}
+		color := mgl32.Vec3{float32(t), 0.3, float32(1 - t)}
+		cloud.Add(position, color, 3)
+	}
+	app.AddItem(cloud)
+	ball = sphere.New(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 0.6, 0}, 1, shaderProgram)
+	ball.SetPrecision(20)
+	app.AddItem(ball)
+}
+
+// Select highlights the point under the cursor with the color id picking.
+func Select() {
+	if selected != nil {
+		selected.SetColor(selectedColor)
+		selected = nil
+	}
+	item, index, ok := app.PickIDCursor()
+	if !ok {
+		fmt.Println("Nothing is selected.")
+		return
+	}
+	if item != cloud {
+		fmt.Println("The sphere is selected.")
+		return
+	}
+	selected = cloud.Get(index)
+	selectedColor = selected.GetColor()
+	selected.SetColor(HighlightColor)
+	fmt.Printf("Selected point: %d, coordinate: %v\n", index, selected.GetCoordinate())
+}
+
+// Update moves the camera based on the input map. The left mouse button
+// selects the point under the cursor.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if walk := m.Axis("walk"); walk != 0 {
+		app.GetCamera().Walk(float32(float64(walk) * moveSpeed * dt))
+	}
+	if strafe := m.Axis("strafe"); strafe != 0 {
+		app.GetCamera().Strafe(float32(float64(strafe) * moveSpeed * dt))
+	}
+	if lift := m.Axis("lift"); lift != 0 {
+		app.GetCamera().Lift(float32(float64(lift) * moveSpeed * dt))
+	}
+	if lookX, lookY := m.Axis("look_x"), m.Axis("look_y"); lookX != 0 || lookY != 0 {
+		app.GetCamera().UpdateDirection(float32(float64(lookX)*lookSpeed*dt), float32(float64(lookY)*lookSpeed*dt))
+	}
+	if m.Pressed("select") {
+		Select()
+	}
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	app.SetCamera(CreateCamera())
+	inputMap := input.NewCameraMap()
+	inputMap.BindAction("select", input.MouseButtonBinding(glfw.MouseButtonLeft, 0))
+	app.SetInputMap(inputMap)
+
+	shaderProgram := shader.NewShader("examples/14-point-cloud/vertexshader.vert", "examples/14-point-cloud/fragmentshader.frag")
+	GenerateItems(shaderProgram)
+
+	// the id buffer follows the size of the framebuffer.
+	idBuffer, err := framebuffer.New(app.GetWindow().GetFramebufferSize())
+	if err != nil {
+		panic(err)
+	}
+	defer idBuffer.Delete()
+	if err := app.SetIDBuffer(idBuffer, picking.NewIDShader()); err != nil {
+		panic(err)
+	}
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.Enable(wrapper.PROGRAM_POINT_SIZE)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard and mouse button callbacks
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	app.GetWindow().SetMouseButtonCallback(app.MouseButtonCallback)
+
+	app.SetUpdateCallback(Update)
+	app.Run()
+}
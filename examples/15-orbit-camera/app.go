@@ -0,0 +1,120 @@
+package main
+
+import (
+	"runtime"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/window"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	WindowWidth  = 800
+	WindowHeight = 800
+	WindowTitle  = "Example - orbit camera"
+
+	// the rotation in degrees and the pan in world units per pixel.
+	rotateSpeed = 0.3
+	panSpeed    = 0.01
+)
+
+var (
+	app   *application.Application
+	orbit *camera.Orbit
+)
+
+// It creates a new orbit camera around the origo.
+func CreateCamera() *camera.Orbit {
+	orbit := camera.NewOrbit(mgl32.Vec3{0, 0, 0}, 8, 30, 20, mgl32.Vec3{0, 1, 0})
+	orbit.SetupProjection(45, float32(WindowWidth)/float32(WindowHeight), 0.1, 100.0)
+	orbit.SetDistanceLimits(2, 30)
+	return orbit
+}
+
+// It generates the inspected model: a cube with a sphere on its top.
+func GenerateItems(shaderProgram *shader.Shader) {
+	bottom := rectangle.NewSquare(mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, -1, 1}, mgl32.Vec3{0, -1, 0}, mgl32.Vec3{0.8, 0.3, 0.2}, shaderProgram)
+	app.AddItem(cuboid.New(bottom, 2, shaderProgram))
+	ball := sphere.New(mgl32.Vec3{0, 1.5, 0}, mgl32.Vec3{0.2, 0.5, 0.9}, 0.5, shaderProgram)
+	ball.SetPrecision(20)
+	app.AddItem(ball)
+}
+
+// Update switches the rotation and the projection mode.
+// It's called in every fixed update step, the dt is in seconds.
+func Update(dt float64) {
+	m := app.GetInputMap()
+	if m.Pressed("mode") {
+		if orbit.GetMode() == camera.ORBIT_MODE_TURNTABLE {
+			orbit.SetMode(camera.ORBIT_MODE_ARCBALL)
+		} else {
+			orbit.SetMode(camera.ORBIT_MODE_TURNTABLE)
+		}
+	}
+	if m.Pressed("projection") {
+		if orbit.IsOrthographic() {
+			orbit.SetProjectionMode(camera.PROJECTION_PERSPECTIVE, orbit.GetDistance())
+		} else {
+			orbit.SetProjectionMode(camera.PROJECTION_ORTHOGRAPHIC, orbit.GetDistance())
+		}
+	}
+}
+
+// Render applies the mouse movement of the frame. The left button drag
+// rotates, the right button drag pans, the scroll zooms.
+func Render(alpha float64) {
+	m := app.GetInputMap()
+	dx, dy := app.GetMouseDelta()
+	if m.IsDown("rotate") {
+		orbit.UpdateDirection(float32(dx)*rotateSpeed, -float32(dy)*rotateSpeed)
+	} else if m.IsDown("pan") {
+		// the scene follows the cursor.
+		scale := panSpeed * orbit.GetDistance() / 8
+		orbit.Pan(-float32(dx)*scale, float32(dy)*scale)
+	}
+	if _, scroll := app.GetScroll(); scroll != 0 {
+		orbit.Zoom(float32(scroll))
+	}
+}
+func main() {
+	runtime.LockOSThread()
+
+	app = application.New()
+	app.SetWindow(window.InitGlfw(WindowWidth, WindowHeight, WindowTitle))
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+
+	orbit = CreateCamera()
+	app.SetCamera(orbit)
+	inputMap := input.New()
+	inputMap.BindAction("rotate", input.MouseButtonBinding(glfw.MouseButtonLeft, 0))
+	inputMap.BindAction("pan", input.MouseButtonBinding(glfw.MouseButtonRight, 0))
+	inputMap.BindAction("mode", input.KeyBinding(glfw.KeyM, 0))
+	inputMap.BindAction("projection", input.KeyBinding(glfw.KeyO, 0))
+	app.SetInputMap(inputMap)
+
+	shaderProgram := shader.NewShader("examples/15-orbit-camera/vertexshader.vert", "examples/15-orbit-camera/fragmentshader.frag")
+	GenerateItems(shaderProgram)
+
+	wrapper.Enable(wrapper.DEPTH_TEST)
+	wrapper.DepthFunc(wrapper.LESS)
+	wrapper.ClearColor(0.3, 0.3, 0.3, 1.0)
+
+	// register keyboard, mouse button and scroll callbacks
+	app.GetWindow().SetKeyCallback(app.KeyCallback)
+	app.GetWindow().SetMouseButtonCallback(app.MouseButtonCallback)
+	app.GetWindow().SetScrollCallback(app.ScrollCallback)
+
+	app.SetUpdateCallback(Update)
+	app.SetRenderCallback(Render)
+	app.Run()
+}
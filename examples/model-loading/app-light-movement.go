@@ -32,12 +32,12 @@ const (
 	UP       = glfw.KeyQ
 	DOWN     = glfw.KeyE
 
-	moveSpeed            = 0.005
+	moveSpeed            = 5.0
 	cameraDirectionSpeed = float32(0.05)
-	CameraMoveSpeed      = 0.005
+	CameraMoveSpeed      = 5.0
 	cameraDistance       = 0.1
 
-	rotationSpeed = float32(2.0)
+	rotationSpeed = float32(2000.0)
 )
 
 var (
@@ -115,9 +115,11 @@ func GenerateMaterialCubeMesh(mat *material.Material, pos mgl32.Vec3) *mesh.Mate
 	m.SetPosition(pos)
 	return m
 }
+
+// Update moves the meshes and the camera. The delta time is in seconds.
 func Update() {
 	nowNano := time.Now().UnixNano()
-	moveTime := float64(nowNano-lastUpdate) / float64(time.Millisecond)
+	moveTime := float64(nowNano-lastUpdate) / float64(time.Second)
 	lastUpdate = nowNano
 
 	rotationAngle = rotationAngle + float32(moveTime)*rotationSpeed
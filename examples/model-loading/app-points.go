@@ -35,8 +35,8 @@ const (
 
 	LEFT_MOUSE_BUTTON = glfw.MouseButtonLeft
 
-	moveSpeed = 1.0 / 100.0
-	epsilon   = 100.0
+	moveSpeed = 10.0
+	epsilon   = 0.1
 )
 
 var (
@@ -64,7 +64,7 @@ func updateCameraState() {
 	//calculate delta
 	nowUnix := time.Now().UnixNano()
 	delta := nowUnix - cameraLastUpdate
-	moveTime := float64(delta / int64(time.Millisecond))
+	moveTime := float64(delta) / float64(time.Second)
 
 	if epsilon > moveTime {
 		return
@@ -34,7 +34,10 @@ type Application struct {
 	camera    Camera
 	cameraSet bool
 
-	shaderMap  map[interfaces.Shader][]Mesh
+	shaderMap map[interfaces.Shader][]Mesh
+	// shaders is the insertion order of the shaders, because the order of
+	// the map iteration is random.
+	shaders    []interfaces.Shader
 	mouseDowns map[glfw.MouseButton]bool
 	MousePosX  float64
 	MousePosY  float64
@@ -59,6 +62,7 @@ func New() *Application {
 	return &Application{
 		cameraSet:               false,
 		shaderMap:               make(map[interfaces.Shader][]Mesh),
+		shaders:                 []interfaces.Shader{},
 		mouseDowns:              make(map[glfw.MouseButton]bool),
 		directionalLightSources: []DirectionalLightSource{},
 		pointLightSources:       []PointLightSource{},
@@ -99,19 +103,25 @@ func (a *Application) GetCamera() Camera {
 
 // AddShader method inserts the new shader to the shaderMap
 func (a *Application) AddShader(s interfaces.Shader) {
+	if _, ok := a.shaderMap[s]; !ok {
+		a.shaders = append(a.shaders, s)
+	}
 	a.shaderMap[s] = []Mesh{}
 }
 
 // AddMeshToShader attaches the mest to a shader.
 func (a *Application) AddMeshToShader(m Mesh, s interfaces.Shader) {
+	if _, ok := a.shaderMap[s]; !ok {
+		a.shaders = append(a.shaders, s)
+	}
 	a.shaderMap[s] = append(a.shaderMap[s], m)
 }
 
-// Draw calls Draw function in every drawable item. It loops on the shaderMap (shaders).
+// Draw calls Draw function in every drawable item. It loops on the shaders in insertion order.
 // For each shader, first set it to used state, setup camera realted uniforms,
 // then setup light related uniforms. Then we can pass the shader to the mesh for drawing.
 func (a *Application) Draw() {
-	for s, _ := range a.shaderMap {
+	for _, s := range a.shaders {
 		s.Use()
 		if a.cameraSet {
 			s.SetUniformMat4("view", a.camera.GetViewMatrix())
@@ -319,7 +329,7 @@ func (a *Application) GetKeyState(key glfw.Key) bool {
 
 // Update calls the Update function in every drawable item.
 func (a *Application) Update(dt float64) {
-	for s, _ := range a.shaderMap {
+	for _, s := range a.shaders {
 		for index, _ := range a.shaderMap[s] {
 			a.shaderMap[s][index].Update(dt)
 		}
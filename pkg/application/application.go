@@ -3,12 +3,18 @@ package application
 import (
 	"fmt"
 
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+
 	"github.com/go-gl/glfw/v3.3/glfw"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
 const (
-	DEBUG = glfw.KeyH
+	DEBUG      = glfw.KeyH
+	SCREENSHOT = glfw.KeyP
+	STATS      = glfw.KeyF3
 )
 
 type Drawable interface {
@@ -27,6 +33,9 @@ type Camera interface {
 	Lift(float32)
 	UpdateDirection(float32, float32)
 	GetPosition() mgl32.Vec3
+	GetFov() float32
+	SetFov(float32)
+	SetAspectRatio(float32)
 }
 
 type Application struct {
@@ -39,12 +48,41 @@ type Application struct {
 	MousePosY  float64
 
 	items []Drawable
+	// the sorted draw commands of the Queueable items.
+	queue *RenderQueue
+	// the frustum culling of the Bounded items and the number of the culled
+	// items of the last frame.
+	frustumCulling bool
+	culled         int
+
+	screenshotDir       string
+	screenshotRequested bool
+
+	loop  loop
+	stats stats
+	input *input.Map
+	mouse mouse
+	// the connected gamepads.
+	gamepad gamepad
+	// the size of the window and the framebuffer, the offscreen targets.
+	size size
+	// the input recording and replay.
+	recorder recorder
+	replay   replay
+	// the offscreen target and the shader of the color id picking.
+	ids idBuffer
 }
 
 type Window interface {
 	GetCursorPos() (float64, float64)
 	SetKeyCallback(glfw.KeyCallback) glfw.KeyCallback
 	SetMouseButtonCallback(glfw.MouseButtonCallback) glfw.MouseButtonCallback
+	SetCursorPosCallback(glfw.CursorPosCallback) glfw.CursorPosCallback
+	SetScrollCallback(glfw.ScrollCallback) glfw.ScrollCallback
+	SetInputMode(glfw.InputMode, int)
+	SetFramebufferSizeCallback(glfw.FramebufferSizeCallback) glfw.FramebufferSizeCallback
+	GetSize() (int, int)
+	GetFramebufferSize() (int, int)
 	ShouldClose() bool
 	SwapBuffers()
 }
@@ -55,7 +93,14 @@ func New() *Application {
 		keyDowns:   make(map[glfw.Key]bool),
 		mouseDowns: make(map[glfw.MouseButton]bool),
 		items:      []Drawable{},
+		queue:      NewRenderQueue(),
 		cameraSet:  false,
+
+		frustumCulling: true,
+
+		screenshotDir: ".",
+		loop:          newLoop(),
+		gamepad:       newGamepad(),
 	}
 }
 
@@ -113,6 +158,17 @@ func (a *Application) GetKeys() map[glfw.Key]bool {
 	return a.keyDowns
 }
 
+// SetInputMap updates the input map. The key, mouse button and scroll events
+// are passed to it, and it's updated before every fixed update step.
+func (a *Application) SetInputMap(m *input.Map) {
+	a.input = m
+}
+
+// GetInputMap returns the input map of the application.
+func (a *Application) GetInputMap() *input.Map {
+	return a.input
+}
+
 // AddItem inserts a new drawable item
 func (a *Application) AddItem(d Drawable) {
 	a.items = append(a.items, d)
@@ -125,44 +181,123 @@ func (a *Application) Draw() {
 	}
 }
 
-// Update calls the Update function in every drawable item.
+// GetRenderQueue returns the render queue of the last DrawWithUniforms call.
+func (a *Application) GetRenderQueue() *RenderQueue {
+	return a.queue
+}
+
+// Update calls the Update function in every drawable item. The dt is in seconds.
 func (a *Application) Update(dt float64) {
 	for index, _ := range a.items {
 		a.items[index].Update(dt)
 	}
 }
 
-// DrawWithUniforms calls DrawWithUniforms function in every drawable item with the calculated V & P.
+// DrawWithUniforms draws the items with the calculated V & P. The commands
+// of the Queueable items are sorted by the render queue. The opaque commands
+// are drawn first, then the other items in insertion order, then the
+// transparent commands. The Bounded items that are outside of the frustum
+// of the camera are skipped if the frustum culling is enabled. The level of
+// detail of the visible Detailed items is selected before the drawing.
 func (a *Application) DrawWithUniforms() {
 	V := mgl32.Ident4()
 	P := mgl32.Ident4()
+	cameraPosition := mgl32.Vec3{0, 0, 0}
 	if a.cameraSet {
 		V = a.camera.GetViewMatrix()
 		P = a.camera.GetProjectionMatrix()
+		cameraPosition = a.camera.GetPosition()
 	}
 
+	a.culled = 0
+	cull := a.cameraSet && a.frustumCulling
+	var frustum bounds.Frustum
+	if cull {
+		frustum = bounds.NewFrustum(P.Mul4(V))
+	}
+	var view lod.View
+	if a.cameraSet {
+		view = a.GetLODView()
+	}
+	a.queue.Clear()
+	var others []Drawable
 	for _, item := range a.items {
+		if cull && !isVisible(frustum, item) {
+			a.culled++
+			continue
+		}
+		if d, ok := item.(Detailed); ok && a.cameraSet {
+			d.SelectLOD(view)
+		}
+		if q, ok := item.(Queueable); ok {
+			a.queue.Add(NewDrawCommand(q))
+		} else {
+			others = append(others, item)
+		}
+	}
+	a.queue.Sort(cameraPosition)
+	a.queue.SubmitOpaque(V, P)
+	for _, item := range others {
 		item.DrawWithUniforms(V, P)
 	}
+	a.queue.SubmitTransparent(V, P)
 }
 
-// KeyCallback is responsible for the keyboard event handling.
+// KeyCallback is responsible for the keyboard event handling. The events of
+// the window are ignored during the replay.
 func (a *Application) KeyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if a.IsReplaying() {
+		return
+	}
+	a.KeyEvent(key, scancode, action, mods)
+}
+
+// KeyEvent handles the key event. It could be used without glfw window (eg:
+// tests, replays).
+func (a *Application) KeyEvent(key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	a.recordEvent(InputEvent{Type: EVENT_KEY, Key: key, Scancode: scancode, Action: action, Mods: mods})
+	if a.input != nil {
+		a.input.KeyEvent(key, action)
+	}
 	switch key {
 	case DEBUG:
 		if action != glfw.Release {
 			fmt.Printf("%s\n", a.Log())
 		}
 		break
+	case SCREENSHOT:
+		if action == glfw.Press {
+			a.screenshotRequested = true
+		}
+		break
+	case STATS:
+		if action == glfw.Press {
+			a.ToggleOverlay()
+		}
+		break
 	default:
 		a.SetKeyState(key, action)
 		break
 	}
 }
 
-// MouseButtonCallback is responsible for the mouse button event handling.
+// MouseButtonCallback is responsible for the mouse button event handling. The
+// events of the window are ignored during the replay.
 func (a *Application) MouseButtonCallback(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
-	a.MousePosX, a.MousePosY = w.GetCursorPos()
+	if a.IsReplaying() {
+		return
+	}
+	a.CursorPosEvent(w.GetCursorPos())
+	a.MouseButtonEvent(button, action, mods)
+}
+
+// MouseButtonEvent handles the mouse button event. The position of the event
+// is the last cursor position (MousePosX, MousePosY).
+func (a *Application) MouseButtonEvent(button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	a.recordEvent(InputEvent{Type: EVENT_MOUSE_BUTTON, Button: button, Action: action, Mods: mods})
+	if a.input != nil {
+		a.input.MouseButtonEvent(button, action)
+	}
 	switch button {
 	default:
 		a.SetButtonState(button, action)
@@ -197,6 +332,15 @@ func (a *Application) GetMouseButtonState(button glfw.MouseButton) bool {
 	return a.mouseDowns[button]
 }
 
+// GetCursorPos returns the current cursor position of the window. Without
+// window or during the replay it returns the position of the last cursor event.
+func (a *Application) GetCursorPos() (float64, float64) {
+	if a.window == nil || a.IsReplaying() {
+		return a.MousePosX, a.MousePosY
+	}
+	return a.window.GetCursorPos()
+}
+
 // GetKeyState returns the state of the given key
 func (a *Application) GetKeyState(key glfw.Key) bool {
 	return a.keyDowns[key]
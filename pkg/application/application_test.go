@@ -20,6 +20,23 @@ func (wm WindowMock) SetKeyCallback(cb glfw.KeyCallback) glfw.KeyCallback {
 func (wm WindowMock) SetMouseButtonCallback(cb glfw.MouseButtonCallback) glfw.MouseButtonCallback {
 	return cb
 }
+func (wm WindowMock) SetCursorPosCallback(cb glfw.CursorPosCallback) glfw.CursorPosCallback {
+	return cb
+}
+func (wm WindowMock) SetScrollCallback(cb glfw.ScrollCallback) glfw.ScrollCallback {
+	return cb
+}
+func (wm WindowMock) SetInputMode(mode glfw.InputMode, value int) {
+}
+func (wm WindowMock) SetFramebufferSizeCallback(cb glfw.FramebufferSizeCallback) glfw.FramebufferSizeCallback {
+	return cb
+}
+func (wm WindowMock) GetSize() (int, int) {
+	return 0, 0
+}
+func (wm WindowMock) GetFramebufferSize() (int, int) {
+	return 0, 0
+}
 func (wm WindowMock) ShouldClose() bool {
 	return false
 }
@@ -51,6 +68,13 @@ func (cm CameraMock) UpdateDirection(float32, float32) {
 func (cm CameraMock) GetPosition() mgl32.Vec3 {
 	return mgl32.Vec3{0, 0, 0}
 }
+func (cm CameraMock) GetFov() float32 {
+	return 45
+}
+func (cm CameraMock) SetFov(float32) {
+}
+func (cm CameraMock) SetAspectRatio(float32) {
+}
 
 var cm CameraMock
 
@@ -243,3 +267,14 @@ func TestGetKeyState(t *testing.T) {
 		t.Error("W should be released")
 	}
 }
+func TestGetCursorPos(t *testing.T) {
+	app := New()
+	app.MousePosX, app.MousePosY = 10, 20
+	if x, y := app.GetCursorPos(); x != 10 || y != 20 {
+		t.Error("Without window the last mouse event position should be returned")
+	}
+	app.SetWindow(WindowMock{})
+	if x, y := app.GetCursorPos(); x != 0 || y != 0 {
+		t.Error("The position of the window should be returned")
+	}
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+)
+
+// Bounded is an item with bounding volumes. The bounded items that are fully
+// outside of the frustum of the camera are not drawn.
+type Bounded interface {
+	GetBoundingSphere() bounds.Sphere
+	GetBoundingBox() bounds.AABB
+}
+
+// SetFrustumCulling enables or disables the frustum culling. It's enabled by default.
+func (a *Application) SetFrustumCulling(enabled bool) {
+	a.frustumCulling = enabled
+}
+
+// IsFrustumCulling returns true if the frustum culling is enabled.
+func (a *Application) IsFrustumCulling() bool {
+	return a.frustumCulling
+}
+
+// GetCulledObjects returns the number of the items that were culled in the
+// last DrawWithUniforms call.
+func (a *Application) GetCulledObjects() int {
+	return a.culled
+}
+
+// isVisible returns false if the item is bounded and it's fully outside of
+// the frustum. The sphere is checked first, it's cheaper than the box.
+func isVisible(f bounds.Frustum, item Drawable) bool {
+	b, ok := item.(Bounded)
+	if !ok {
+		return true
+	}
+	return f.IntersectsSphere(b.GetBoundingSphere()) && f.IntersectsAABB(b.GetBoundingBox())
+}
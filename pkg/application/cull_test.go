@@ -0,0 +1,54 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// boundedMock is a unit sphere around the center, it records the drawing.
+type boundedMock struct {
+	DrawableMock
+	center mgl32.Vec3
+	drawn  *int
+}
+
+func (b *boundedMock) DrawWithUniforms(v, p mgl32.Mat4) {
+	*b.drawn++
+}
+func (b *boundedMock) GetBoundingSphere() bounds.Sphere {
+	return bounds.Sphere{Center: b.center, Radius: 1}
+}
+func (b *boundedMock) GetBoundingBox() bounds.AABB {
+	return b.GetBoundingSphere().BoundingBox()
+}
+
+func TestFrustumCulling(t *testing.T) {
+	drawn := 0
+	app := New()
+	if !app.IsFrustumCulling() {
+		t.Error("The frustum culling should be enabled by default")
+	}
+	// the frustum of the camera mock is the [-1, 1] cube.
+	app.SetCamera(cm)
+	app.AddItem(&boundedMock{center: mgl32.Vec3{0, 0, 0}, drawn: &drawn})
+	app.AddItem(&boundedMock{center: mgl32.Vec3{1.5, 0, 0}, drawn: &drawn})
+	app.AddItem(&boundedMock{center: mgl32.Vec3{5, 0, 0}, drawn: &drawn})
+	app.AddItem(&boundedMock{center: mgl32.Vec3{0, -3, 0}, drawn: &drawn})
+	app.AddItem(dm)
+	app.DrawWithUniforms()
+	if drawn != 2 {
+		t.Errorf("Invalid number of drawn items '%d'.", drawn)
+	}
+	if app.GetCulledObjects() != 2 {
+		t.Errorf("Invalid number of culled items '%d'.", app.GetCulledObjects())
+	}
+	drawn = 0
+	app.SetFrustumCulling(false)
+	app.DrawWithUniforms()
+	if drawn != 4 || app.GetCulledObjects() != 0 {
+		t.Errorf("Every item should be drawn without culling, drawn: '%d', culled: '%d'.", drawn, app.GetCulledObjects())
+	}
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"math"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+const (
+	// DEFAULT_DEAD_ZONE is the default dead zone of the sticks and the triggers.
+	DEFAULT_DEAD_ZONE = float32(0.15)
+)
+
+// JoystickSource is the source of the joystick states. The GLFWJoysticks
+// reads the glfw joysticks, the tests could use a fake one.
+type JoystickSource interface {
+	Present(glfw.Joystick) bool
+	IsGamepad(glfw.Joystick) bool
+	GetGamepadState(glfw.Joystick) *glfw.GamepadState
+}
+
+// GLFWJoysticks reads the joysticks with the standard gamepad mappings of glfw.
+type GLFWJoysticks struct{}
+
+func (GLFWJoysticks) Present(joy glfw.Joystick) bool {
+	return joy.Present()
+}
+func (GLFWJoysticks) IsGamepad(joy glfw.Joystick) bool {
+	return joy.IsGamepad()
+}
+func (GLFWJoysticks) GetGamepadState(joy glfw.Joystick) *glfw.GamepadState {
+	return joy.GetGamepadState()
+}
+
+// gamepad stores the states of the connected gamepads. The raw states are
+// used for the change detection, the states are the dead zone filtered ones.
+type gamepad struct {
+	source    JoystickSource
+	deadZone  float32
+	raw       map[glfw.Joystick]glfw.GamepadState
+	states    map[glfw.Joystick]glfw.GamepadState
+	connected []glfw.Joystick
+	callback  func(glfw.Joystick, bool)
+}
+
+func newGamepad() gamepad {
+	return gamepad{
+		deadZone: DEFAULT_DEAD_ZONE,
+		raw:      make(map[glfw.Joystick]glfw.GamepadState),
+		states:   make(map[glfw.Joystick]glfw.GamepadState),
+	}
+}
+
+// SetJoystickSource sets the source of the gamepads (eg: GLFWJoysticks{}). The
+// gamepads are polled in every frame. Without source the gamepads are disabled.
+func (a *Application) SetJoystickSource(source JoystickSource) {
+	a.gamepad.source = source
+}
+
+// SetDeadZone updates the dead zone of the sticks and the triggers [0, 1).
+func (a *Application) SetDeadZone(deadZone float32) {
+	a.gamepad.deadZone = deadZone
+}
+
+// SetGamepadCallback sets the function that is called when a gamepad is
+// connected (true) or disconnected (false).
+func (a *Application) SetGamepadCallback(callback func(glfw.Joystick, bool)) {
+	a.gamepad.callback = callback
+}
+
+// GetGamepads returns the connected gamepads in the order of the connection.
+func (a *Application) GetGamepads() []glfw.Joystick {
+	return a.gamepad.connected
+}
+
+// GetGamepadButton returns true if the button of the gamepad is pressed.
+func (a *Application) GetGamepadButton(joy glfw.Joystick, button glfw.GamepadButton) bool {
+	state, ok := a.gamepad.states[joy]
+	return ok && state.Buttons[button] == glfw.Press
+}
+
+// GetGamepadAxis returns the dead zone filtered value of the axis. The sticks
+// are in [-1, 1], the triggers are in [0, 1].
+func (a *Application) GetGamepadAxis(joy glfw.Joystick, axis glfw.GamepadAxis) float32 {
+	return a.gamepad.states[joy].Axes[axis]
+}
+
+// pollGamepads reads the state of every joystick from the source. During the
+// replay the states are set from the recording.
+func (a *Application) pollGamepads() {
+	if a.gamepad.source == nil || a.IsReplaying() {
+		return
+	}
+	for joy := glfw.Joystick1; joy <= glfw.JoystickLast; joy++ {
+		var state *glfw.GamepadState
+		if a.gamepad.source.Present(joy) && a.gamepad.source.IsGamepad(joy) {
+			state = a.gamepad.source.GetGamepadState(joy)
+		}
+		raw, connected := a.gamepad.raw[joy]
+		if state == nil && !connected || state != nil && connected && *state == raw {
+			continue
+		}
+		a.GamepadEvent(joy, state)
+	}
+}
+
+// GamepadEvent updates the state of the gamepad. The nil state means the
+// disconnection. The state of the first connected gamepad is passed to the
+// input map.
+func (a *Application) GamepadEvent(joy glfw.Joystick, state *glfw.GamepadState) {
+	a.recordEvent(InputEvent{Type: EVENT_GAMEPAD, Joystick: joy, Gamepad: state})
+	_, connected := a.gamepad.raw[joy]
+	if state == nil {
+		delete(a.gamepad.raw, joy)
+		delete(a.gamepad.states, joy)
+		for i, c := range a.gamepad.connected {
+			if c == joy {
+				a.gamepad.connected = append(a.gamepad.connected[:i], a.gamepad.connected[i+1:]...)
+				break
+			}
+		}
+	} else {
+		a.gamepad.raw[joy] = *state
+		a.gamepad.states[joy] = filterDeadZone(*state, a.gamepad.deadZone)
+		if !connected {
+			a.gamepad.connected = append(a.gamepad.connected, joy)
+		}
+	}
+	if connected != (state != nil) && a.gamepad.callback != nil {
+		a.gamepad.callback(joy, state != nil)
+	}
+	if a.input != nil {
+		var active glfw.GamepadState
+		if len(a.gamepad.connected) > 0 {
+			active = a.gamepad.states[a.gamepad.connected[0]]
+		}
+		a.input.GamepadEvent(active)
+	}
+}
+
+// filterDeadZone applies the radial dead zone to the sticks, and the linear
+// dead zone to the triggers. The triggers are mapped from [-1, 1] to [0, 1].
+// The values outside of the dead zone are rescaled, so that they start from 0.
+func filterDeadZone(state glfw.GamepadState, deadZone float32) glfw.GamepadState {
+	for _, stick := range [2][2]glfw.GamepadAxis{{glfw.AxisLeftX, glfw.AxisLeftY}, {glfw.AxisRightX, glfw.AxisRightY}} {
+		x, y := state.Axes[stick[0]], state.Axes[stick[1]]
+		length := float32(math.Sqrt(float64(x*x + y*y)))
+		scale := float32(0)
+		if length > deadZone {
+			scale = float32(math.Min(1, float64((length-deadZone)/(1-deadZone)))) / length
+		}
+		state.Axes[stick[0]], state.Axes[stick[1]] = x*scale, y*scale
+	}
+	for _, trigger := range []glfw.GamepadAxis{glfw.AxisLeftTrigger, glfw.AxisRightTrigger} {
+		value := (state.Axes[trigger] + 1) / 2
+		if value > deadZone {
+			state.Axes[trigger] = (value - deadZone) / (1 - deadZone)
+		} else {
+			state.Axes[trigger] = 0
+		}
+	}
+	return state
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/input"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// fakeJoysticks is a joystick source, its gamepads could be plugged in and out.
+type fakeJoysticks struct {
+	gamepads map[glfw.Joystick]*glfw.GamepadState
+	// the joysticks without gamepad mapping.
+	joysticks map[glfw.Joystick]bool
+}
+
+func newFakeJoysticks() *fakeJoysticks {
+	return &fakeJoysticks{
+		gamepads:  make(map[glfw.Joystick]*glfw.GamepadState),
+		joysticks: make(map[glfw.Joystick]bool),
+	}
+}
+func (f *fakeJoysticks) Present(joy glfw.Joystick) bool {
+	_, ok := f.gamepads[joy]
+	return ok || f.joysticks[joy]
+}
+func (f *fakeJoysticks) IsGamepad(joy glfw.Joystick) bool {
+	_, ok := f.gamepads[joy]
+	return ok
+}
+func (f *fakeJoysticks) GetGamepadState(joy glfw.Joystick) *glfw.GamepadState {
+	state := *f.gamepads[joy]
+	return &state
+}
+
+func TestGamepadConnection(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	source := newFakeJoysticks()
+	app.SetJoystickSource(source)
+	var events []bool
+	app.SetGamepadCallback(func(joy glfw.Joystick, connected bool) {
+		if joy != glfw.Joystick2 {
+			t.Errorf("Invalid joystick '%d'.", joy)
+		}
+		events = append(events, connected)
+	})
+	source.joysticks[glfw.Joystick1] = true
+	source.gamepads[glfw.Joystick2] = &glfw.GamepadState{}
+	app.Frame()
+	clock.Advance(0.01)
+	app.Frame()
+	if len(app.GetGamepads()) != 1 || app.GetGamepads()[0] != glfw.Joystick2 {
+		t.Errorf("Invalid gamepads '%v'.", app.GetGamepads())
+	}
+	delete(source.gamepads, glfw.Joystick2)
+	clock.Advance(0.01)
+	app.Frame()
+	if len(events) != 2 || !events[0] || events[1] {
+		t.Errorf("Invalid connection events '%v'.", events)
+	}
+	if len(app.GetGamepads()) != 0 {
+		t.Error("The gamepad should be disconnected")
+	}
+}
+func TestGamepadDeadZone(t *testing.T) {
+	app, _, _ := testLoopApp()
+	source := newFakeJoysticks()
+	app.SetJoystickSource(source)
+	app.SetDeadZone(0.2)
+	state := &glfw.GamepadState{}
+	state.Buttons[glfw.ButtonB] = glfw.Press
+	state.Axes[glfw.AxisLeftX] = 0.1
+	state.Axes[glfw.AxisLeftY] = -0.1
+	state.Axes[glfw.AxisRightX] = 0.6
+	state.Axes[glfw.AxisLeftTrigger] = -1
+	state.Axes[glfw.AxisRightTrigger] = 1
+	source.gamepads[glfw.Joystick1] = state
+	app.Frame()
+	if !app.GetGamepadButton(glfw.Joystick1, glfw.ButtonB) || app.GetGamepadButton(glfw.Joystick1, glfw.ButtonA) {
+		t.Error("Invalid buttons")
+	}
+	if app.GetGamepadAxis(glfw.Joystick1, glfw.AxisLeftX) != 0 || app.GetGamepadAxis(glfw.Joystick1, glfw.AxisLeftY) != 0 {
+		t.Error("The left stick should be in the dead zone")
+	}
+	if x := app.GetGamepadAxis(glfw.Joystick1, glfw.AxisRightX); x < 0.4999 || x > 0.5001 {
+		t.Errorf("Invalid rescaled axis '%f'.", x)
+	}
+	if app.GetGamepadAxis(glfw.Joystick1, glfw.AxisLeftTrigger) != 0 || app.GetGamepadAxis(glfw.Joystick1, glfw.AxisRightTrigger) != 1 {
+		t.Error("Invalid triggers")
+	}
+}
+func TestGamepadInputMap(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	app.SetInputMap(input.NewCameraMap())
+	source := newFakeJoysticks()
+	app.SetJoystickSource(source)
+	var walk []float32
+	app.SetUpdateCallback(func(dt float64) {
+		walk = append(walk, app.GetInputMap().Axis("walk"))
+	})
+	state := &glfw.GamepadState{}
+	state.Axes[glfw.AxisLeftY] = -1
+	source.gamepads[glfw.Joystick1] = state
+	var buf bytes.Buffer
+	app.StartRecording(&buf)
+	clock.Advance(0.01)
+	app.Frame()
+	state.Axes[glfw.AxisLeftY] = 0
+	clock.Advance(0.01)
+	app.Frame()
+	app.StopRecording()
+	if len(walk) != 2 || walk[0] != 1 || walk[1] != 0 {
+		t.Fatalf("Invalid walk axis '%v'.", walk)
+	}
+	events, err := LoadRecording(&buf)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %s", err.Error())
+	}
+	replayed, _, _ := testLoopApp()
+	replayed.SetInputMap(input.NewCameraMap())
+	replayed.SetJoystickSource(newFakeJoysticks())
+	var replayedWalk []float32
+	replayed.SetUpdateCallback(func(dt float64) {
+		replayedWalk = append(replayedWalk, replayed.GetInputMap().Axis("walk"))
+	})
+	replayed.Replay(events)
+	for replayed.IsReplaying() {
+		replayed.Frame()
+	}
+	if len(replayedWalk) != 2 || replayedWalk[0] != 1 || replayedWalk[1] != 0 {
+		t.Errorf("Invalid replayed walk axis '%v'.", replayedWalk)
+	}
+}
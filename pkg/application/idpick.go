@@ -0,0 +1,101 @@
+package application
+
+import (
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+)
+
+// IDTarget is the offscreen render target of the id pass (eg:
+// *framebuffer.Framebuffer). It needs color and depth buffer.
+type IDTarget interface {
+	Bind()
+	UnBind()
+}
+
+// idBuffer stores the target and the shader of the id pass.
+type idBuffer struct {
+	target IDTarget
+	shader picking.IDShader
+}
+
+// idRange is the ids of an item in the id pass.
+type idRange struct {
+	item  Drawable
+	first uint32
+	count int
+}
+
+// clearIDBuffer clears the bound target to the 0 id (black). The clear color
+// of the application is restored.
+var clearIDBuffer = func() {
+	var color [4]float32
+	wrapper.GetFloatv(wrapper.COLOR_CLEAR_VALUE, &color[0])
+	wrapper.ClearColor(0, 0, 0, 0)
+	wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
+	wrapper.ClearColor(color[0], color[1], color[2], color[3])
+}
+
+// SetIDBuffer sets the target and the shader of the color id picking (eg:
+// picking.NewIDShader()). If the target is a RenderTarget, it follows the
+// size of the framebuffer.
+func (a *Application) SetIDBuffer(target IDTarget, shader picking.IDShader) error {
+	a.ids = idBuffer{target: target, shader: shader}
+	if t, ok := target.(RenderTarget); ok {
+		return a.AddRenderTarget(t)
+	}
+	return nil
+}
+
+// PickID draws the IDDrawable items to the id buffer with unique colors and
+// returns the item under the given window position (in screen coordinates,
+// like the cursor position) and the index of the picked id inside the item
+// (eg: the index of the point of a point cloud). The other items aren't drawn,
+// so that they don't hide the items behind them. It returns false without
+// camera or id buffer or if nothing is picked.
+func (a *Application) PickID(x, y float64) (Drawable, int, bool) {
+	if !a.cameraSet || a.ids.target == nil {
+		return nil, 0, false
+	}
+	scaleX, scaleY := a.GetContentScale()
+	pixelX := int32(x * float64(scaleX))
+	pixelY := int32(a.size.framebufferHeight) - 1 - int32(y*float64(scaleY))
+	if pixelX < 0 || pixelY < 0 || pixelX >= int32(a.size.framebufferWidth) || pixelY >= int32(a.size.framebufferHeight) {
+		return nil, 0, false
+	}
+	V := a.camera.GetViewMatrix()
+	P := a.camera.GetProjectionMatrix()
+
+	a.ids.target.Bind()
+	clearIDBuffer()
+	var ranges []idRange
+	next := uint32(1)
+	for _, item := range a.items {
+		d, ok := item.(picking.IDDrawable)
+		if !ok || d.IDCount() == 0 {
+			continue
+		}
+		if next+uint32(d.IDCount())-1 > picking.MAX_ID {
+			break
+		}
+		d.DrawIDs(a.ids.shader, V, P, next)
+		ranges = append(ranges, idRange{item: item, first: next, count: d.IDCount()})
+		next += uint32(d.IDCount())
+	}
+	pixel := readPixels(pixelX, pixelY, 1, 1).Pix
+	a.ids.target.UnBind()
+	setViewport(a.size.framebufferWidth, a.size.framebufferHeight)
+
+	id := picking.ColorID(pixel[0], pixel[1], pixel[2])
+	for _, r := range ranges {
+		if id >= r.first && id < r.first+uint32(r.count) {
+			return r.item, int(id - r.first), true
+		}
+	}
+	return nil, 0, false
+}
+
+// PickIDCursor returns the item under the current cursor position with the
+// color id picking.
+func (a *Application) PickIDCursor() (Drawable, int, bool) {
+	return a.PickID(a.MousePosX, a.MousePosY)
+}
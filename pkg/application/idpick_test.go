@@ -0,0 +1,102 @@
+package application
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+)
+
+// idDrawableMock stores the first id of the last id pass.
+type idDrawableMock struct {
+	DrawableMock
+	count int
+	first uint32
+}
+
+func (d *idDrawableMock) IDCount() int {
+	return d.count
+}
+func (d *idDrawableMock) DrawIDs(s picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	d.first = first
+}
+
+// idTargetMock counts the bindings of the id buffer.
+type idTargetMock struct {
+	sizeTarget
+	binds   int
+	unbinds int
+}
+
+func (t *idTargetMock) Bind() {
+	t.binds++
+}
+func (t *idTargetMock) UnBind() {
+	t.unbinds++
+}
+
+// mockIDReader returns the pixel with the given id and stores the position of
+// the read pixel. It returns the function that restores the original ones.
+func mockIDReader(id uint32, position *[2]int32) func() {
+	originalClear, originalSetViewport, originalReadPixels := clearIDBuffer, setViewport, readPixels
+	clearIDBuffer = func() {}
+	setViewport = func(width, height int) {}
+	readPixels = func(x, y, width, height int32) *image.RGBA {
+		position[0], position[1] = x, y
+		img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+		c := picking.IDColor(id)
+		img.Set(0, 0, color.RGBA{uint8(c.X()*255 + 0.5), uint8(c.Y()*255 + 0.5), uint8(c.Z()*255 + 0.5), 255})
+		return img
+	}
+	return func() {
+		clearIDBuffer, setViewport, readPixels = originalClear, originalSetViewport, originalReadPixels
+	}
+}
+func TestPickID(t *testing.T) {
+	var position [2]int32
+	defer mockIDReader(4, &position)()
+	app := New()
+	app.size.windowWidth, app.size.windowHeight = 400, 300
+	target := &idTargetMock{}
+	if err := app.SetIDBuffer(target, nil); err != nil {
+		t.Fatal(err)
+	}
+	app.FramebufferSizeEvent(800, 600)
+	if target.width != 800 {
+		t.Error("The id buffer should follow the framebuffer size")
+	}
+	single := &idDrawableMock{count: 1}
+	cloud := &idDrawableMock{count: 5}
+	app.AddItem(single)
+	app.AddItem(dm)
+	app.AddItem(cloud)
+	if _, _, ok := app.PickID(100, 100); ok {
+		t.Error("Nothing should be picked without camera")
+	}
+	app.SetCamera(cm)
+	item, index, ok := app.PickID(100, 100)
+	if !ok || item != cloud || index != 2 {
+		t.Errorf("Invalid pick '%v', '%d', '%v'.", item, index, ok)
+	}
+	if single.first != 1 || cloud.first != 2 {
+		t.Errorf("Invalid id ranges '%d', '%d'.", single.first, cloud.first)
+	}
+	// the HiDPI scale and the flipped y coordinate.
+	if position != [2]int32{200, 399} {
+		t.Errorf("Invalid pixel position '%v'.", position)
+	}
+	if target.binds != 1 || target.unbinds != 1 {
+		t.Error("The id buffer should be bound for the pass")
+	}
+	if _, _, ok := app.PickID(500, 100); ok {
+		t.Error("Nothing should be picked outside of the window")
+	}
+	defer mockIDReader(0, &position)()
+	app.CursorPosEvent(10, 10)
+	if _, _, ok := app.PickIDCursor(); ok {
+		t.Error("The background shouldn't be picked")
+	}
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+)
+
+// Detailed is an item with levels of detail. The level of the visible items
+// is selected before the drawing, based on their projected size.
+type Detailed interface {
+	SelectLOD(lod.View)
+}
+
+// Orthographic is a camera with orthographic mode. The projected size of the
+// items doesn't depend on their distance in orthographic mode.
+type Orthographic interface {
+	IsOrthographic() bool
+	GetOrthoSize() float32
+}
+
+// GetLODView returns the view of the camera for the level of detail
+// selection. The height is the height of the framebuffer in pixels.
+func (a *Application) GetLODView() lod.View {
+	view := lod.View{
+		Position: a.camera.GetPosition(),
+		Fov:      a.camera.GetFov(),
+		Height:   float32(a.size.framebufferHeight),
+	}
+	if o, ok := a.camera.(Orthographic); ok && o.IsOrthographic() {
+		view.OrthoSize = o.GetOrthoSize()
+	}
+	return view
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+)
+
+// detailedMock records the views of the level of detail selection.
+type detailedMock struct {
+	DrawableMock
+	views []lod.View
+}
+
+func (d *detailedMock) SelectLOD(view lod.View) {
+	d.views = append(d.views, view)
+}
+func TestSelectLOD(t *testing.T) {
+	app := New()
+	item := &detailedMock{}
+	app.AddItem(item)
+	app.DrawWithUniforms()
+	if len(item.views) != 0 {
+		t.Error("The level shouldn't be selected without camera")
+	}
+	app.SetCamera(cm)
+	app.size.framebufferHeight = 600
+	app.DrawWithUniforms()
+	if len(item.views) != 1 {
+		t.Fatalf("The level should be selected once, instead we have '%d'.", len(item.views))
+	}
+	view := item.views[0]
+	if view.Fov != cm.GetFov() || view.Position != cm.GetPosition() || view.Height != 600 {
+		t.Errorf("Invalid view '%v'.", view)
+	}
+}
+
+// orthoCameraMock is a camera mock in orthographic mode.
+type orthoCameraMock struct {
+	CameraMock
+}
+
+func (o orthoCameraMock) IsOrthographic() bool {
+	return true
+}
+func (o orthoCameraMock) GetOrthoSize() float32 {
+	return 5
+}
+func TestGetLODViewOrthographic(t *testing.T) {
+	app := New()
+	app.SetCamera(cm)
+	if view := app.GetLODView(); view.OrthoSize != 0 {
+		t.Errorf("Invalid perspective view '%v'.", view)
+	}
+	app.SetCamera(orthoCameraMock{})
+	if view := app.GetLODView(); view.OrthoSize != 5 {
+		t.Errorf("Invalid orthographic view '%v'.", view)
+	}
+}
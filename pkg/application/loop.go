@@ -0,0 +1,237 @@
+package application
+
+import (
+	"time"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+const (
+	// DEFAULT_STEP is the default fixed update step in seconds.
+	DEFAULT_STEP = 1.0 / 60.0
+	// DEFAULT_MAX_FRAME_TIME is the default upper limit of the frame time in seconds.
+	// It prevents the spiral of death after a long frame (eg: window dragging).
+	DEFAULT_MAX_FRAME_TIME = 0.25
+)
+
+// pollEvents and clearScreen are variables for testing the loop without gl context.
+var pollEvents = glfw.PollEvents
+var clearScreen = func() {
+	wrapper.Clear(wrapper.COLOR_BUFFER_BIT | wrapper.DEPTH_BUFFER_BIT)
+}
+
+// loop stores the state of the fixed timestep game loop.
+type loop struct {
+	clock        func() time.Time
+	lastFrame    time.Time
+	started      bool
+	step         float64
+	maxFrameTime float64
+	timeScale    float64
+	accumulator  float64
+	alpha        float64
+	paused       bool
+	stepRequest  bool
+	elapsed      float64
+	frame        int
+
+	updateCallback func(float64)
+	renderCallback func(float64)
+}
+
+func newLoop() loop {
+	return loop{
+		clock:        time.Now,
+		step:         DEFAULT_STEP,
+		maxFrameTime: DEFAULT_MAX_FRAME_TIME,
+		timeScale:    1,
+	}
+}
+
+// SetClock updates the time source of the loop. It could be used for testing
+// or for deterministic replays.
+func (a *Application) SetClock(clock func() time.Time) {
+	a.loop.clock = clock
+	a.loop.started = false
+}
+
+// SetStep updates the fixed update step (seconds).
+func (a *Application) SetStep(step float64) {
+	if step > 0 {
+		a.loop.step = step
+	}
+}
+
+// GetStep returns the fixed update step (seconds).
+func (a *Application) GetStep() float64 {
+	return a.loop.step
+}
+
+// SetMaxFrameTime updates the upper limit of the frame time (seconds).
+func (a *Application) SetMaxFrameTime(max float64) {
+	if max > 0 {
+		a.loop.maxFrameTime = max
+	}
+}
+
+// SetTimeScale updates the speed of the simulation. 1 is the real time, 0.5 is the slow motion.
+func (a *Application) SetTimeScale(scale float64) {
+	if scale >= 0 {
+		a.loop.timeScale = scale
+	}
+}
+
+// GetTimeScale returns the speed of the simulation.
+func (a *Application) GetTimeScale() float64 {
+	return a.loop.timeScale
+}
+
+// Pause stops the updates. The drawing continues.
+func (a *Application) Pause() {
+	a.loop.paused = true
+}
+
+// Resume continues the updates after Pause.
+func (a *Application) Resume() {
+	a.loop.paused = false
+}
+
+// IsPaused returns true if the updates are paused.
+func (a *Application) IsPaused() bool {
+	return a.loop.paused
+}
+
+// Step requests exactly one update step in the next frame, if the application is paused.
+func (a *Application) Step() {
+	a.loop.stepRequest = true
+}
+
+// Alpha returns the render interpolation factor [0, 1). It's the ratio of the
+// remaining accumulated time and the step, so that the state could be
+// interpolated between the last two updates.
+func (a *Application) Alpha() float64 {
+	return a.loop.alpha
+}
+
+// GetElapsedTime returns the simulated time (seconds) since the start of the loop.
+func (a *Application) GetElapsedTime() float64 {
+	return a.loop.elapsed
+}
+
+// SetUpdateCallback sets the function that is called before the items are updated
+// in every fixed step. Its input is the step in seconds.
+func (a *Application) SetUpdateCallback(f func(float64)) {
+	a.loop.updateCallback = f
+}
+
+// SetRenderCallback sets the function that is called before the drawing in every
+// frame. Its input is the interpolation alpha.
+func (a *Application) SetRenderCallback(f func(float64)) {
+	a.loop.renderCallback = f
+}
+
+// Frame calculates the time since the previous frame and calls the fixed
+// updates for the accumulated time. It returns the number of the updates.
+func (a *Application) Frame() int {
+	a.applyReplay()
+	now := a.loop.clock()
+	if !a.loop.started {
+		a.loop.started = true
+		a.loop.lastFrame = now
+	}
+	a.stats.current.Frame = now.Sub(a.loop.lastFrame)
+	a.recordEvent(InputEvent{Type: EVENT_FRAME, FrameTime: a.stats.current.Frame})
+	frameTime := a.stats.current.Frame.Seconds()
+	a.loop.lastFrame = now
+	if frameTime > a.loop.maxFrameTime {
+		frameTime = a.loop.maxFrameTime
+	}
+	a.pollSize()
+	// the cursor position is also polled, so that the movement is tracked
+	// without cursor position callback.
+	a.CursorPosEvent(a.GetCursorPos())
+	a.updateMouse()
+	a.pollGamepads()
+	updates := 0
+	start := statsClock()
+	if !a.loop.paused {
+		a.loop.accumulator += frameTime * a.loop.timeScale
+		for a.loop.accumulator >= a.loop.step {
+			a.tick(a.loop.step)
+			a.loop.accumulator -= a.loop.step
+			updates++
+		}
+	} else if a.loop.stepRequest {
+		a.tick(a.loop.step)
+		updates++
+	}
+	a.loop.stepRequest = false
+	a.loop.alpha = a.loop.accumulator / a.loop.step
+	a.stats.current.Update = statsClock().Sub(start)
+	a.stats.current.Updates = updates
+	a.loop.frame++
+	if a.replay.active && len(a.replay.events) == 0 {
+		a.StopReplay()
+	}
+	return updates
+}
+
+func (a *Application) tick(dt float64) {
+	// the input is updated in every step, so that the pressed and released
+	// states are seen by exactly one update.
+	if a.input != nil {
+		a.input.Update()
+	}
+	if a.loop.updateCallback != nil {
+		a.loop.updateCallback(dt)
+	}
+	a.Update(dt)
+	a.loop.elapsed += dt
+}
+
+// Render clears the screen and draws the items. If the camera is set, the
+// items are drawn with the view and projection uniforms. It collects the
+// draw statistics of the frame, and draws the stats overlay if it's visible.
+func (a *Application) Render() {
+	resetDrawCounters()
+	start := statsClock()
+	if a.stats.gpuTiming {
+		a.stats.timer.begin()
+	}
+	clearScreen()
+	if a.loop.renderCallback != nil {
+		a.loop.renderCallback(a.loop.alpha)
+	}
+	if a.cameraSet {
+		a.DrawWithUniforms()
+	} else {
+		a.Draw()
+	}
+	a.saveRequestedScreenshot()
+	if a.stats.gpuTiming {
+		a.stats.timer.end()
+		a.stats.current.GPU = a.stats.timer.last
+	}
+	a.stats.current.Draw = statsClock().Sub(start)
+	a.stats.current.DrawCalls, a.stats.current.Triangles = drawCounters()
+	a.stats.current.SkippedStateChanges = stateCounters()
+	a.stats.current.CulledObjects = a.culled
+	a.stats.push()
+	if a.stats.overlayVisible {
+		a.stats.overlay.DrawStats(a.GetStatsHistory())
+	}
+}
+
+// Run is the main loop of the application. It runs until the window is closed.
+// In every frame it polls the events, calls the fixed updates, and renders the items.
+func (a *Application) Run() {
+	a.loop.started = false
+	for !a.window.ShouldClose() {
+		pollEvents()
+		a.Frame()
+		a.Render()
+		a.window.SwapBuffers()
+	}
+}
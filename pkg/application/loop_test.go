@@ -0,0 +1,175 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akosgarai/opengl_playground/pkg/input"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+func (f *fakeClock) Advance(seconds float64) {
+	f.now = f.now.Add(time.Duration(seconds * float64(time.Second)))
+}
+
+type countingDrawable struct {
+	DrawableMock
+	updates int
+	dt      float64
+}
+
+func (c *countingDrawable) Update(dt float64) {
+	c.updates++
+	c.dt = dt
+}
+func testLoopApp() (*Application, *fakeClock, *countingDrawable) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	app := New()
+	app.SetClock(clock.Now)
+	app.SetStep(0.01)
+	item := &countingDrawable{}
+	app.AddItem(item)
+	app.Frame()
+	return app, clock, item
+}
+func TestFrame(t *testing.T) {
+	app, clock, item := testLoopApp()
+	clock.Advance(0.035)
+	if updates := app.Frame(); updates != 3 {
+		t.Errorf("Invalid number of updates. Instead of '3', we have '%d'.", updates)
+	}
+	if item.updates != 3 || item.dt != 0.01 {
+		t.Error("Items haven't been updated with the fixed step")
+	}
+	if app.Alpha() < 0.49 || app.Alpha() > 0.51 {
+		t.Errorf("Invalid alpha '%f'.", app.Alpha())
+	}
+}
+func TestFrameInput(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	m := input.New()
+	m.BindAction("jump", input.KeyBinding(glfw.KeySpace, 0))
+	app.SetInputMap(m)
+	pressed := 0
+	app.SetUpdateCallback(func(dt float64) {
+		if m.Pressed("jump") {
+			pressed++
+		}
+	})
+	app.KeyCallback(nil, glfw.KeySpace, 0, glfw.Press, 0)
+	// the event of a frame without update is kept for the next one.
+	clock.Advance(0.005)
+	app.Frame()
+	clock.Advance(0.035)
+	app.Frame()
+	if pressed != 1 {
+		t.Errorf("The press should be seen by exactly one update, instead of '%d'.", pressed)
+	}
+	if !m.IsDown("jump") {
+		t.Error("The action should be down")
+	}
+}
+func TestFrameMaxFrameTime(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	// exact binary fractions, so that the accumulator doesn't suffer from rounding.
+	app.SetStep(1.0 / 64.0)
+	app.SetMaxFrameTime(1.0 / 16.0)
+	clock.Advance(10)
+	if updates := app.Frame(); updates != 4 {
+		t.Errorf("Invalid number of updates. Instead of '4', we have '%d'.", updates)
+	}
+}
+func TestPauseStep(t *testing.T) {
+	app, clock, item := testLoopApp()
+	app.Pause()
+	if !app.IsPaused() {
+		t.Error("Application should be paused")
+	}
+	clock.Advance(0.1)
+	app.Frame()
+	if item.updates != 0 {
+		t.Error("Paused application shouldn't update")
+	}
+	app.Step()
+	clock.Advance(0.1)
+	app.Frame()
+	if item.updates != 1 {
+		t.Error("Step should update once")
+	}
+	app.Resume()
+	clock.Advance(0.02)
+	app.Frame()
+	if item.updates != 3 {
+		t.Errorf("Invalid number of updates after resume '%d'.", item.updates)
+	}
+}
+func TestTimeScale(t *testing.T) {
+	app, clock, item := testLoopApp()
+	app.SetTimeScale(0.5)
+	if app.GetTimeScale() != 0.5 {
+		t.Error("Invalid time scale")
+	}
+	clock.Advance(0.04)
+	app.Frame()
+	if item.updates != 2 {
+		t.Errorf("Invalid number of updates '%d'.", item.updates)
+	}
+	if app.GetElapsedTime() < 0.0199 || app.GetElapsedTime() > 0.0201 {
+		t.Error("Invalid elapsed time")
+	}
+}
+func TestUpdateCallback(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	calls := 0
+	app.SetUpdateCallback(func(dt float64) {
+		calls++
+	})
+	clock.Advance(0.02)
+	app.Frame()
+	if calls != 2 {
+		t.Errorf("Invalid number of callback calls '%d'.", calls)
+	}
+}
+func TestRun(t *testing.T) {
+	originalPollEvents, originalClearScreen := pollEvents, clearScreen
+	defer func() {
+		pollEvents, clearScreen = originalPollEvents, originalClearScreen
+	}()
+	pollEvents = func() {}
+	clearScreen = func() {}
+	app, _, item := testLoopApp()
+	window := &closingWindow{frames: 3}
+	app.SetWindow(window)
+	renders := 0
+	app.SetRenderCallback(func(alpha float64) {
+		renders++
+	})
+	app.Run()
+	if renders != 3 || window.swaps != 3 {
+		t.Error("Invalid number of frames")
+	}
+	if item.updates != 0 {
+		t.Error("The clock hasn't been advanced, so that update shouldn't happen")
+	}
+}
+
+type closingWindow struct {
+	WindowMock
+	frames int
+	swaps  int
+}
+
+func (c *closingWindow) ShouldClose() bool {
+	return c.swaps >= c.frames
+}
+func (c *closingWindow) SwapBuffers() {
+	c.swaps++
+}
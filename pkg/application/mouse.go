@@ -0,0 +1,151 @@
+package application
+
+import (
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// DEFAULT_MIN_FOV and DEFAULT_MAX_FOV are the limits of the scroll zoom in degrees.
+	DEFAULT_MIN_FOV = float32(10)
+	DEFAULT_MAX_FOV = float32(90)
+)
+
+// mouse stores the cursor movement and the scroll offsets. The events are
+// accumulated between the frames.
+type mouse struct {
+	positionSet   bool
+	pendingDelta  [2]float64
+	delta         [2]float64
+	pendingScroll [2]float64
+	scroll        [2]float64
+
+	captured bool
+	// the mouse look is disabled if the sensitivity is 0.
+	lookSensitivity float32
+	// the scroll zoom is disabled if the speed is 0.
+	zoomSpeed float32
+	minFov    float32
+	maxFov    float32
+}
+
+// CursorPosCallback is responsible for the cursor movement event handling.
+// The events of the window are ignored during the replay.
+func (a *Application) CursorPosCallback(w *glfw.Window, x, y float64) {
+	if a.IsReplaying() {
+		return
+	}
+	a.CursorPosEvent(x, y)
+}
+
+// CursorPosEvent updates the cursor position and accumulates the movement. It
+// could be used without glfw window (eg: tests, replays).
+func (a *Application) CursorPosEvent(x, y float64) {
+	if a.mouse.positionSet && x == a.MousePosX && y == a.MousePosY {
+		return
+	}
+	a.recordEvent(InputEvent{Type: EVENT_CURSOR, X: x, Y: y})
+	if a.mouse.positionSet {
+		a.mouse.pendingDelta[0] += x - a.MousePosX
+		a.mouse.pendingDelta[1] += y - a.MousePosY
+	}
+	a.MousePosX, a.MousePosY = x, y
+	a.mouse.positionSet = true
+	if a.input != nil {
+		a.input.CursorPosEvent(x, y)
+	}
+}
+
+// ScrollCallback is responsible for the scroll event handling. The events of
+// the window are ignored during the replay.
+func (a *Application) ScrollCallback(w *glfw.Window, xOffset, yOffset float64) {
+	if a.IsReplaying() {
+		return
+	}
+	a.ScrollEvent(xOffset, yOffset)
+}
+
+// ScrollEvent accumulates the scroll offsets. The offsets are also passed to the input map.
+func (a *Application) ScrollEvent(xOffset, yOffset float64) {
+	a.recordEvent(InputEvent{Type: EVENT_SCROLL, X: xOffset, Y: yOffset})
+	a.mouse.pendingScroll[0] += xOffset
+	a.mouse.pendingScroll[1] += yOffset
+	if a.input != nil {
+		a.input.ScrollEvent(xOffset, yOffset)
+	}
+}
+
+// GetMouseDelta returns the cursor movement of the current frame in pixels.
+func (a *Application) GetMouseDelta() (float64, float64) {
+	return a.mouse.delta[0], a.mouse.delta[1]
+}
+
+// GetScroll returns the scroll offsets of the current frame.
+func (a *Application) GetScroll() (float64, float64) {
+	return a.mouse.scroll[0], a.mouse.scroll[1]
+}
+
+// SetCursorCaptured hides the cursor and locks it to the window (the movement
+// is unlimited), or it releases the cursor.
+func (a *Application) SetCursorCaptured(captured bool) {
+	a.mouse.captured = captured
+	// the position jumps on mode change, it shouldn't be a movement.
+	a.mouse.positionSet = false
+	if a.input != nil {
+		a.input.ResetCursor()
+	}
+	if a.window == nil {
+		return
+	}
+	if captured {
+		a.window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	} else {
+		a.window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	}
+}
+
+// IsCursorCaptured returns true if the cursor is captured.
+func (a *Application) IsCursorCaptured() bool {
+	return a.mouse.captured
+}
+
+// SetMouseLook sets the sensitivity of the mouse look (degrees / pixel). While
+// the cursor is captured, the mouse movement rotates the camera. The 0
+// sensitivity disables it.
+func (a *Application) SetMouseLook(sensitivity float32) {
+	a.mouse.lookSensitivity = sensitivity
+}
+
+// SetScrollZoom sets the speed of the scroll zoom (degrees / scroll unit) and
+// the limits of the field of view. The scroll changes the fov of the camera.
+// The 0 speed disables it.
+func (a *Application) SetScrollZoom(speed, minFov, maxFov float32) {
+	a.mouse.zoomSpeed = speed
+	a.mouse.minFov = minFov
+	a.mouse.maxFov = maxFov
+}
+
+// updateMouse closes the accumulation of the mouse events of the frame, and
+// applies the mouse look and the scroll zoom.
+func (a *Application) updateMouse() {
+	a.mouse.delta, a.mouse.pendingDelta = a.mouse.pendingDelta, [2]float64{}
+	a.mouse.scroll, a.mouse.pendingScroll = a.mouse.pendingScroll, [2]float64{}
+	if !a.cameraSet {
+		return
+	}
+	dx, dy := a.GetMouseDelta()
+	if a.mouse.captured && a.mouse.lookSensitivity != 0 && (dx != 0 || dy != 0) {
+		// the y axis of the window goes down.
+		a.camera.UpdateDirection(float32(dx)*a.mouse.lookSensitivity, -float32(dy)*a.mouse.lookSensitivity)
+	}
+	if _, scroll := a.GetScroll(); a.mouse.zoomSpeed != 0 && scroll != 0 {
+		// the fov of the camera is in radians, the speed and the limits are in degrees.
+		fov := mgl32.RadToDeg(a.camera.GetFov()) - float32(scroll)*a.mouse.zoomSpeed
+		if fov < a.mouse.minFov {
+			fov = a.mouse.minFov
+		} else if fov > a.mouse.maxFov {
+			fov = a.mouse.maxFov
+		}
+		a.camera.SetFov(mgl32.DegToRad(fov))
+	}
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/input"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// lookCamera records the direction updates and the fov.
+type lookCamera struct {
+	CameraMock
+	yaw, pitch float32
+	fov        float32
+}
+
+func (c *lookCamera) UpdateDirection(x, y float32) {
+	c.yaw += x
+	c.pitch += y
+}
+func (c *lookCamera) GetFov() float32 {
+	return c.fov
+}
+func (c *lookCamera) SetFov(fov float32) {
+	c.fov = fov
+}
+
+// modeWindow records the input mode, its cursor position could be moved.
+type modeWindow struct {
+	WindowMock
+	cursorMode int
+	x, y       float64
+}
+
+func (w *modeWindow) SetInputMode(mode glfw.InputMode, value int) {
+	if mode == glfw.CursorMode {
+		w.cursorMode = value
+	}
+}
+func (w *modeWindow) GetCursorPos() (float64, float64) {
+	return w.x, w.y
+}
+
+func TestMouseDelta(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	app.CursorPosEvent(100, 100)
+	app.Frame()
+	app.CursorPosEvent(105, 100)
+	app.CursorPosEvent(110, 95)
+	app.ScrollEvent(0, 1)
+	app.ScrollEvent(0, 2)
+	clock.Advance(0.01)
+	app.Frame()
+	if dx, dy := app.GetMouseDelta(); dx != 10 || dy != -5 {
+		t.Errorf("Invalid mouse delta '%f, %f'.", dx, dy)
+	}
+	if _, scroll := app.GetScroll(); scroll != 3 {
+		t.Errorf("Invalid scroll '%f'.", scroll)
+	}
+	clock.Advance(0.01)
+	app.Frame()
+	if dx, dy := app.GetMouseDelta(); dx != 0 || dy != 0 {
+		t.Error("The delta should be reset in the next frame")
+	}
+}
+func TestMouseLook(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	window := &modeWindow{}
+	app.SetWindow(window)
+	camera := &lookCamera{fov: 45}
+	app.SetCamera(camera)
+	app.SetMouseLook(0.5)
+	window.x, window.y = 10, 10
+	app.Frame()
+	if camera.yaw != 0 || camera.pitch != 0 {
+		t.Error("The camera shouldn't be rotated without captured cursor")
+	}
+	app.SetCursorCaptured(true)
+	if !app.IsCursorCaptured() || window.cursorMode != glfw.CursorDisabled {
+		t.Error("The cursor should be captured")
+	}
+	// the first position after the capture isn't a movement. The position
+	// is polled in every frame.
+	window.x, window.y = 100, 100
+	app.Frame()
+	window.x, window.y = 110, 120
+	clock.Advance(0.01)
+	app.Frame()
+	if camera.yaw != 5 || camera.pitch != -10 {
+		t.Errorf("Invalid camera rotation '%f, %f'.", camera.yaw, camera.pitch)
+	}
+	app.SetCursorCaptured(false)
+	if app.IsCursorCaptured() || window.cursorMode != glfw.CursorNormal {
+		t.Error("The cursor should be released")
+	}
+}
+func TestScrollZoom(t *testing.T) {
+	app, clock, _ := testLoopApp()
+	camera := &lookCamera{fov: mgl32.DegToRad(45)}
+	app.SetCamera(camera)
+	app.ScrollEvent(0, 1)
+	app.Frame()
+	if camera.fov != mgl32.DegToRad(45) {
+		t.Error("The zoom is disabled by default")
+	}
+	app.SetScrollZoom(5, DEFAULT_MIN_FOV, DEFAULT_MAX_FOV)
+	app.ScrollEvent(0, 2)
+	clock.Advance(0.01)
+	app.Frame()
+	// the fov of the camera is in radians.
+	if d := mgl32.RadToDeg(camera.fov); d < 34.999 || d > 35.001 {
+		t.Errorf("Invalid fov '%f'.", d)
+	}
+	app.ScrollEvent(0, 100)
+	app.Frame()
+	if camera.fov != mgl32.DegToRad(DEFAULT_MIN_FOV) {
+		t.Errorf("The fov should be clamped '%f'.", camera.fov)
+	}
+}
+func TestCursorCaptureResetsInputMap(t *testing.T) {
+	app := New()
+	m := input.New()
+	m.BindAxis("look_x", input.MouseDeltaBinding(input.AXIS_X))
+	app.SetInputMap(m)
+	app.CursorPosEvent(10, 10)
+	app.SetCursorCaptured(true)
+	// the cursor jumps on capture.
+	app.CursorPosEvent(500, 500)
+	m.Update()
+	if m.Axis("look_x") != 0 {
+		t.Errorf("The jump shouldn't be a movement '%f'.", m.Axis("look_x"))
+	}
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+)
+
+// GetRay returns the ray of the given window position (in screen coordinates,
+// like the cursor position) in world coordinates. It needs camera.
+func (a *Application) GetRay(x, y float64) picking.Ray {
+	mX, mY := a.MouseCoordinates(x, y)
+	return picking.NewCameraRay(float32(mX), float32(mY), a.camera)
+}
+
+// GetCursorRay returns the ray of the current cursor position.
+func (a *Application) GetCursorRay() picking.Ray {
+	return a.GetRay(a.MousePosX, a.MousePosY)
+}
+
+// Pick returns the nearest hit of the Pickable items at the given window
+// position. It returns false without camera or if nothing is hit.
+func (a *Application) Pick(x, y float64) (picking.Hit, bool) {
+	if !a.cameraSet {
+		return picking.Hit{}, false
+	}
+	items := make([]interface{}, len(a.items))
+	for i, item := range a.items {
+		items[i] = item
+	}
+	return a.GetRay(x, y).Pick(items)
+}
+
+// PickCursor returns the nearest hit of the Pickable items at the current
+// cursor position.
+func (a *Application) PickCursor() (picking.Hit, bool) {
+	return a.Pick(a.MousePosX, a.MousePosY)
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// pickableMock is a triangle in the given depth around the origo.
+type pickableMock struct {
+	DrawableMock
+	z float32
+}
+
+func (p *pickableMock) GetBoundingBox() bounds.AABB {
+	return bounds.AABB{Min: mgl32.Vec3{-0.5, -0.5, p.z}, Max: mgl32.Vec3{0.5, 0.5, p.z}}
+}
+func (p *pickableMock) GetTriangles() [][3]mgl32.Vec3 {
+	return [][3]mgl32.Vec3{{{-0.5, -0.5, p.z}, {0.5, -0.5, p.z}, {0, 0.5, p.z}}}
+}
+func TestPick(t *testing.T) {
+	app := New()
+	app.size.windowWidth, app.size.windowHeight = 800, 600
+	far := &pickableMock{z: 0.5}
+	nearest := &pickableMock{z: -0.5}
+	app.AddItem(far)
+	app.AddItem(dm)
+	app.AddItem(nearest)
+	if _, ok := app.Pick(400, 300); ok {
+		t.Error("Nothing should be picked without camera")
+	}
+	// the world of the camera mock is the ndc cube, the rays go to the +z direction.
+	app.SetCamera(cm)
+	hit, ok := app.Pick(400, 300)
+	if !ok || hit.Item != nearest {
+		t.Fatalf("The nearest item should be picked, '%v'.", hit)
+	}
+	if hit.Point.Sub(mgl32.Vec3{0, 0, -0.5}).Len() > 1e-5 || !mgl32.FloatEqual(hit.Distance, 0.5) {
+		t.Errorf("Invalid hit '%v'.", hit)
+	}
+	app.CursorPosEvent(0, 0)
+	if _, ok := app.PickCursor(); ok {
+		t.Error("Nothing should be picked in the corner")
+	}
+	ray := app.GetCursorRay()
+	if ray.Origin.Sub(mgl32.Vec3{-1, 1, -1}).Len() > 1e-5 {
+		t.Errorf("Invalid cursor ray '%v'.", ray)
+	}
+}
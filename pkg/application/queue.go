@@ -0,0 +1,194 @@
+package application
+
+import (
+	"sort"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// beginTransparent and endTransparent set up the gl state of the transparent
+// commands. They are variables, so that the queue could be tested without gl context.
+var beginTransparent = func(blendMode int) {
+	wrapper.SetBlendMode(blendMode)
+	wrapper.DepthMask(false)
+}
+var endTransparent = func() {
+	wrapper.DepthMask(true)
+	wrapper.SetBlendMode(wrapper.BLEND_MODE_NONE)
+}
+
+// DrawCommand is an item of the render queue. The Shader, Material, Texture
+// and Mesh have to be comparable (eg. pointers), they are only compared. The
+// commands with the same values are drawn after each other, so that the state
+// changes are minimized. The Transform is the model matrix, its translation
+// is used for the camera distance.
+type DrawCommand struct {
+	Shader      interface{}
+	Material    interface{}
+	Texture     interface{}
+	Mesh        interface{}
+	Transform   mgl32.Mat4
+	Transparent bool
+	// Draw draws the item with the view and projection matrices.
+	Draw func(mgl32.Mat4, mgl32.Mat4)
+}
+
+// Queueable is a drawable item that could be sorted by the render queue.
+type Queueable interface {
+	Drawable
+	// GetRenderKeys returns the shader, the material, the texture and the
+	// mesh of the item. The missing keys are nil.
+	GetRenderKeys() (interface{}, interface{}, interface{}, interface{})
+	// GetTransform returns the model matrix of the item.
+	GetTransform() mgl32.Mat4
+	IsTransparent() bool
+}
+
+// NewDrawCommand returns the draw command of the item.
+func NewDrawCommand(item Queueable) DrawCommand {
+	shader, material, texture, mesh := item.GetRenderKeys()
+	return DrawCommand{
+		Shader:      shader,
+		Material:    material,
+		Texture:     texture,
+		Mesh:        mesh,
+		Transform:   item.GetTransform(),
+		Transparent: item.IsTransparent(),
+		Draw:        item.DrawWithUniforms,
+	}
+}
+
+// RenderQueue collects the draw commands of a frame. The opaque commands are
+// sorted by shader, material, texture and mesh, the transparent ones are
+// sorted back to front by the distance from the camera.
+type RenderQueue struct {
+	opaque      []DrawCommand
+	transparent []DrawCommand
+	blendMode   int
+}
+
+// NewRenderQueue returns an empty render queue. The transparent commands are
+// drawn with alpha blending.
+func NewRenderQueue() *RenderQueue {
+	return &RenderQueue{
+		opaque:      []DrawCommand{},
+		transparent: []DrawCommand{},
+		blendMode:   wrapper.BLEND_MODE_ALPHA,
+	}
+}
+
+// SetBlendMode updates the blend mode of the transparent commands.
+func (q *RenderQueue) SetBlendMode(mode int) {
+	q.blendMode = mode
+}
+
+// GetBlendMode returns the blend mode of the transparent commands.
+func (q *RenderQueue) GetBlendMode() int {
+	return q.blendMode
+}
+
+// Add inserts the command to the queue.
+func (q *RenderQueue) Add(c DrawCommand) {
+	if c.Transparent {
+		q.transparent = append(q.transparent, c)
+		return
+	}
+	q.opaque = append(q.opaque, c)
+}
+
+// Len returns the number of the queued commands.
+func (q *RenderQueue) Len() int {
+	return len(q.opaque) + len(q.transparent)
+}
+
+// Clear removes the commands from the queue.
+func (q *RenderQueue) Clear() {
+	q.opaque = q.opaque[:0]
+	q.transparent = q.transparent[:0]
+}
+
+// Sort sorts the commands. The keys are ordered by their first occurrence, so
+// that the order of the groups is stable between the frames.
+func (q *RenderQueue) Sort(cameraPosition mgl32.Vec3) {
+	shaders := groupOrder{}
+	materials := groupOrder{}
+	textures := groupOrder{}
+	meshes := groupOrder{}
+	for _, c := range q.opaque {
+		shaders.add(c.Shader)
+		materials.add(c.Material)
+		textures.add(c.Texture)
+		meshes.add(c.Mesh)
+	}
+	sort.SliceStable(q.opaque, func(i, j int) bool {
+		a, b := q.opaque[i], q.opaque[j]
+		if shaders[a.Shader] != shaders[b.Shader] {
+			return shaders[a.Shader] < shaders[b.Shader]
+		}
+		if materials[a.Material] != materials[b.Material] {
+			return materials[a.Material] < materials[b.Material]
+		}
+		if textures[a.Texture] != textures[b.Texture] {
+			return textures[a.Texture] < textures[b.Texture]
+		}
+		return meshes[a.Mesh] < meshes[b.Mesh]
+	})
+	sort.SliceStable(q.transparent, func(i, j int) bool {
+		return distance(q.transparent[i], cameraPosition) > distance(q.transparent[j], cameraPosition)
+	})
+}
+
+// Opaque returns the opaque commands in drawing order.
+func (q *RenderQueue) Opaque() []DrawCommand {
+	return q.opaque
+}
+
+// Transparent returns the transparent commands in drawing order.
+func (q *RenderQueue) Transparent() []DrawCommand {
+	return q.transparent
+}
+
+// Submit draws the commands, the opaque ones first.
+func (q *RenderQueue) Submit(view, projection mgl32.Mat4) {
+	q.SubmitOpaque(view, projection)
+	q.SubmitTransparent(view, projection)
+}
+
+// SubmitOpaque draws the opaque commands.
+func (q *RenderQueue) SubmitOpaque(view, projection mgl32.Mat4) {
+	for _, c := range q.opaque {
+		c.Draw(view, projection)
+	}
+}
+
+// SubmitTransparent draws the transparent commands with the blend mode of the
+// queue. The depth test is kept, but the depth buffer isn't written, so that
+// the transparent commands don't hide each other. The blending is disabled
+// and the depth writing is enabled after the drawing.
+func (q *RenderQueue) SubmitTransparent(view, projection mgl32.Mat4) {
+	if len(q.transparent) == 0 {
+		return
+	}
+	beginTransparent(q.blendMode)
+	for _, c := range q.transparent {
+		c.Draw(view, projection)
+	}
+	endTransparent()
+}
+
+// groupOrder maps the keys to the index of their first occurrence.
+type groupOrder map[interface{}]int
+
+func (g groupOrder) add(key interface{}) {
+	if _, ok := g[key]; !ok {
+		g[key] = len(g)
+	}
+}
+
+// distance returns the squared distance of the command from the camera.
+func distance(c DrawCommand, cameraPosition mgl32.Vec3) float32 {
+	d := c.Transform.Col(3).Vec3().Sub(cameraPosition)
+	return d.Dot(d)
+}
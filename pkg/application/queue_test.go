@@ -0,0 +1,123 @@
+package application
+
+import (
+	"testing"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// queueableMock records the drawing order to the given slice.
+type queueableMock struct {
+	DrawableMock
+	name        string
+	shader      string
+	material    string
+	position    mgl32.Vec3
+	transparent bool
+	drawn       *[]string
+}
+
+func (q *queueableMock) DrawWithUniforms(v, p mgl32.Mat4) {
+	*q.drawn = append(*q.drawn, q.name)
+}
+func (q *queueableMock) GetRenderKeys() (interface{}, interface{}, interface{}, interface{}) {
+	return q.shader, q.material, nil, nil
+}
+func (q *queueableMock) GetTransform() mgl32.Mat4 {
+	return mgl32.Translate3D(q.position.X(), q.position.Y(), q.position.Z())
+}
+func (q *queueableMock) IsTransparent() bool {
+	return q.transparent
+}
+
+func equalOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeTransparentState records the gl state calls of the transparent commands.
+// It returns the function that restores the original state functions.
+func fakeTransparentState(drawn *[]string) func() {
+	originalBegin, originalEnd := beginTransparent, endTransparent
+	beginTransparent = func(mode int) {
+		*drawn = append(*drawn, "begin")
+	}
+	endTransparent = func() {
+		*drawn = append(*drawn, "end")
+	}
+	return func() {
+		beginTransparent, endTransparent = originalBegin, originalEnd
+	}
+}
+func TestRenderQueueSort(t *testing.T) {
+	var drawn []string
+	defer fakeTransparentState(&drawn)()
+	q := NewRenderQueue()
+	items := []*queueableMock{
+		{name: "a", shader: "s1", material: "m1"},
+		{name: "b", shader: "s2", material: "m1"},
+		{name: "c", shader: "s1", material: "m2"},
+		{name: "d", shader: "s1", material: "m1"},
+		{name: "near", shader: "s1", transparent: true, position: mgl32.Vec3{0, 0, 1}},
+		{name: "far", shader: "s1", transparent: true, position: mgl32.Vec3{0, 0, -10}},
+	}
+	for _, item := range items {
+		item.drawn = &drawn
+		q.Add(NewDrawCommand(item))
+	}
+	if q.Len() != 6 || len(q.Opaque()) != 4 || len(q.Transparent()) != 2 {
+		t.Fatal("Invalid queue size")
+	}
+	q.Sort(mgl32.Vec3{0, 0, 2})
+	q.Submit(mgl32.Ident4(), mgl32.Ident4())
+	if !equalOrder(drawn, []string{"a", "d", "c", "b", "begin", "far", "near", "end"}) {
+		t.Errorf("Invalid drawing order '%v'.", drawn)
+	}
+	q.Clear()
+	if q.Len() != 0 {
+		t.Error("Queue should be empty")
+	}
+}
+func TestDrawWithUniformsQueue(t *testing.T) {
+	var drawn []string
+	defer fakeTransparentState(&drawn)()
+	app := New()
+	app.AddItem(&queueableMock{name: "glass", transparent: true, drawn: &drawn})
+	app.AddItem(&queueableMock{name: "a", shader: "s1", drawn: &drawn})
+	app.AddItem(&queueableMock{name: "b", shader: "s2", drawn: &drawn})
+	app.AddItem(dm)
+	app.AddItem(&queueableMock{name: "c", shader: "s1", drawn: &drawn})
+	app.DrawWithUniforms()
+	if !equalOrder(drawn, []string{"a", "c", "b", "begin", "glass", "end"}) {
+		t.Errorf("Invalid drawing order '%v'.", drawn)
+	}
+	if app.GetRenderQueue().Len() != 4 {
+		t.Error("Invalid queue size")
+	}
+}
+func TestRenderQueueOpaque(t *testing.T) {
+	var drawn []string
+	defer fakeTransparentState(&drawn)()
+	q := NewRenderQueue()
+	q.Add(NewDrawCommand(&queueableMock{name: "a", drawn: &drawn}))
+	q.Submit(mgl32.Ident4(), mgl32.Ident4())
+	if !equalOrder(drawn, []string{"a"}) {
+		t.Errorf("The transparent state shouldn't be set without transparent commands '%v'.", drawn)
+	}
+	if q.GetBlendMode() != wrapper.BLEND_MODE_ALPHA {
+		t.Error("Invalid default blend mode")
+	}
+	q.SetBlendMode(wrapper.BLEND_MODE_ADDITIVE)
+	if q.GetBlendMode() != wrapper.BLEND_MODE_ADDITIVE {
+		t.Error("Invalid blend mode")
+	}
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+const (
+	// The types of the recorded events.
+	EVENT_FRAME        = "frame"
+	EVENT_KEY          = "key"
+	EVENT_MOUSE_BUTTON = "mouse_button"
+	EVENT_CURSOR       = "cursor"
+	EVENT_SCROLL       = "scroll"
+	EVENT_GAMEPAD      = "gamepad"
+)
+
+// InputEvent is a recorded event. The frame is the number of the frame that
+// handles the event. The frame events store the frame time, so that the
+// replay runs the same number of fixed updates in every frame. The cursor
+// events store the position, the scroll events store the offsets in X, Y.
+// The gamepad events store the raw state, the nil state is the disconnection.
+type InputEvent struct {
+	Frame     int                `json:"frame"`
+	Type      string             `json:"type"`
+	FrameTime time.Duration      `json:"frameTime,omitempty"`
+	Key       glfw.Key           `json:"key,omitempty"`
+	Scancode  int                `json:"scancode,omitempty"`
+	Button    glfw.MouseButton   `json:"button,omitempty"`
+	Action    glfw.Action        `json:"action,omitempty"`
+	Mods      glfw.ModifierKey   `json:"mods,omitempty"`
+	X         float64            `json:"x,omitempty"`
+	Y         float64            `json:"y,omitempty"`
+	Joystick  glfw.Joystick      `json:"joystick,omitempty"`
+	Gamepad   *glfw.GamepadState `json:"gamepad,omitempty"`
+}
+
+// recorder writes the events as json lines.
+type recorder struct {
+	encoder *json.Encoder
+	err     error
+}
+
+// replay stores the events that haven't been applied yet and the time of
+// the replay clock.
+type replay struct {
+	active bool
+	events []InputEvent
+	now    time.Time
+	clock  func() time.Time
+}
+
+// StartRecording starts the recording of the input events to the writer. The
+// events are written as json lines.
+func (a *Application) StartRecording(w io.Writer) {
+	a.recorder = recorder{encoder: json.NewEncoder(w)}
+}
+
+// StopRecording stops the recording. It returns the first write error.
+func (a *Application) StopRecording() error {
+	err := a.recorder.err
+	a.recorder = recorder{}
+	return err
+}
+
+// IsRecording returns true if the input events are recorded.
+func (a *Application) IsRecording() bool {
+	return a.recorder.encoder != nil
+}
+
+// GetFrameNumber returns the number of the frames since the start of the application.
+func (a *Application) GetFrameNumber() int {
+	return a.loop.frame
+}
+
+// recordEvent writes the event with the current frame number. The events of
+// the replay aren't recorded.
+func (a *Application) recordEvent(event InputEvent) {
+	if a.recorder.encoder == nil || a.recorder.err != nil || a.replay.active {
+		return
+	}
+	event.Frame = a.loop.frame
+	a.recorder.err = a.recorder.encoder.Encode(event)
+}
+
+// LoadRecording reads the recorded events.
+func LoadRecording(r io.Reader) ([]InputEvent, error) {
+	var events []InputEvent
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event InputEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("Invalid event in line '%d': %s", line, err.Error())
+		}
+		if len(events) > 0 && event.Frame < events[len(events)-1].Frame {
+			return nil, fmt.Errorf("The events have to be ordered by the frames, line '%d'.", line)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Replay starts the replay of the recorded events. The events of the window
+// are ignored, the recorded events are applied in the beginning of their
+// frames, and the frame times are read from the recording, so that the
+// application runs the same fixed updates as the recorded one. The replay
+// starts from the current frame number, it stops after the last event.
+func (a *Application) Replay(events []InputEvent) {
+	if len(events) == 0 {
+		return
+	}
+	offset := a.loop.frame - events[0].Frame
+	a.replay = replay{
+		active: true,
+		events: make([]InputEvent, len(events)),
+		now:    time.Unix(0, 0),
+		clock:  a.loop.clock,
+	}
+	for i, event := range events {
+		event.Frame += offset
+		a.replay.events[i] = event
+	}
+	a.loop.clock = func() time.Time {
+		return a.replay.now
+	}
+	a.loop.lastFrame = a.replay.now
+	a.loop.started = true
+}
+
+// IsReplaying returns true until the last recorded event is applied.
+func (a *Application) IsReplaying() bool {
+	return a.replay.active
+}
+
+// StopReplay stops the replay, the original clock is restored.
+func (a *Application) StopReplay() {
+	if !a.replay.active {
+		return
+	}
+	a.loop.clock = a.replay.clock
+	a.loop.started = false
+	a.replay = replay{}
+}
+
+// applyReplay applies the recorded events of the current frame. It's called
+// in the beginning of the frame.
+func (a *Application) applyReplay() {
+	if !a.replay.active {
+		return
+	}
+	for len(a.replay.events) > 0 && a.replay.events[0].Frame <= a.loop.frame {
+		event := a.replay.events[0]
+		a.replay.events = a.replay.events[1:]
+		switch event.Type {
+		case EVENT_FRAME:
+			a.replay.now = a.replay.now.Add(event.FrameTime)
+		case EVENT_KEY:
+			a.KeyEvent(event.Key, event.Scancode, event.Action, event.Mods)
+		case EVENT_MOUSE_BUTTON:
+			a.MouseButtonEvent(event.Button, event.Action, event.Mods)
+		case EVENT_CURSOR:
+			a.CursorPosEvent(event.X, event.Y)
+		case EVENT_SCROLL:
+			a.ScrollEvent(event.X, event.Y)
+		case EVENT_GAMEPAD:
+			a.GamepadEvent(event.Joystick, event.Gamepad)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// session runs a few frames with events and returns the log of the updates.
+func session(app *Application, clock *fakeClock, frameTimes []float64) []string {
+	var log []string
+	app.SetUpdateCallback(func(dt float64) {
+		_, scroll := app.GetScroll()
+		log = append(log, fmt.Sprintf("%v %v %.0f %.0f %.0f", app.GetKeyState(glfw.KeyW), app.GetMouseButtonState(glfw.MouseButtonLeft), app.MousePosX, app.MousePosY, scroll))
+	})
+	for i, frameTime := range frameTimes {
+		if clock != nil {
+			clock.Advance(frameTime)
+			switch i {
+			case 1:
+				app.KeyEvent(glfw.KeyW, 17, glfw.Press, 0)
+			case 2:
+				app.CursorPosEvent(10, 20)
+				app.MouseButtonEvent(glfw.MouseButtonLeft, glfw.Press, 0)
+			case 4:
+				app.KeyEvent(glfw.KeyW, 17, glfw.Release, 0)
+				app.CursorPosEvent(30, 40)
+				app.ScrollEvent(0, 1)
+			}
+		}
+		app.Frame()
+	}
+	return log
+}
+func TestRecordReplay(t *testing.T) {
+	frameTimes := []float64{0.016, 0.035, 0.004, 0.021, 0.017, 0.01}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	recorded := New()
+	recorded.SetClock(clock.Now)
+	recorded.SetStep(0.01)
+	var buf bytes.Buffer
+	recorded.StartRecording(&buf)
+	if !recorded.IsRecording() {
+		t.Error("The application should be recording")
+	}
+	expected := session(recorded, clock, frameTimes)
+	if err := recorded.StopRecording(); err != nil {
+		t.Fatalf("Recording failed: %s", err.Error())
+	}
+	if recorded.GetFrameNumber() != len(frameTimes) {
+		t.Errorf("Invalid frame number '%d'.", recorded.GetFrameNumber())
+	}
+
+	events, err := LoadRecording(&buf)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %s", err.Error())
+	}
+	replayed := New()
+	replayed.SetStep(0.01)
+	replayed.Replay(events)
+	if !replayed.IsReplaying() {
+		t.Error("The application should be replaying")
+	}
+	// the events of the window are ignored.
+	replayed.KeyCallback(nil, glfw.KeyW, 17, glfw.Press, 0)
+	result := session(replayed, nil, frameTimes)
+	if replayed.IsReplaying() {
+		t.Error("The replay should be finished after the last event")
+	}
+	if strings.Join(result, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Invalid replay.\nExpected:\n%s\nResult:\n%s", strings.Join(expected, "\n"), strings.Join(result, "\n"))
+	}
+}
+func TestLoadRecording(t *testing.T) {
+	events, err := LoadRecording(strings.NewReader(`{"frame":0,"type":"frame"}
+
+{"frame":1,"type":"key","key":87,"action":1}
+`))
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %s", err.Error())
+	}
+	if len(events) != 2 || events[1].Key != glfw.KeyW || events[1].Action != glfw.Press {
+		t.Errorf("Invalid events '%v'.", events)
+	}
+	if _, err := LoadRecording(strings.NewReader("{\"frame\":2}\n{\"frame\":1}\n")); err == nil {
+		t.Error("Unordered events should be an error")
+	}
+	if _, err := LoadRecording(strings.NewReader("invalid\n")); err == nil {
+		t.Error("Invalid json should be an error")
+	}
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// RenderTarget is an offscreen render target that follows the size of the
+// framebuffer (eg: *framebuffer.Framebuffer).
+type RenderTarget interface {
+	Resize(width, height int) error
+}
+
+// size stores the size of the window in screen coordinates and the size of
+// the framebuffer in pixels. They are different on HiDPI displays.
+type size struct {
+	windowWidth       int
+	windowHeight      int
+	framebufferWidth  int
+	framebufferHeight int
+	targets           []RenderTarget
+	callback          func(int, int)
+}
+
+// setViewport updates the viewport of the default framebuffer.
+var setViewport = func(width, height int) {
+	wrapper.Viewport(0, 0, int32(width), int32(height))
+}
+
+// FramebufferSizeCallback is responsible for the framebuffer size event
+// handling. The size is also polled in every frame, so that the resize is
+// handled without the callback.
+func (a *Application) FramebufferSizeCallback(w *glfw.Window, width, height int) {
+	a.FramebufferSizeEvent(width, height)
+}
+
+// FramebufferSizeEvent updates the viewport, the aspect ratio of the camera
+// and the size of the render targets. The 0 size (minimized window) is ignored.
+// It panics if a render target couldn't be resized.
+func (a *Application) FramebufferSizeEvent(width, height int) {
+	if width <= 0 || height <= 0 || width == a.size.framebufferWidth && height == a.size.framebufferHeight {
+		return
+	}
+	a.size.framebufferWidth, a.size.framebufferHeight = width, height
+	setViewport(width, height)
+	if a.cameraSet {
+		a.camera.SetAspectRatio(float32(width) / float32(height))
+	}
+	for _, target := range a.size.targets {
+		if err := target.Resize(width, height); err != nil {
+			panic(err)
+		}
+	}
+	if a.size.callback != nil {
+		a.size.callback(width, height)
+	}
+}
+
+// SetResizeCallback sets the function that is called with the new framebuffer
+// size (pixels) after the resize.
+func (a *Application) SetResizeCallback(callback func(int, int)) {
+	a.size.callback = callback
+}
+
+// AddRenderTarget appends the target to the resized ones. It's resized to the
+// current framebuffer size.
+func (a *Application) AddRenderTarget(target RenderTarget) error {
+	a.size.targets = append(a.size.targets, target)
+	if a.size.framebufferWidth == 0 {
+		return nil
+	}
+	return target.Resize(a.size.framebufferWidth, a.size.framebufferHeight)
+}
+
+// GetWindowSize returns the size of the window in screen coordinates. The
+// cursor position is also in screen coordinates.
+func (a *Application) GetWindowSize() (int, int) {
+	return a.size.windowWidth, a.size.windowHeight
+}
+
+// GetFramebufferSize returns the size of the framebuffer in pixels.
+func (a *Application) GetFramebufferSize() (int, int) {
+	return a.size.framebufferWidth, a.size.framebufferHeight
+}
+
+// GetContentScale returns the ratio of the framebuffer and the window size. It's
+// greater than 1 on HiDPI displays, the cursor position has to be multiplied
+// with it to get the pixel position.
+func (a *Application) GetContentScale() (float32, float32) {
+	if a.size.windowWidth == 0 || a.size.windowHeight == 0 {
+		return 1, 1
+	}
+	return float32(a.size.framebufferWidth) / float32(a.size.windowWidth), float32(a.size.framebufferHeight) / float32(a.size.windowHeight)
+}
+
+// MouseCoordinates transforms the cursor position (screen coordinates) to
+// normalized device coordinates [-1, 1] with the current size of the window.
+func (a *Application) MouseCoordinates(x, y float64) (float64, float64) {
+	if a.size.windowWidth == 0 || a.size.windowHeight == 0 {
+		return 0, 0
+	}
+	halfWidth := float64(a.size.windowWidth) / 2.0
+	halfHeight := float64(a.size.windowHeight) / 2.0
+	return (x - halfWidth) / halfWidth, (halfHeight - y) / halfHeight
+}
+
+// pollSize reads the size of the window and the framebuffer.
+func (a *Application) pollSize() {
+	if a.window == nil {
+		return
+	}
+	width, height := a.window.GetSize()
+	if width > 0 && height > 0 {
+		a.size.windowWidth, a.size.windowHeight = width, height
+	}
+	a.FramebufferSizeEvent(a.window.GetFramebufferSize())
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+)
+
+// sizeWindow is a window with adjustable size, its framebuffer is scaled with
+// the content scale.
+type sizeWindow struct {
+	WindowMock
+	width, height int
+	scale         int
+}
+
+func (w *sizeWindow) GetSize() (int, int) {
+	return w.width, w.height
+}
+func (w *sizeWindow) GetFramebufferSize() (int, int) {
+	return w.width * w.scale, w.height * w.scale
+}
+
+// aspectCamera records the aspect ratio.
+type aspectCamera struct {
+	CameraMock
+	aspect float32
+}
+
+func (c *aspectCamera) SetAspectRatio(aspect float32) {
+	c.aspect = aspect
+}
+
+// sizeTarget records the size of the resizes.
+type sizeTarget struct {
+	width, height int
+	err           error
+}
+
+func (t *sizeTarget) Resize(width, height int) error {
+	t.width, t.height = width, height
+	return t.err
+}
+
+func TestResize(t *testing.T) {
+	originalSetViewport := setViewport
+	defer func() {
+		setViewport = originalSetViewport
+	}()
+	var viewports [][2]int
+	setViewport = func(width, height int) {
+		viewports = append(viewports, [2]int{width, height})
+	}
+	app, clock, _ := testLoopApp()
+	w := &sizeWindow{width: 400, height: 300, scale: 2}
+	app.SetWindow(w)
+	cam := &aspectCamera{}
+	app.SetCamera(cam)
+	target := &sizeTarget{}
+	if err := app.AddRenderTarget(target); err != nil {
+		t.Fatalf("AddRenderTarget failed: %s", err.Error())
+	}
+	var resizes []string
+	app.SetResizeCallback(func(width, height int) {
+		resizes = append(resizes, fmt.Sprintf("%dx%d", width, height))
+	})
+	app.Frame()
+	if width, height := app.GetFramebufferSize(); width != 800 || height != 600 {
+		t.Errorf("Invalid framebuffer size '%dx%d'.", width, height)
+	}
+	if sx, sy := app.GetContentScale(); sx != 2 || sy != 2 {
+		t.Errorf("Invalid content scale '%f, %f'.", sx, sy)
+	}
+	if x, y := app.MouseCoordinates(300, 75); x != 0.5 || y != 0.5 {
+		t.Errorf("Invalid mouse coordinates '%f, %f'.", x, y)
+	}
+	w.width, w.height = 500, 500
+	clock.Advance(0.01)
+	app.Frame()
+	// the minimized window has 0 size.
+	w.width, w.height = 0, 0
+	clock.Advance(0.01)
+	app.Frame()
+	if len(viewports) != 2 || viewports[1] != [2]int{1000, 1000} {
+		t.Errorf("Invalid viewports '%v'.", viewports)
+	}
+	if cam.aspect != 1 || target.width != 1000 || target.height != 1000 {
+		t.Errorf("Invalid aspect ratio '%f' or target size '%dx%d'.", cam.aspect, target.width, target.height)
+	}
+	if len(resizes) != 2 || resizes[0] != "800x600" {
+		t.Errorf("Invalid resize callbacks '%v'.", resizes)
+	}
+	if width, _ := app.GetWindowSize(); width != 500 {
+		t.Error("The size of the minimized window should be ignored")
+	}
+	late := &sizeTarget{}
+	app.AddRenderTarget(late)
+	if late.width != 1000 {
+		t.Error("The new target should be resized to the current size")
+	}
+}
+func TestResizePanic(t *testing.T) {
+	originalSetViewport := setViewport
+	setViewport = func(width, height int) {}
+	app := New()
+	app.AddRenderTarget(&sizeTarget{err: fmt.Errorf("Incomplete.")})
+	defer func() {
+		setViewport = originalSetViewport
+		if r := recover(); r == nil {
+			t.Error("The failed resize should panic")
+		}
+	}()
+	app.FramebufferSizeEvent(100, 100)
+}
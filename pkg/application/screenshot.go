@@ -0,0 +1,72 @@
+package application
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/akosgarai/opengl_playground/pkg/framebuffer"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+)
+
+// viewport returns the x, y, width, height of the current viewport.
+var viewport = func() [4]int32 {
+	var v [4]int32
+	wrapper.GetIntegerv(wrapper.VIEWPORT, &v[0])
+	return v
+}
+
+// readPixels returns the given area of the current framebuffer. The rows are
+// in gl order, so that the first row is the bottom one.
+var readPixels = func(x, y, width, height int32) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	wrapper.ReadPixels(x, y, width, height, wrapper.RGBA, wrapper.UNSIGNED_BYTE, wrapper.Ptr(img.Pix))
+	return img
+}
+
+// Screenshot returns the content of the current viewport of the bound
+// framebuffer. It could be the default or an offscreen one.
+func (a *Application) Screenshot() *image.RGBA {
+	v := viewport()
+	img := readPixels(v[0], v[1], v[2], v[3])
+	framebuffer.FlipVertical(img)
+	return img
+}
+
+// SaveScreenshot writes the screenshot to the given directory as png file.
+// The file name contains the current timestamp. It returns the path of the file.
+func (a *Application) SaveScreenshot(dir string) (string, error) {
+	img := a.Screenshot()
+	path := filepath.Join(dir, "screenshot-"+time.Now().Format("20060102-150405.000")+".png")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// saveRequestedScreenshot saves the screenshot that has been requested with
+// the SCREENSHOT key. It's called after the items are drawn.
+func (a *Application) saveRequestedScreenshot() {
+	if !a.screenshotRequested {
+		return
+	}
+	a.screenshotRequested = false
+	if path, err := a.SaveScreenshot(a.screenshotDir); err != nil {
+		fmt.Printf("Screenshot failed: %s\n", err.Error())
+	} else {
+		fmt.Printf("Screenshot has been saved to '%s'.\n", path)
+	}
+}
+
+// SetScreenshotDir updates the directory of the screenshots taken with the SCREENSHOT key.
+func (a *Application) SetScreenshotDir(dir string) {
+	a.screenshotDir = dir
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"image"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// mockPixelReader replaces the pixel reader functions. It returns the
+// function that restores the original ones.
+func mockPixelReader() func() {
+	originalViewport, originalReadPixels := viewport, readPixels
+	viewport = func() [4]int32 {
+		return [4]int32{0, 0, 2, 2}
+	}
+	readPixels = func(x, y, width, height int32) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+		// the first gl row is the bottom one.
+		img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+		return img
+	}
+	return func() {
+		viewport, readPixels = originalViewport, originalReadPixels
+	}
+}
+func TestScreenshot(t *testing.T) {
+	defer mockPixelReader()()
+	app := New()
+	img := app.Screenshot()
+	if img.Bounds().Dx() != 2 || img.Bounds().Dy() != 2 {
+		t.Error("Invalid screenshot size")
+	}
+	if img.RGBAAt(0, 1) != (color.RGBA{255, 0, 0, 255}) {
+		t.Error("Screenshot hasn't been flipped")
+	}
+}
+func TestSaveScreenshot(t *testing.T) {
+	defer mockPixelReader()()
+	dir, _ := ioutil.TempDir("", "screenshot")
+	defer os.RemoveAll(dir)
+	app := New()
+	path, err := app.SaveScreenshot(dir)
+	if err != nil {
+		t.Fatalf("SaveScreenshot failed: %s", err.Error())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Missing screenshot file")
+	}
+}
+func TestScreenshotKey(t *testing.T) {
+	defer mockPixelReader()()
+	dir, _ := ioutil.TempDir("", "screenshot")
+	defer os.RemoveAll(dir)
+	app := New()
+	app.SetScreenshotDir(dir)
+	app.KeyCallback(nil, SCREENSHOT, 0, glfw.Press, 0)
+	if !app.screenshotRequested {
+		t.Error("Screenshot should be requested")
+	}
+	originalClearScreen := clearScreen
+	defer func() {
+		clearScreen = originalClearScreen
+	}()
+	clearScreen = func() {}
+	// the app without camera is drawn with the Draw function.
+	app.Render()
+	if app.screenshotRequested {
+		t.Error("Screenshot request should be handled")
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Error("Missing screenshot file")
+	}
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"time"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+)
+
+const (
+	// STATS_HISTORY is the number of the frames that are stored in the statistics.
+	STATS_HISTORY = 120
+)
+
+// FrameStats stores the timing and the draw statistics of a frame.
+type FrameStats struct {
+	// Frame is the real time since the previous frame.
+	Frame time.Duration
+	// Update is the cpu time of the fixed updates.
+	Update time.Duration
+	// Draw is the cpu time of the drawing.
+	Draw time.Duration
+	// GPU is the gpu time of the drawing. It's measured with query objects
+	// if the gpu timing is enabled. The result is read back a frame later, to
+	// prevent the stalls, so that it belongs to an earlier frame.
+	GPU time.Duration
+	// Updates is the number of the fixed updates.
+	Updates int
+	// DrawCalls is the number of the draw calls.
+	DrawCalls int
+	// Triangles is the number of the drawn triangles.
+	Triangles int
+	// SkippedStateChanges is the number of the redundant gl state changes
+	// that are skipped by the state cache of the wrapper.
+	SkippedStateChanges int
+	// CulledObjects is the number of the items that are outside of the
+	// frustum, so that they aren't drawn.
+	CulledObjects int
+}
+
+// Overlay is the interface of the stats displays, that are drawn on top of the scene.
+type Overlay interface {
+	DrawStats([]FrameStats)
+}
+
+// statsClock, resetDrawCounters, drawCounters and stateCounters are variables
+// for testing the statistics without gl context.
+var statsClock = time.Now
+var resetDrawCounters = func() {
+	wrapper.ResetDrawCounters()
+	wrapper.ResetStateCounters()
+}
+var drawCounters = func() (int, int) {
+	return wrapper.GetDrawCalls(), wrapper.GetTriangles()
+}
+var stateCounters = func() int {
+	return wrapper.GetStateCounters().Total()
+}
+
+// The gl query functions of the gpu timer. They are variables for testing
+// the timer without gl context.
+var genQuery = wrapper.GenQueries
+var beginTimeQuery = func(query uint32) {
+	wrapper.BeginQuery(wrapper.TIME_ELAPSED, query)
+}
+var endTimeQuery = func() {
+	wrapper.EndQuery(wrapper.TIME_ELAPSED)
+}
+var timeQueryResult = func(query uint32) (uint64, bool) {
+	var available int32
+	wrapper.GetQueryObjectiv(query, wrapper.QUERY_RESULT_AVAILABLE, &available)
+	if available == 0 {
+		return 0, false
+	}
+	var result uint64
+	wrapper.GetQueryObjectui64v(query, wrapper.QUERY_RESULT, &result)
+	return result, true
+}
+
+// gpuTimer measures the gpu time with two TIME_ELAPSED queries. Every frame uses
+// the other query, so that the result of the previous frame could be read
+// without waiting for the gpu.
+type gpuTimer struct {
+	queries [2]uint32
+	pending [2]bool
+	current int
+	last    time.Duration
+}
+
+// begin reads the result of the current query if it's available, and starts it again.
+func (g *gpuTimer) begin() {
+	if g.queries[0] == 0 {
+		g.queries[0] = genQuery()
+		g.queries[1] = genQuery()
+	}
+	query := g.queries[g.current]
+	if g.pending[g.current] {
+		if result, ok := timeQueryResult(query); ok {
+			g.last = time.Duration(result)
+		}
+		g.pending[g.current] = false
+	}
+	beginTimeQuery(query)
+}
+
+// end stops the current query and switches to the other one.
+func (g *gpuTimer) end() {
+	endTimeQuery()
+	g.pending[g.current] = true
+	g.current = 1 - g.current
+}
+
+// stats stores the statistics of the last frames.
+type stats struct {
+	history   [STATS_HISTORY]FrameStats
+	next      int
+	count     int
+	current   FrameStats
+	gpuTiming bool
+	timer     gpuTimer
+
+	overlay        Overlay
+	overlayVisible bool
+}
+
+// push appends the current frame to the history.
+func (s *stats) push() {
+	s.history[s.next] = s.current
+	s.next = (s.next + 1) % STATS_HISTORY
+	if s.count < STATS_HISTORY {
+		s.count++
+	}
+	s.current = FrameStats{}
+}
+
+// GetFrameStats returns the statistics of the last finished frame.
+func (a *Application) GetFrameStats() FrameStats {
+	if a.stats.count == 0 {
+		return FrameStats{}
+	}
+	return a.stats.history[(a.stats.next+STATS_HISTORY-1)%STATS_HISTORY]
+}
+
+// GetStatsHistory returns the statistics of the last STATS_HISTORY frames.
+// The first one is the oldest one.
+func (a *Application) GetStatsHistory() []FrameStats {
+	history := make([]FrameStats, a.stats.count)
+	start := (a.stats.next + STATS_HISTORY - a.stats.count) % STATS_HISTORY
+	for i := 0; i < a.stats.count; i++ {
+		history[i] = a.stats.history[(start+i)%STATS_HISTORY]
+	}
+	return history
+}
+
+// GetAverageFrameStats returns the average of the stored statistics.
+func (a *Application) GetAverageFrameStats() FrameStats {
+	var avg FrameStats
+	if a.stats.count == 0 {
+		return avg
+	}
+	for _, s := range a.GetStatsHistory() {
+		avg.Frame += s.Frame
+		avg.Update += s.Update
+		avg.Draw += s.Draw
+		avg.GPU += s.GPU
+		avg.Updates += s.Updates
+		avg.DrawCalls += s.DrawCalls
+		avg.Triangles += s.Triangles
+		avg.SkippedStateChanges += s.SkippedStateChanges
+		avg.CulledObjects += s.CulledObjects
+	}
+	n := a.stats.count
+	avg.Frame /= time.Duration(n)
+	avg.Update /= time.Duration(n)
+	avg.Draw /= time.Duration(n)
+	avg.GPU /= time.Duration(n)
+	avg.Updates /= n
+	avg.DrawCalls /= n
+	avg.Triangles /= n
+	avg.SkippedStateChanges /= n
+	avg.CulledObjects /= n
+	return avg
+}
+
+// SetGPUTiming enables or disables the gpu time measurement. It needs gl context.
+func (a *Application) SetGPUTiming(enabled bool) {
+	a.stats.gpuTiming = enabled
+}
+
+// SetOverlay sets the stats display, that is drawn on top of the scene in
+// every frame. It could be toggled with the STATS key.
+func (a *Application) SetOverlay(o Overlay) {
+	a.stats.overlay = o
+	a.stats.overlayVisible = o != nil
+}
+
+// ToggleOverlay shows or hides the stats overlay.
+func (a *Application) ToggleOverlay() {
+	a.stats.overlayVisible = !a.stats.overlayVisible && a.stats.overlay != nil
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type overlayMock struct {
+	frames int
+}
+
+func (o *overlayMock) DrawStats(history []FrameStats) {
+	o.frames = len(history)
+}
+func TestStatsHistory(t *testing.T) {
+	app := New()
+	if app.GetFrameStats() != (FrameStats{}) || len(app.GetStatsHistory()) != 0 {
+		t.Error("Stats should be empty")
+	}
+	for i := 0; i < STATS_HISTORY+5; i++ {
+		app.stats.current.DrawCalls = i
+		app.stats.push()
+	}
+	history := app.GetStatsHistory()
+	if len(history) != STATS_HISTORY {
+		t.Errorf("Invalid history length '%d'.", len(history))
+	}
+	if history[0].DrawCalls != 5 || history[STATS_HISTORY-1].DrawCalls != STATS_HISTORY+4 {
+		t.Error("Invalid history order")
+	}
+	if app.GetFrameStats().DrawCalls != STATS_HISTORY+4 {
+		t.Error("Invalid last frame stats")
+	}
+}
+func TestAverageFrameStats(t *testing.T) {
+	app := New()
+	app.stats.current = FrameStats{Frame: 10 * time.Millisecond, Triangles: 10}
+	app.stats.push()
+	app.stats.current = FrameStats{Frame: 20 * time.Millisecond, Triangles: 20}
+	app.stats.push()
+	avg := app.GetAverageFrameStats()
+	if avg.Frame != 15*time.Millisecond || avg.Triangles != 15 {
+		t.Error("Invalid average")
+	}
+}
+func TestRenderStats(t *testing.T) {
+	originalClearScreen, originalDrawCounters, originalStateCounters := clearScreen, drawCounters, stateCounters
+	defer func() {
+		clearScreen, drawCounters, stateCounters = originalClearScreen, originalDrawCounters, originalStateCounters
+	}()
+	clearScreen = func() {}
+	drawCounters = func() (int, int) {
+		return 3, 12
+	}
+	stateCounters = func() int {
+		return 7
+	}
+	app, clock, _ := testLoopApp()
+	overlay := &overlayMock{}
+	app.SetOverlay(overlay)
+	clock.Advance(0.025)
+	app.Frame()
+	app.Render()
+	stats := app.GetFrameStats()
+	if stats.Frame != 25*time.Millisecond || stats.Updates != 2 {
+		t.Error("Invalid frame stats")
+	}
+	if stats.DrawCalls != 3 || stats.Triangles != 12 || stats.SkippedStateChanges != 7 {
+		t.Error("Invalid draw stats")
+	}
+	if overlay.frames != 1 {
+		t.Error("Overlay should be drawn")
+	}
+	app.ToggleOverlay()
+	app.Frame()
+	app.Render()
+	if overlay.frames != 1 {
+		t.Error("Hidden overlay shouldn't be drawn")
+	}
+	drawn := 0
+	app.SetCamera(cm)
+	app.AddItem(&boundedMock{center: mgl32.Vec3{5, 0, 0}, drawn: &drawn})
+	app.Render()
+	if app.GetFrameStats().CulledObjects != 1 || drawn != 0 {
+		t.Error("Invalid culling stats")
+	}
+}
+func TestGPUTimer(t *testing.T) {
+	originalGenQuery, originalBeginTimeQuery := genQuery, beginTimeQuery
+	originalEndTimeQuery, originalTimeQueryResult := endTimeQuery, timeQueryResult
+	defer func() {
+		genQuery, beginTimeQuery = originalGenQuery, originalBeginTimeQuery
+		endTimeQuery, timeQueryResult = originalEndTimeQuery, originalTimeQueryResult
+	}()
+	nextQuery := uint32(0)
+	genQuery = func() uint32 {
+		nextQuery++
+		return nextQuery
+	}
+	var started []uint32
+	beginTimeQuery = func(query uint32) {
+		started = append(started, query)
+	}
+	endTimeQuery = func() {}
+	timeQueryResult = func(query uint32) (uint64, bool) {
+		return uint64(query) * 1000, true
+	}
+	var timer gpuTimer
+	for i := 0; i < 3; i++ {
+		timer.begin()
+		timer.end()
+	}
+	if len(started) != 3 || started[0] != 1 || started[1] != 2 || started[2] != 1 {
+		t.Error("The queries should be used alternately")
+	}
+	if timer.last != time.Microsecond {
+		t.Errorf("Invalid gpu time '%s'.", timer.last)
+	}
+}
@@ -0,0 +1,120 @@
+package bounds
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// AABB is an axis aligned bounding box.
+type AABB struct {
+	Min mgl32.Vec3
+	Max mgl32.Vec3
+}
+
+// NewAABB returns the bounding box of the points. Without points it returns
+// an empty box in the origo.
+func NewAABB(points ...mgl32.Vec3) AABB {
+	if len(points) == 0 {
+		return AABB{}
+	}
+	box := AABB{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		box = box.extend(p)
+	}
+	return box
+}
+
+// extend returns the box that also contains the point.
+func (b AABB) extend(p mgl32.Vec3) AABB {
+	for i := 0; i < 3; i++ {
+		if p[i] < b.Min[i] {
+			b.Min[i] = p[i]
+		}
+		if p[i] > b.Max[i] {
+			b.Max[i] = p[i]
+		}
+	}
+	return b
+}
+
+// Center returns the center point of the box.
+func (b AABB) Center() mgl32.Vec3 {
+	return b.Min.Add(b.Max).Mul(0.5)
+}
+
+// Extents returns the half size of the box.
+func (b AABB) Extents() mgl32.Vec3 {
+	return b.Max.Sub(b.Min).Mul(0.5)
+}
+
+// Corners returns the 8 corner points of the box.
+func (b AABB) Corners() [8]mgl32.Vec3 {
+	var corners [8]mgl32.Vec3
+	for i := 0; i < 8; i++ {
+		for axis := 0; axis < 3; axis++ {
+			if i&(1<<uint(axis)) == 0 {
+				corners[i][axis] = b.Min[axis]
+			} else {
+				corners[i][axis] = b.Max[axis]
+			}
+		}
+	}
+	return corners
+}
+
+// Union returns the box that contains both boxes.
+func (b AABB) Union(o AABB) AABB {
+	return b.extend(o.Min).extend(o.Max)
+}
+
+// Transform returns the bounding box of the transformed corners.
+func (b AABB) Transform(m mgl32.Mat4) AABB {
+	corners := b.Corners()
+	for i := range corners {
+		corners[i] = mgl32.TransformCoordinate(corners[i], m)
+	}
+	return NewAABB(corners[:]...)
+}
+
+// BoundingSphere returns the sphere that contains the box.
+func (b AABB) BoundingSphere() Sphere {
+	return Sphere{Center: b.Center(), Radius: b.Extents().Len()}
+}
+
+// Sphere is a bounding sphere.
+type Sphere struct {
+	Center mgl32.Vec3
+	Radius float32
+}
+
+// BoundingBox returns the box that contains the sphere.
+func (s Sphere) BoundingBox() AABB {
+	r := mgl32.Vec3{s.Radius, s.Radius, s.Radius}
+	return AABB{Min: s.Center.Sub(r), Max: s.Center.Add(r)}
+}
+
+// Transform returns the transformed sphere. The radius is scaled with the
+// largest scale of the transformation.
+func (s Sphere) Transform(m mgl32.Mat4) Sphere {
+	scale := float32(0)
+	for i := 0; i < 3; i++ {
+		if l := m.Col(i).Vec3().Len(); l > scale {
+			scale = l
+		}
+	}
+	return Sphere{Center: mgl32.TransformCoordinate(s.Center, m), Radius: s.Radius * scale}
+}
+
+// Union returns the bounding sphere of the spheres.
+func (s Sphere) Union(o Sphere) Sphere {
+	d := o.Center.Sub(s.Center)
+	distance := d.Len()
+	if distance+o.Radius <= s.Radius {
+		return s
+	}
+	if distance+s.Radius <= o.Radius {
+		return o
+	}
+	radius := (distance + s.Radius + o.Radius) / 2
+	center := s.Center.Add(d.Mul((radius - s.Radius) / distance))
+	return Sphere{Center: center, Radius: radius}
+}
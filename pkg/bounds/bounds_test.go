@@ -0,0 +1,119 @@
+package bounds
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestNewAABB(t *testing.T) {
+	box := NewAABB(mgl32.Vec3{1, -2, 3}, mgl32.Vec3{-1, 2, 0}, mgl32.Vec3{0, 0, 5})
+	if box.Min != (mgl32.Vec3{-1, -2, 0}) || box.Max != (mgl32.Vec3{1, 2, 5}) {
+		t.Errorf("Invalid box '%v'.", box)
+	}
+	if box.Center() != (mgl32.Vec3{0, 0, 2.5}) || box.Extents() != (mgl32.Vec3{1, 2, 2.5}) {
+		t.Error("Invalid center or extents")
+	}
+	if (NewAABB() != AABB{}) {
+		t.Error("Empty box should be in the origo")
+	}
+}
+func TestAABBTransform(t *testing.T) {
+	box := NewAABB(mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, 1, 1})
+	moved := box.Transform(mgl32.Translate3D(1, 2, 3).Mul4(mgl32.Scale3D(2, 2, 2)))
+	if moved.Min != (mgl32.Vec3{-1, 0, 1}) || moved.Max != (mgl32.Vec3{3, 4, 5}) {
+		t.Errorf("Invalid transformed box '%v'.", moved)
+	}
+	union := box.Union(AABB{Min: mgl32.Vec3{0, 0, 0}, Max: mgl32.Vec3{5, 0.5, 0}})
+	if union.Min != box.Min || union.Max != (mgl32.Vec3{5, 1, 1}) {
+		t.Errorf("Invalid union '%v'.", union)
+	}
+	corners := box.Corners()
+	if corners[0] != box.Min || corners[7] != box.Max {
+		t.Error("Invalid corners")
+	}
+}
+func TestSphere(t *testing.T) {
+	s := Sphere{Center: mgl32.Vec3{1, 0, 0}, Radius: 2}
+	box := s.BoundingBox()
+	if box.Min != (mgl32.Vec3{-1, -2, -2}) || box.Max != (mgl32.Vec3{3, 2, 2}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+	moved := s.Transform(mgl32.Translate3D(0, 1, 0).Mul4(mgl32.Scale3D(3, 3, 3)))
+	if moved.Center != (mgl32.Vec3{3, 1, 0}) || moved.Radius != 6 {
+		t.Errorf("Invalid transformed sphere '%v'.", moved)
+	}
+	union := s.Union(Sphere{Center: mgl32.Vec3{7, 0, 0}, Radius: 2})
+	if union.Center != (mgl32.Vec3{4, 0, 0}) || union.Radius != 5 {
+		t.Errorf("Invalid union '%v'.", union)
+	}
+	if s.Union(Sphere{Center: mgl32.Vec3{1, 0, 0}, Radius: 1}) != s {
+		t.Error("The union with an inner sphere should be the outer sphere")
+	}
+	inner := NewAABB(mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, 1, 1}).BoundingSphere()
+	if inner.Center != (mgl32.Vec3{0, 0, 0}) || !mgl32.FloatEqual(inner.Radius, mgl32.Vec3{1, 1, 1}.Len()) {
+		t.Errorf("Invalid bounding sphere '%v'.", inner)
+	}
+}
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+func testFrustum() Frustum {
+	// the camera is in the origo, it looks to the -z direction.
+	projection := mgl32.Perspective(mgl32.DegToRad(90), 1, 1, 100)
+	view := mgl32.LookAtV(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 0, -1}, mgl32.Vec3{0, 1, 0})
+	return NewFrustum(projection.Mul4(view))
+}
+func TestFrustumPlanes(t *testing.T) {
+	f := testFrustum()
+	near := f[PLANE_NEAR]
+	if abs(near.SignedDistance(mgl32.Vec3{0, 0, -1})) > 1e-4 {
+		t.Errorf("The near plane should be at 1, '%v'.", near)
+	}
+	far := f[PLANE_FAR]
+	if abs(far.SignedDistance(mgl32.Vec3{0, 0, -100})) > 1e-3 {
+		t.Errorf("The far plane should be at 100, '%v'.", far)
+	}
+	for i, plane := range f {
+		if !mgl32.FloatEqualThreshold(plane.Normal.Len(), 1, 1e-5) {
+			t.Errorf("The normal vector of the plane '%d' should be normalized.", i)
+		}
+	}
+}
+func TestFrustumIntersects(t *testing.T) {
+	f := testFrustum()
+	testData := []struct {
+		point  mgl32.Vec3
+		inside bool
+	}{
+		{mgl32.Vec3{0, 0, -10}, true},
+		{mgl32.Vec3{9, -9, -10}, true},
+		{mgl32.Vec3{0, 0, 10}, false},
+		{mgl32.Vec3{0, 0, -0.5}, false},
+		{mgl32.Vec3{0, 0, -200}, false},
+		{mgl32.Vec3{11, 0, -10}, false},
+		{mgl32.Vec3{0, 11, -10}, false},
+	}
+	for _, tt := range testData {
+		if f.ContainsPoint(tt.point) != tt.inside {
+			t.Errorf("Invalid containment of '%v'.", tt.point)
+		}
+		if f.IntersectsSphere(Sphere{Center: tt.point, Radius: 0.1}) != tt.inside {
+			t.Errorf("Invalid sphere intersection of '%v'.", tt.point)
+		}
+		box := Sphere{Center: tt.point, Radius: 0.1}.BoundingBox()
+		if f.IntersectsAABB(box) != tt.inside {
+			t.Errorf("Invalid box intersection of '%v'.", tt.point)
+		}
+	}
+	// the partially visible volumes intersect the frustum.
+	if !f.IntersectsSphere(Sphere{Center: mgl32.Vec3{0, 0, 2}, Radius: 5}) {
+		t.Error("The sphere around the camera should intersect the frustum")
+	}
+	if !f.IntersectsAABB(AABB{Min: mgl32.Vec3{-1, -1, -20}, Max: mgl32.Vec3{1, 1, 20}}) {
+		t.Error("The box through the frustum should intersect it")
+	}
+}
@@ -0,0 +1,96 @@
+package bounds
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// The indices of the frustum planes.
+const (
+	PLANE_LEFT   = 0
+	PLANE_RIGHT  = 1
+	PLANE_BOTTOM = 2
+	PLANE_TOP    = 3
+	PLANE_NEAR   = 4
+	PLANE_FAR    = 5
+)
+
+// Plane is described with its unit normal vector and its distance from the
+// origo. The normal vector points to the inner side.
+type Plane struct {
+	Normal   mgl32.Vec3
+	Distance float32
+}
+
+// SignedDistance returns the distance of the point from the plane. It's
+// negative if the point is on the outer side.
+func (p Plane) SignedDistance(point mgl32.Vec3) float32 {
+	return p.Normal.Dot(point) + p.Distance
+}
+
+// Frustum is the visible volume of a camera. It's bounded by 6 planes.
+type Frustum [6]Plane
+
+// NewFrustum extracts the planes from the projection * view matrix (Gribb -
+// Hartmann method). The planes are in world coordinates.
+func NewFrustum(viewProjection mgl32.Mat4) Frustum {
+	r0, r1, r2, r3 := viewProjection.Row(0), viewProjection.Row(1), viewProjection.Row(2), viewProjection.Row(3)
+	var f Frustum
+	f[PLANE_LEFT] = newPlane(r3.Add(r0))
+	f[PLANE_RIGHT] = newPlane(r3.Sub(r0))
+	f[PLANE_BOTTOM] = newPlane(r3.Add(r1))
+	f[PLANE_TOP] = newPlane(r3.Sub(r1))
+	f[PLANE_NEAR] = newPlane(r3.Add(r2))
+	f[PLANE_FAR] = newPlane(r3.Sub(r2))
+	return f
+}
+
+// newPlane returns the plane of the ax + by + cz + d = 0 equation with normalized normal vector.
+func newPlane(v mgl32.Vec4) Plane {
+	normal := v.Vec3()
+	length := normal.Len()
+	if length == 0 {
+		return Plane{Normal: normal, Distance: v.W()}
+	}
+	return Plane{Normal: normal.Mul(1 / length), Distance: v.W() / length}
+}
+
+// ContainsPoint returns true if the point is inside the frustum.
+func (f Frustum) ContainsPoint(point mgl32.Vec3) bool {
+	for _, plane := range f {
+		if plane.SignedDistance(point) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsSphere returns false if the sphere is fully outside of the frustum.
+func (f Frustum) IntersectsSphere(s Sphere) bool {
+	for _, plane := range f {
+		if plane.SignedDistance(s.Center) < -s.Radius {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsAABB returns false if the box is fully outside of the frustum.
+// It checks the corner that is the farthest along the normal vector of the
+// plane, so that it could return true for some boxes near the corners of the
+// frustum that are outside.
+func (f Frustum) IntersectsAABB(b AABB) bool {
+	for _, plane := range f {
+		var farthest mgl32.Vec3
+		for i := 0; i < 3; i++ {
+			if plane.Normal[i] >= 0 {
+				farthest[i] = b.Max[i]
+			} else {
+				farthest[i] = b.Min[i]
+			}
+		}
+		if plane.SignedDistance(farthest) < 0 {
+			return false
+		}
+	}
+	return true
+}
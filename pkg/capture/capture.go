@@ -0,0 +1,171 @@
+package capture
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FrameWriter is the common interface of the frame exporters.
+type FrameWriter interface {
+	AddFrame(image.Image) error
+	Close() error
+}
+
+// New returns a GIFWriter if the path has '.gif' extension, otherwise
+// a PNGWriter that writes the frames to the path directory.
+func New(path string, fps int) (FrameWriter, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".gif" {
+		return NewGIFWriter(path, fps), nil
+	}
+	return NewPNGWriter(path)
+}
+
+// PNGWriter writes the frames to a directory as 'frame-0000.png', 'frame-0001.png', ...
+type PNGWriter struct {
+	dir   string
+	count int
+}
+
+// NewPNGWriter returns a PNGWriter. It creates the directory if it's missing.
+func NewPNGWriter(dir string) (*PNGWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &PNGWriter{dir: dir}, nil
+}
+
+// AddFrame writes the image to the next file.
+func (p *PNGWriter) AddFrame(img image.Image) error {
+	f, err := os.Create(filepath.Join(p.dir, fmt.Sprintf("frame-%04d.png", p.count)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p.count++
+	return png.Encode(f, img)
+}
+
+// Count returns the number of the written frames.
+func (p *PNGWriter) Count() int {
+	return p.count
+}
+
+// Close does nothing, the frames are written in the AddFrame function.
+func (p *PNGWriter) Close() error {
+	return nil
+}
+
+// GIFWriter collects the frames and writes them as an animated gif on Close.
+type GIFWriter struct {
+	path string
+	fps  int
+	// elapsed is the sum of the delays of the frames in 100ths of a second.
+	elapsed int
+	anim    gif.GIF
+}
+
+// NewGIFWriter returns a GIFWriter. The delay between the frames is
+// calculated from the fps (in 100ths of a second, as the gif stores it).
+func NewGIFWriter(path string, fps int) *GIFWriter {
+	if fps < 1 {
+		fps = 1
+	}
+	return &GIFWriter{path: path, fps: fps}
+}
+
+// nextDelay returns the delay of the next frame. The delays are rounded
+// down, the remainder is carried to the next frames, so that the animation
+// doesn't drift if the fps doesn't divide 100 (eg: 3, 3, 4 with 30 fps).
+// The delay is at least 1.
+func (g *GIFWriter) nextDelay() int {
+	delay := (len(g.anim.Image)+1)*100/g.fps - g.elapsed
+	if delay < 1 {
+		delay = 1
+	}
+	g.elapsed += delay
+	return delay
+}
+
+// AddFrame quantizes the image to a 256 colors palette and appends it to the animation.
+func (g *GIFWriter) AddFrame(img image.Image) error {
+	paletted := image.NewPaletted(img.Bounds(), Quantize(img, 256))
+	draw.FloydSteinberg.Draw(paletted, img.Bounds(), img, img.Bounds().Min)
+	g.anim.Delay = append(g.anim.Delay, g.nextDelay())
+	g.anim.Image = append(g.anim.Image, paletted)
+	return nil
+}
+
+// Count returns the number of the collected frames.
+func (g *GIFWriter) Count() int {
+	return len(g.anim.Image)
+}
+
+// Close writes the animation to the file.
+func (g *GIFWriter) Close() error {
+	f, err := os.Create(g.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return gif.EncodeAll(f, &g.anim)
+}
+
+type colorBucket struct {
+	count      int
+	r, g, b, a int
+}
+
+// Quantize returns a palette with at most maxColors colors. The colors of
+// the image are grouped to buckets (4 bits per channel), the palette
+// contains the average colors of the most popular buckets.
+func Quantize(img image.Image, maxColors int) color.Palette {
+	buckets := make(map[uint16]*colorBucket)
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			key := uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+			bucket, ok := buckets[key]
+			if !ok {
+				bucket = &colorBucket{}
+				buckets[key] = bucket
+			}
+			bucket.count++
+			bucket.r += int(c.R)
+			bucket.g += int(c.G)
+			bucket.b += int(c.B)
+			bucket.a += int(c.A)
+		}
+	}
+	sorted := make([]*colorBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		sorted = append(sorted, bucket)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].count > sorted[j].count
+	})
+	if len(sorted) > maxColors {
+		sorted = sorted[:maxColors]
+	}
+	palette := make(color.Palette, 0, len(sorted))
+	for _, bucket := range sorted {
+		palette = append(palette, color.NRGBA{
+			R: uint8(bucket.r / bucket.count),
+			G: uint8(bucket.g / bucket.count),
+			B: uint8(bucket.b / bucket.count),
+			A: uint8(bucket.a / bucket.count),
+		})
+	}
+	if len(palette) == 0 {
+		palette = append(palette, color.Black)
+	}
+	return palette
+}
@@ -0,0 +1,88 @@
+package capture
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 60), uint8(y * 60), 0, 255})
+		}
+	}
+	return img
+}
+func TestQuantize(t *testing.T) {
+	palette := Quantize(testImage(), 256)
+	if len(palette) != 16 {
+		t.Errorf("Invalid palette size. Instead of '16', we have '%d'.", len(palette))
+	}
+	palette = Quantize(testImage(), 4)
+	if len(palette) != 4 {
+		t.Errorf("Invalid palette size. Instead of '4', we have '%d'.", len(palette))
+	}
+}
+func TestPNGWriter(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "capture")
+	defer os.RemoveAll(dir)
+	w, err := New(filepath.Join(dir, "frames"), 25)
+	if err != nil {
+		t.Fatalf("New failed: %s", err.Error())
+	}
+	w.AddFrame(testImage())
+	w.AddFrame(testImage())
+	w.Close()
+	if _, err := os.Stat(filepath.Join(dir, "frames", "frame-0001.png")); err != nil {
+		t.Error("Missing frame file")
+	}
+}
+func TestGIFWriter(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "capture")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "anim.gif")
+	w, _ := New(path, 25)
+	w.AddFrame(testImage())
+	w.AddFrame(testImage())
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err.Error())
+	}
+	f, _ := os.Open(path)
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("Invalid gif: %s", err.Error())
+	}
+	if len(anim.Image) != 2 || anim.Delay[0] != 4 {
+		t.Error("Invalid animation")
+	}
+}
+func TestGIFWriterDelay(t *testing.T) {
+	w := NewGIFWriter("anim.gif", 30)
+	for i := 0; i < 30; i++ {
+		w.AddFrame(testImage())
+	}
+	sum := 0
+	for _, delay := range w.anim.Delay {
+		if delay != 3 && delay != 4 {
+			t.Errorf("Invalid delay '%d'.", delay)
+		}
+		sum += delay
+	}
+	// one second of frames is 100ths of a second.
+	if sum != 100 || w.anim.Delay[0] != 3 || w.anim.Delay[2] != 4 {
+		t.Errorf("Invalid delays '%v'.", w.anim.Delay)
+	}
+	// the delay is at least 1 above 100 fps.
+	fast := NewGIFWriter("anim.gif", 200)
+	fast.AddFrame(testImage())
+	if fast.anim.Delay[0] != 1 {
+		t.Errorf("Invalid delay '%d'.", fast.anim.Delay[0])
+	}
+}
@@ -0,0 +1,103 @@
+package framebuffer
+
+import (
+	"fmt"
+	"image"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+)
+
+// Framebuffer is an offscreen render target with a color and a depth renderbuffer.
+type Framebuffer struct {
+	id          uint32
+	colorBuffer uint32
+	depthBuffer uint32
+	width       int
+	height      int
+}
+
+// New returns a framebuffer with the given size. It returns error if the
+// framebuffer is not complete.
+func New(width, height int) (*Framebuffer, error) {
+	f := &Framebuffer{
+		id:          wrapper.GenFramebuffers(),
+		colorBuffer: wrapper.GenRenderbuffers(),
+		depthBuffer: wrapper.GenRenderbuffers(),
+	}
+	if err := f.Resize(width, height); err != nil {
+		f.Delete()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Resize updates the storage of the renderbuffers. The content of the buffers is lost.
+func (f *Framebuffer) Resize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("Invalid framebuffer size '%dx%d'.", width, height)
+	}
+	f.width = width
+	f.height = height
+	wrapper.BindFramebuffer(wrapper.FRAMEBUFFER, f.id)
+	wrapper.BindRenderbuffer(wrapper.RENDERBUFFER, f.colorBuffer)
+	wrapper.RenderbufferStorage(wrapper.RENDERBUFFER, wrapper.RGBA8, int32(width), int32(height))
+	wrapper.FramebufferRenderbuffer(wrapper.FRAMEBUFFER, wrapper.COLOR_ATTACHMENT0, wrapper.RENDERBUFFER, f.colorBuffer)
+	wrapper.BindRenderbuffer(wrapper.RENDERBUFFER, f.depthBuffer)
+	wrapper.RenderbufferStorage(wrapper.RENDERBUFFER, wrapper.DEPTH_COMPONENT24, int32(width), int32(height))
+	wrapper.FramebufferRenderbuffer(wrapper.FRAMEBUFFER, wrapper.DEPTH_ATTACHMENT, wrapper.RENDERBUFFER, f.depthBuffer)
+	wrapper.BindRenderbuffer(wrapper.RENDERBUFFER, 0)
+	status := wrapper.CheckFramebufferStatus(wrapper.FRAMEBUFFER)
+	wrapper.BindFramebuffer(wrapper.FRAMEBUFFER, 0)
+	if status != wrapper.FRAMEBUFFER_COMPLETE {
+		return fmt.Errorf("Framebuffer is not complete. Status: '%d'.", status)
+	}
+	return nil
+}
+
+// Size returns the width and the height of the framebuffer.
+func (f *Framebuffer) Size() (int, int) {
+	return f.width, f.height
+}
+
+// Bind makes the framebuffer the current render target and sets the viewport to its size.
+func (f *Framebuffer) Bind() {
+	wrapper.BindFramebuffer(wrapper.FRAMEBUFFER, f.id)
+	wrapper.Viewport(0, 0, int32(f.width), int32(f.height))
+}
+
+// UnBind makes the default framebuffer the current render target.
+// The viewport has to be restored by the caller.
+func (f *Framebuffer) UnBind() {
+	wrapper.BindFramebuffer(wrapper.FRAMEBUFFER, 0)
+}
+
+// ReadPixels returns the content of the color buffer. The gl rows are
+// starting from the bottom, so that the image is flipped vertically.
+func (f *Framebuffer) ReadPixels() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, f.width, f.height))
+	wrapper.BindFramebuffer(wrapper.FRAMEBUFFER, f.id)
+	wrapper.ReadPixels(0, 0, int32(f.width), int32(f.height), wrapper.RGBA, wrapper.UNSIGNED_BYTE, wrapper.Ptr(img.Pix))
+	FlipVertical(img)
+	return img
+}
+
+// Delete releases the gl objects of the framebuffer.
+func (f *Framebuffer) Delete() {
+	wrapper.DeleteRenderbuffers(f.colorBuffer)
+	wrapper.DeleteRenderbuffers(f.depthBuffer)
+	wrapper.DeleteFramebuffers(f.id)
+}
+
+// FlipVertical swaps the rows of the image in place.
+func FlipVertical(img *image.RGBA) {
+	height := img.Rect.Dy()
+	rowLength := img.Rect.Dx() * 4
+	tmp := make([]byte, rowLength)
+	for y := 0; y < height/2; y++ {
+		top := img.Pix[y*img.Stride : y*img.Stride+rowLength]
+		bottom := img.Pix[(height-1-y)*img.Stride : (height-1-y)*img.Stride+rowLength]
+		copy(tmp, top)
+		copy(top, bottom)
+		copy(bottom, tmp)
+	}
+}
@@ -0,0 +1,25 @@
+package framebuffer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	t.Skip("Unimplemented")
+}
+func TestFlipVertical(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	top := color.RGBA{255, 0, 0, 255}
+	bottom := color.RGBA{0, 0, 255, 255}
+	img.Set(1, 0, top)
+	img.Set(1, 2, bottom)
+	FlipVertical(img)
+	if img.RGBAAt(1, 0) != bottom {
+		t.Error("Invalid top row after flip")
+	}
+	if img.RGBAAt(1, 2) != top {
+		t.Error("Invalid bottom row after flip")
+	}
+}
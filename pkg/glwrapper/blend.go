@@ -0,0 +1,34 @@
+package glwrapper
+
+// The blend modes. The BLEND_MODE_NONE disables the blending.
+const (
+	BLEND_MODE_NONE          = 0
+	BLEND_MODE_ALPHA         = 1
+	BLEND_MODE_ADDITIVE      = 2
+	BLEND_MODE_PREMULTIPLIED = 3
+)
+
+// blendFactors maps the blend modes to the source and destination factors.
+var blendFactors = map[int][2]uint32{
+	BLEND_MODE_ALPHA:         {SRC_ALPHA, ONE_MINUS_SRC_ALPHA},
+	BLEND_MODE_ADDITIVE:      {SRC_ALPHA, ONE},
+	BLEND_MODE_PREMULTIPLIED: {ONE, ONE_MINUS_SRC_ALPHA},
+}
+
+// SetBlendMode enables the blending with the factors of the mode, or disables
+// it in case of BLEND_MODE_NONE. The invalid modes are ignored.
+// - BLEND_MODE_ALPHA - the color is mixed with the background based on its alpha.
+// - BLEND_MODE_ADDITIVE - the color is added to the background (eg. glow, particles).
+// - BLEND_MODE_PREMULTIPLIED - the color is already multiplied with its alpha.
+func SetBlendMode(mode int) {
+	if mode == BLEND_MODE_NONE {
+		Disable(BLEND)
+		return
+	}
+	factors, ok := blendFactors[mode]
+	if !ok {
+		return
+	}
+	Enable(BLEND)
+	BlendFunc(factors[0], factors[1])
+}
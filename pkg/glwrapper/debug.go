@@ -0,0 +1,189 @@
+package glwrapper
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// The severities of the debug messages in increasing order. The errors of
+// the glGetError are reported with SEVERITY_HIGH.
+const (
+	SEVERITY_NOTIFICATION = 0
+	SEVERITY_LOW          = 1
+	SEVERITY_MEDIUM       = 2
+	SEVERITY_HIGH         = 3
+)
+
+// DebugMessage is a gl error or a message of the debug output.
+type DebugMessage struct {
+	Severity int
+	Message  string
+	// Caller is the go call site (file:line) of the gl call.
+	Caller string
+}
+
+// String returns the message as 'file.go:12: [HIGH] message'.
+func (m DebugMessage) String() string {
+	return fmt.Sprintf("%s: [%s] %s", m.Caller, severityNames[m.Severity], m.Message)
+}
+
+// DebugOptions is the setup of the debug mode.
+type DebugOptions struct {
+	// MinSeverity filters the messages, the lower severities are ignored.
+	MinSeverity int
+	// Panic panics with the message instead of calling the handler. It could
+	// be used in tests.
+	Panic bool
+	// Handler gets the messages. It prints them to the stderr if it's nil.
+	Handler func(DebugMessage)
+}
+
+var (
+	severityNames = map[int]string{
+		SEVERITY_NOTIFICATION: "NOTIFICATION",
+		SEVERITY_LOW:          "LOW",
+		SEVERITY_MEDIUM:       "MEDIUM",
+		SEVERITY_HIGH:         "HIGH",
+	}
+	errorNames = map[uint32]string{
+		gl.INVALID_ENUM:                  "INVALID_ENUM",
+		gl.INVALID_VALUE:                 "INVALID_VALUE",
+		gl.INVALID_OPERATION:             "INVALID_OPERATION",
+		gl.INVALID_FRAMEBUFFER_OPERATION: "INVALID_FRAMEBUFFER_OPERATION",
+		gl.OUT_OF_MEMORY:                 "OUT_OF_MEMORY",
+		gl.STACK_OVERFLOW:                "STACK_OVERFLOW",
+		gl.STACK_UNDERFLOW:               "STACK_UNDERFLOW",
+	}
+
+	// the state of the debug mode. If the debug output is used, the errors
+	// aren't checked after the calls.
+	debugEnabled bool
+	debugOutput  bool
+	debugOptions DebugOptions
+
+	// getError is a variable, so that the error checking could be tested without gl context.
+	getError = gl.GetError
+)
+
+// EnableDebug turns on the debug mode. It registers the debug output callback
+// if it's available (gl 4.3 or KHR_debug extension, the debug context is
+// recommended), otherwise the glGetError is called after every wrapper call.
+// It returns true if the debug output is used. It has to be called after the
+// InitOpenGL.
+func EnableDebug(options DebugOptions) bool {
+	debugEnabled = true
+	debugOptions = options
+	debugOutput = hasDebugOutput()
+	if debugOutput {
+		Enable(gl.DEBUG_OUTPUT)
+		// the callback is called from the gl function, so that the call site is on the stack.
+		Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+		gl.DebugMessageCallback(debugCallback, nil)
+	}
+	return debugOutput
+}
+
+// DisableDebug turns off the debug mode.
+func DisableDebug() {
+	if debugOutput {
+		Disable(gl.DEBUG_OUTPUT)
+	}
+	debugEnabled = false
+	debugOutput = false
+}
+
+// IsDebugEnabled returns true if the debug mode is on.
+func IsDebugEnabled() bool {
+	return debugEnabled
+}
+
+// hasDebugOutput returns true if the gl version is at least 4.3 or the KHR_debug extension is supported.
+func hasDebugOutput() bool {
+	var major, minor, extensions int32
+	gl.GetIntegerv(gl.MAJOR_VERSION, &major)
+	gl.GetIntegerv(gl.MINOR_VERSION, &minor)
+	if major > 4 || major == 4 && minor >= 3 {
+		return true
+	}
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &extensions)
+	for i := uint32(0); i < uint32(extensions); i++ {
+		if gl.GoStr(gl.GetStringi(gl.EXTENSIONS, i)) == "GL_KHR_debug" {
+			return true
+		}
+	}
+	return false
+}
+
+// debugCallback converts the message of the debug output.
+func debugCallback(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	s := SEVERITY_NOTIFICATION
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		s = SEVERITY_HIGH
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		s = SEVERITY_MEDIUM
+	case gl.DEBUG_SEVERITY_LOW:
+		s = SEVERITY_LOW
+	}
+	report(DebugMessage{Severity: s, Message: message, Caller: callSite()})
+}
+
+// checkError reads the gl errors after a wrapper call. It does nothing if the
+// debug mode is off or the debug output is used.
+func checkError() {
+	if !debugEnabled || debugOutput {
+		return
+	}
+	for err := getError(); err != gl.NO_ERROR; err = getError() {
+		name, ok := errorNames[err]
+		if !ok {
+			name = fmt.Sprintf("0x%x", err)
+		}
+		report(DebugMessage{Severity: SEVERITY_HIGH, Message: name, Caller: callSite()})
+	}
+}
+
+// report passes the message to the handler, or panics with it.
+func report(m DebugMessage) {
+	if m.Severity < debugOptions.MinSeverity {
+		return
+	}
+	if debugOptions.Panic {
+		panic(m.String())
+	}
+	if debugOptions.Handler != nil {
+		debugOptions.Handler(m)
+		return
+	}
+	fmt.Fprintln(os.Stderr, m.String())
+}
+
+// callSite returns the file:line of the first caller outside of the wrapper,
+// the gl and the runtime packages.
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isInternal(frame) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// isInternal returns true if the frame belongs to the wrapper, the gl or the runtime package.
+func isInternal(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "glwrapper/wrapper.go") || strings.HasSuffix(frame.File, "glwrapper/debug.go") {
+		return true
+	}
+	return strings.HasPrefix(frame.Function, "github.com/go-gl/gl/") || strings.HasPrefix(frame.Function, "runtime.")
+}
@@ -0,0 +1,62 @@
+package glwrapper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// fakeErrors returns a getError function that returns the errors, then NO_ERROR.
+func fakeErrors(errors ...uint32) func() uint32 {
+	return func() uint32 {
+		if len(errors) == 0 {
+			return gl.NO_ERROR
+		}
+		err := errors[0]
+		errors = errors[1:]
+		return err
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	defer DisableDebug()
+	var messages []DebugMessage
+	debugEnabled = true
+	debugOptions = DebugOptions{Handler: func(m DebugMessage) {
+		messages = append(messages, m)
+	}}
+	getError = fakeErrors(gl.INVALID_ENUM, gl.INVALID_OPERATION)
+	checkError()
+	if len(messages) != 2 || messages[0].Message != "INVALID_ENUM" || messages[1].Message != "INVALID_OPERATION" {
+		t.Fatalf("Invalid messages '%v'.", messages)
+	}
+	if !strings.Contains(messages[0].Caller, "glwrapper/debug_test.go:") {
+		t.Errorf("Invalid call site '%s'.", messages[0].Caller)
+	}
+	DisableDebug()
+	getError = fakeErrors(gl.INVALID_VALUE)
+	checkError()
+	if len(messages) != 2 {
+		t.Error("The errors shouldn't be checked without debug mode")
+	}
+}
+func TestReport(t *testing.T) {
+	defer DisableDebug()
+	var messages []DebugMessage
+	debugOptions = DebugOptions{MinSeverity: SEVERITY_MEDIUM, Handler: func(m DebugMessage) {
+		messages = append(messages, m)
+	}}
+	report(DebugMessage{Severity: SEVERITY_LOW, Message: "low"})
+	report(DebugMessage{Severity: SEVERITY_HIGH, Message: "high"})
+	if len(messages) != 1 || messages[0].Message != "high" {
+		t.Errorf("Invalid filtered messages '%v'.", messages)
+	}
+	debugOptions.Panic = true
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "[HIGH] high") {
+			t.Errorf("Invalid panic '%v'.", r)
+		}
+	}()
+	report(DebugMessage{Severity: SEVERITY_HIGH, Message: "high"})
+}
@@ -0,0 +1,210 @@
+package glwrapper
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// StateCounters are the numbers of the skipped redundant gl calls since the
+// last ResetStateCounters call.
+type StateCounters struct {
+	Program       int
+	VertexArray   int
+	Buffer        int
+	ActiveTexture int
+	Texture       int
+	Capability    int
+	DepthFunc     int
+	DepthMask     int
+	BlendFunc     int
+	Viewport      int
+}
+
+// Total returns the number of all the skipped calls.
+func (c StateCounters) Total() int {
+	return c.Program + c.VertexArray + c.Buffer + c.ActiveTexture + c.Texture + c.Capability + c.DepthFunc + c.DepthMask + c.BlendFunc + c.Viewport
+}
+
+// The kinds of the cached states.
+const (
+	stateProgram = iota
+	stateVertexArray
+	stateBuffer
+	stateActiveTexture
+	stateTexture
+	stateCapability
+	stateDepthFunc
+	stateDepthMask
+	stateBlendFunc
+)
+
+// stateKey identifies a cached state. The target is the buffer or texture
+// target or the capability, the unit is the texture unit of the texture binding.
+type stateKey struct {
+	kind   int
+	target uint32
+	unit   uint32
+}
+
+// stateCache shadows the current gl state. The missing keys are unknown, so
+// that their next call isn't skipped.
+type stateCache struct {
+	disabled      bool
+	values        map[stateKey]uint32
+	viewport      [4]int32
+	viewportValid bool
+	counters      StateCounters
+}
+
+var cache = newStateCache()
+
+func newStateCache() *stateCache {
+	return &stateCache{values: make(map[stateKey]uint32)}
+}
+
+// SetStateCache enables or disables the state cache. It's enabled by default.
+// The disabled cache forgets the state, every call is passed to gl.
+func SetStateCache(enabled bool) {
+	cache.disabled = !enabled
+	cache.invalidate()
+}
+
+// InvalidateState forgets the cached state. It has to be called after the gl
+// calls that bypass the wrapper, or after the change of the current context.
+func InvalidateState() {
+	cache.invalidate()
+}
+
+// GetStateCounters returns the numbers of the skipped calls since the last ResetStateCounters call.
+func GetStateCounters() StateCounters {
+	return cache.counters
+}
+
+// ResetStateCounters sets the skipped call counters to 0.
+func ResetStateCounters() {
+	cache.counters = StateCounters{}
+}
+
+func (c *stateCache) invalidate() {
+	c.values = make(map[stateKey]uint32)
+	c.viewportValid = false
+}
+
+// skip returns true if the state already has the value, otherwise it stores the value.
+func (c *stateCache) skip(key stateKey, value uint32) bool {
+	if c.disabled {
+		return false
+	}
+	if current, ok := c.values[key]; ok && current == value {
+		c.count(key.kind)
+		return true
+	}
+	c.values[key] = value
+	return false
+}
+
+func (c *stateCache) count(kind int) {
+	switch kind {
+	case stateProgram:
+		c.counters.Program++
+	case stateVertexArray:
+		c.counters.VertexArray++
+	case stateBuffer:
+		c.counters.Buffer++
+	case stateActiveTexture:
+		c.counters.ActiveTexture++
+	case stateTexture:
+		c.counters.Texture++
+	case stateCapability:
+		c.counters.Capability++
+	case stateDepthFunc:
+		c.counters.DepthFunc++
+	case stateDepthMask:
+		c.counters.DepthMask++
+	case stateBlendFunc:
+		c.counters.BlendFunc++
+	}
+}
+
+// deleted updates the cached bindings of the deleted object. The gl unbinds
+// the deleted names, so that their bindings become 0. Otherwise a new object
+// that gets the same name could be skipped. The 0 name isn't deleted.
+func (c *stateCache) deleted(kind int, name uint32) {
+	if name == 0 {
+		return
+	}
+	for key, value := range c.values {
+		if key.kind == kind && value == name {
+			c.values[key] = 0
+		}
+	}
+}
+
+func (c *stateCache) useProgram(program uint32) bool {
+	return c.skip(stateKey{kind: stateProgram}, program)
+}
+
+// bindVertexArray also forgets the element array buffer binding, because it
+// belongs to the vertex array.
+func (c *stateCache) bindVertexArray(vao uint32) bool {
+	if c.skip(stateKey{kind: stateVertexArray}, vao) {
+		return true
+	}
+	delete(c.values, stateKey{kind: stateBuffer, target: gl.ELEMENT_ARRAY_BUFFER})
+	return false
+}
+
+func (c *stateCache) bindBuffer(target, buffer uint32) bool {
+	return c.skip(stateKey{kind: stateBuffer, target: target}, buffer)
+}
+
+func (c *stateCache) activeTexture(unit uint32) bool {
+	return c.skip(stateKey{kind: stateActiveTexture}, unit)
+}
+
+// bindTexture caches the binding of the active texture unit. If the active
+// unit is unknown, the binding isn't cached.
+func (c *stateCache) bindTexture(target, texture uint32) bool {
+	unit, ok := c.values[stateKey{kind: stateActiveTexture}]
+	if !ok {
+		return false
+	}
+	return c.skip(stateKey{kind: stateTexture, target: target, unit: unit}, texture)
+}
+
+func (c *stateCache) setCapability(capability uint32, enabled bool) bool {
+	value := uint32(0)
+	if enabled {
+		value = 1
+	}
+	return c.skip(stateKey{kind: stateCapability, target: capability}, value)
+}
+
+func (c *stateCache) depthFunc(function uint32) bool {
+	return c.skip(stateKey{kind: stateDepthFunc}, function)
+}
+
+func (c *stateCache) depthMask(flag bool) bool {
+	value := uint32(0)
+	if flag {
+		value = 1
+	}
+	return c.skip(stateKey{kind: stateDepthMask}, value)
+}
+
+// blendFunc stores the factors in one value, the blend factor enums are less than 1<<16.
+func (c *stateCache) blendFunc(sfactor, dfactor uint32) bool {
+	return c.skip(stateKey{kind: stateBlendFunc}, sfactor<<16|dfactor)
+}
+
+func (c *stateCache) setViewport(viewport [4]int32) bool {
+	if c.disabled {
+		return false
+	}
+	if c.viewportValid && c.viewport == viewport {
+		c.counters.Viewport++
+		return true
+	}
+	c.viewport = viewport
+	c.viewportValid = true
+	return false
+}
@@ -0,0 +1,121 @@
+package glwrapper
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func TestStateCacheSkip(t *testing.T) {
+	c := newStateCache()
+	if c.useProgram(1) {
+		t.Error("The unknown program shouldn't be skipped")
+	}
+	if !c.useProgram(1) {
+		t.Error("The same program should be skipped")
+	}
+	if c.useProgram(2) {
+		t.Error("The new program shouldn't be skipped")
+	}
+	if c.setCapability(gl.DEPTH_TEST, true) || !c.setCapability(gl.DEPTH_TEST, true) || c.setCapability(gl.DEPTH_TEST, false) {
+		t.Error("Invalid capability cache")
+	}
+	if c.depthFunc(gl.LESS) || !c.depthFunc(gl.LESS) {
+		t.Error("Invalid depth func cache")
+	}
+	if c.setViewport([4]int32{0, 0, 800, 600}) || !c.setViewport([4]int32{0, 0, 800, 600}) || c.setViewport([4]int32{0, 0, 400, 300}) {
+		t.Error("Invalid viewport cache")
+	}
+	if c.depthMask(false) || !c.depthMask(false) || c.depthMask(true) {
+		t.Error("Invalid depth mask cache")
+	}
+	if c.blendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA) || !c.blendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA) || c.blendFunc(gl.SRC_ALPHA, gl.ONE) {
+		t.Error("Invalid blend func cache")
+	}
+	counters := c.counters
+	if counters.Program != 1 || counters.Capability != 1 || counters.DepthFunc != 1 || counters.DepthMask != 1 || counters.BlendFunc != 1 || counters.Viewport != 1 || counters.Total() != 6 {
+		t.Errorf("Invalid counters '%v'.", counters)
+	}
+}
+func TestStateCacheBindings(t *testing.T) {
+	c := newStateCache()
+	c.bindVertexArray(1)
+	c.bindBuffer(gl.ELEMENT_ARRAY_BUFFER, 2)
+	c.bindBuffer(gl.ARRAY_BUFFER, 3)
+	if !c.bindBuffer(gl.ELEMENT_ARRAY_BUFFER, 2) {
+		t.Error("The same element buffer should be skipped")
+	}
+	c.bindVertexArray(4)
+	if c.bindBuffer(gl.ELEMENT_ARRAY_BUFFER, 2) {
+		t.Error("The element buffer belongs to the vertex array, it shouldn't be skipped after the vertex array change")
+	}
+	if !c.bindBuffer(gl.ARRAY_BUFFER, 3) {
+		t.Error("The array buffer should be skipped")
+	}
+	if c.bindTexture(gl.TEXTURE_2D, 5) || c.bindTexture(gl.TEXTURE_2D, 5) {
+		t.Error("The texture binding of the unknown unit shouldn't be skipped")
+	}
+	c.activeTexture(gl.TEXTURE0)
+	c.bindTexture(gl.TEXTURE_2D, 5)
+	c.activeTexture(gl.TEXTURE1)
+	if c.bindTexture(gl.TEXTURE_2D, 5) {
+		t.Error("The texture binding of the other unit shouldn't be skipped")
+	}
+	if !c.activeTexture(gl.TEXTURE1) || !c.bindTexture(gl.TEXTURE_2D, 5) {
+		t.Error("The same unit and texture should be skipped")
+	}
+	if c.counters.Buffer != 2 || c.counters.ActiveTexture != 1 || c.counters.Texture != 1 {
+		t.Errorf("Invalid counters '%v'.", c.counters)
+	}
+}
+func TestStateCacheInvalidate(t *testing.T) {
+	defer SetStateCache(true)
+	defer ResetStateCounters()
+	cache.useProgram(1)
+	InvalidateState()
+	if cache.useProgram(1) {
+		t.Error("The program shouldn't be skipped after the invalidation")
+	}
+	SetStateCache(false)
+	if cache.useProgram(1) || cache.useProgram(1) {
+		t.Error("The disabled cache shouldn't skip")
+	}
+	SetStateCache(true)
+	cache.useProgram(1)
+	cache.useProgram(1)
+	if GetStateCounters().Program != 1 {
+		t.Error("Invalid program counter")
+	}
+	ResetStateCounters()
+	if GetStateCounters().Total() != 0 {
+		t.Error("The counters should be reset")
+	}
+}
+func TestStateCacheDeleted(t *testing.T) {
+	c := newStateCache()
+	c.bindVertexArray(1)
+	c.bindBuffer(gl.ARRAY_BUFFER, 2)
+	c.bindBuffer(gl.ELEMENT_ARRAY_BUFFER, 3)
+	c.activeTexture(gl.TEXTURE0)
+	c.bindTexture(gl.TEXTURE_2D, 4)
+	c.activeTexture(gl.TEXTURE1)
+	c.bindTexture(gl.TEXTURE_2D, 4)
+	c.deleted(stateVertexArray, 1)
+	c.deleted(stateBuffer, 2)
+	c.deleted(stateTexture, 4)
+	if !c.bindBuffer(gl.ELEMENT_ARRAY_BUFFER, 3) {
+		t.Error("The other bindings should be kept")
+	}
+	// the new objects could get the deleted names.
+	if c.bindTexture(gl.TEXTURE_2D, 4) || c.bindBuffer(gl.ARRAY_BUFFER, 2) || c.bindVertexArray(1) {
+		t.Error("The deleted names shouldn't be skipped")
+	}
+	c.activeTexture(gl.TEXTURE0)
+	if !c.bindTexture(gl.TEXTURE_2D, 0) {
+		t.Error("The deleted texture should be unbound from every unit")
+	}
+	c.deleted(stateBuffer, 0)
+	if !c.bindBuffer(gl.ARRAY_BUFFER, 2) {
+		t.Error("The 0 name shouldn't be deleted")
+	}
+}
@@ -34,19 +34,55 @@ const (
 	UNSIGNED_BYTE        = gl.UNSIGNED_BYTE
 	FLOAT                = gl.FLOAT
 	POINTS               = gl.POINTS
+	LINES                = gl.LINES
 	TRIANGLES            = gl.TRIANGLES
 	TEXTURE_BORDER_COLOR = gl.TEXTURE_BORDER_COLOR
 	CLAMP_TO_EDGE        = gl.CLAMP_TO_EDGE
+	REPEAT               = gl.REPEAT
 	LINEAR               = gl.LINEAR
+	NEAREST              = gl.NEAREST
 	COLOR_BUFFER_BIT     = gl.COLOR_BUFFER_BIT
 	DEPTH_BUFFER_BIT     = gl.DEPTH_BUFFER_BIT
 	DEPTH_TEST           = gl.DEPTH_TEST
 	LESS                 = gl.LESS
 	PROGRAM_POINT_SIZE   = gl.PROGRAM_POINT_SIZE
+	FRONT_AND_BACK       = gl.FRONT_AND_BACK
+	LINE                 = gl.LINE
+	FILL                 = gl.FILL
+	FRAMEBUFFER          = gl.FRAMEBUFFER
+	RENDERBUFFER         = gl.RENDERBUFFER
+	COLOR_ATTACHMENT0    = gl.COLOR_ATTACHMENT0
+	DEPTH_ATTACHMENT     = gl.DEPTH_ATTACHMENT
+	DEPTH_COMPONENT24    = gl.DEPTH_COMPONENT24
+	RGBA8                = gl.RGBA8
+	FRAMEBUFFER_COMPLETE = gl.FRAMEBUFFER_COMPLETE
+	VIEWPORT             = gl.VIEWPORT
+	FRAMEBUFFER_SRGB     = gl.FRAMEBUFFER_SRGB
+	MULTISAMPLE          = gl.MULTISAMPLE
+	BLEND                = gl.BLEND
+	ZERO                 = gl.ZERO
+	ONE                  = gl.ONE
+	SRC_ALPHA            = gl.SRC_ALPHA
+	ONE_MINUS_SRC_ALPHA  = gl.ONE_MINUS_SRC_ALPHA
+	COLOR_CLEAR_VALUE    = gl.COLOR_CLEAR_VALUE
+)
+
+// The query related constants.
+const (
+	TIME_ELAPSED           = gl.TIME_ELAPSED
+	QUERY_RESULT           = gl.QUERY_RESULT
+	QUERY_RESULT_AVAILABLE = gl.QUERY_RESULT_AVAILABLE
+)
+
+// The number of the draw calls and the drawn triangles since the last ResetDrawCounters call.
+var (
+	drawCalls int
+	triangles int
 )
 
 // Wrapper for gl.GenVertexArrays function.
 func GenVertexArrays() uint32 {
+	defer checkError()
 	var vertexArrayObject uint32
 	gl.GenVertexArrays(1, &vertexArrayObject)
 	return vertexArrayObject
@@ -54,23 +90,49 @@ func GenVertexArrays() uint32 {
 
 // Wrapper for gl.GenBuffers function.
 func GenBuffers() uint32 {
+	defer checkError()
 	var vertexBufferObject uint32
 	gl.GenBuffers(1, &vertexBufferObject)
 	return vertexBufferObject
 }
 
+// Wrapper for gl.DeleteVertexArrays function. The gl unbinds the deleted
+// vertex array, the cached binding is updated.
+func DeleteVertexArrays(vao uint32) {
+	cache.deleted(stateVertexArray, vao)
+	defer checkError()
+	gl.DeleteVertexArrays(1, &vao)
+}
+
+// Wrapper for gl.DeleteBuffers function. The gl unbinds the deleted buffer,
+// the cached bindings are updated.
+func DeleteBuffers(buffer uint32) {
+	cache.deleted(stateBuffer, buffer)
+	defer checkError()
+	gl.DeleteBuffers(1, &buffer)
+}
+
 // Wrapper for gl.BindVertexArray function.
 func BindVertexArray(vao uint32) {
+	if cache.bindVertexArray(vao) {
+		return
+	}
+	defer checkError()
 	gl.BindVertexArray(vao)
 }
 
 // Wrapper for gl.BindBuffer function.
 func BindBuffer(bufferType, vbo uint32) {
+	if cache.bindBuffer(bufferType, vbo) {
+		return
+	}
+	defer checkError()
 	gl.BindBuffer(bufferType, vbo)
 }
 
 // Wrapper for gl.BufferData function but for ARRAY_BUFFER.
 func ArrayBufferData(bufferData []float32) {
+	defer checkError()
 	// a 32-bit float has 4 bytes, so we are saying the size of the buffer,
 	// in bytes, is 4 times the number of points
 	gl.BufferData(gl.ARRAY_BUFFER, 4*len(bufferData), gl.Ptr(bufferData), gl.STATIC_DRAW)
@@ -78,6 +140,7 @@ func ArrayBufferData(bufferData []float32) {
 
 // Wrapper for gl.BufferData function, but for ELEMENT_ARRAY_BUFFER.
 func ElementBufferData(bufferData []uint32) {
+	defer checkError()
 	// a 32-bit uint has 4 bytes, so we are saying the size of the buffer,
 	// in bytes, is 4 times the number of points
 	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(bufferData), gl.Ptr(bufferData), gl.STATIC_DRAW)
@@ -85,64 +148,94 @@ func ElementBufferData(bufferData []uint32) {
 
 // VertexAttribPointer enables and sets the pointer.
 func VertexAttribPointer(index uint32, size int32, xtype uint32, normalized bool, stride int32, pointer unsafe.Pointer) {
+	defer checkError()
 	gl.EnableVertexAttribArray(index)
 	gl.VertexAttribPointer(index, size, xtype, normalized, stride, pointer)
 }
 
 // Wrapper for gl.ActiveTexture function.
 func ActiveTexture(id uint32) {
+	if cache.activeTexture(id) {
+		return
+	}
+	defer checkError()
 	gl.ActiveTexture(id)
 }
 
 // Wrapper for gl.BindTexture function.
 func BindTexture(id, textureId uint32) {
+	if cache.bindTexture(id, textureId) {
+		return
+	}
+	defer checkError()
 	gl.BindTexture(id, textureId)
 }
 
 // Wrapper for gl.DrawElements function in triangle mode.
 func DrawTriangleElements(count int32) {
+	defer checkError()
+	countDraw(gl.TRIANGLES, count)
 	gl.DrawElements(gl.TRIANGLES, count, gl.UNSIGNED_INT, gl.PtrOffset(0))
 }
 
+// Wrapper for gl.DrawElementsInstanced function. It draws the triangles of
+// the bound element array buffer instances times.
+func DrawTriangleElementsInstanced(count int32, instances int32) {
+	defer checkError()
+	countDrawInstanced(gl.TRIANGLES, count, instances)
+	gl.DrawElementsInstanced(gl.TRIANGLES, count, gl.UNSIGNED_INT, gl.PtrOffset(0), instances)
+}
+
 // Wrapper for gl.UseProgram function.
 func UseProgram(id uint32) {
+	if cache.useProgram(id) {
+		return
+	}
+	defer checkError()
 	gl.UseProgram(id)
 }
 
 // Use is a wrapper for gl.GetUniformLocation
 func GetUniformLocation(shaderProgramId uint32, uniformName string) int32 {
+	defer checkError()
 	return gl.GetUniformLocation(shaderProgramId, gl.Str(uniformName+"\x00"))
 }
 
 // Uniform1i gets an uniform name string and 3 float values as input and
 // calls the gl.Uniform1i function
 func Uniform1i(location int32, value int32) {
+	defer checkError()
 	gl.Uniform1i(location, value)
 }
 
 // Wrapper for gl.Use function.
 func CreateProgram() uint32 {
+	defer checkError()
 	program := gl.CreateProgram()
 	return program
 }
 
 // Wrapper for gl.AttachShader function.
 func AttachShader(program, shader uint32) {
+	defer checkError()
 	gl.AttachShader(program, shader)
 }
 
 // Wrapper for gl.LinkProgram function.
 func LinkProgram(program uint32) {
+	defer checkError()
 	gl.LinkProgram(program)
 }
 
 // Wrapper for gl.UniformMatrix4fv function.
 func UniformMatrix4fv(location int32, count int32, transpose bool, value *float32) {
+	defer checkError()
 	gl.UniformMatrix4fv(location, count, transpose, value)
 }
 
 // Wrapper for gl.CreateShader function.
 func CreateShader(shaderType uint32) uint32 {
+	defer checkError()
 	shader := gl.CreateShader(shaderType)
 	return shader
 }
@@ -155,21 +248,25 @@ func Strs(strs string) (**uint8, func()) {
 
 // Wrapper for gl.ShaderSource function.
 func ShaderSource(shader uint32, count int32, xstring **uint8, length *int32) {
+	defer checkError()
 	gl.ShaderSource(shader, count, xstring, length)
 }
 
 // Wrapper for gl.CompileShader function.
 func CompileShader(id uint32) {
+	defer checkError()
 	gl.CompileShader(id)
 }
 
 // Wrapper for gl.GetShaderiv function.
 func GetShaderiv(shader uint32, pname uint32, params *int32) {
+	defer checkError()
 	gl.GetShaderiv(shader, pname, params)
 }
 
 // Wrapper for gl.GetShaderInfoLog function.
 func GetShaderInfoLog(shader uint32, bufSize int32, length *int32, infoLog *uint8) {
+	defer checkError()
 	gl.GetShaderInfoLog(shader, bufSize, length, infoLog)
 }
 
@@ -183,12 +280,15 @@ func InitOpenGL() {
 	if err := gl.Init(); err != nil {
 		panic(err)
 	}
+	// the state of the new context is unknown.
+	InvalidateState()
 	version := gl.GoStr(gl.GetString(gl.VERSION))
 	fmt.Println("OpenGL version", version)
 }
 
 // Wrapper for gl.TexImage2D function.
 func TexImage2D(target uint32, level int32, internalformat int32, width int32, height int32, border int32, format uint32, xtype uint32, pixels unsafe.Pointer) {
+	defer checkError()
 	gl.TexImage2D(target, level, internalformat, width, height, border, format, xtype, pixels)
 }
 
@@ -199,26 +299,39 @@ func Ptr(data interface{}) unsafe.Pointer {
 
 // Wrapper for gl.GenerateMipmap function.
 func GenerateMipmap(target uint32) {
+	defer checkError()
 	gl.GenerateMipmap(target)
 }
 
 // Wrapper for gl.GenTextures function.
 func GenTextures(n int32, textures *uint32) {
+	defer checkError()
 	gl.GenTextures(n, textures)
 }
 
+// Wrapper for gl.DeleteTextures function. The gl unbinds the deleted texture
+// from every texture unit, the cached bindings are updated.
+func DeleteTextures(texture uint32) {
+	cache.deleted(stateTexture, texture)
+	defer checkError()
+	gl.DeleteTextures(1, &texture)
+}
+
 // Wrapper for gl.UniformMatrix3fv function.
 func UniformMatrix3fv(location int32, count int32, transpose bool, value *float32) {
+	defer checkError()
 	gl.UniformMatrix3fv(location, count, transpose, value)
 }
 
 // Wrapper for gl.Uniform3f function
 func Uniform3f(location int32, v0 float32, v1 float32, v2 float32) {
+	defer checkError()
 	gl.Uniform3f(location, v0, v1, v2)
 }
 
 // Wrapper for gl.Uniform1f function.
 func Uniform1f(location int32, v0 float32) {
+	defer checkError()
 	gl.Uniform1f(location, v0)
 }
 
@@ -229,45 +342,256 @@ func PtrOffset(offset int) unsafe.Pointer {
 
 // Wrapper for gl.DisableVertexAttribArray function.
 func DisableVertexAttribArray(index uint32) {
+	defer checkError()
 	gl.DisableVertexAttribArray(index)
 }
 
 // Wrapper for gl.DrawArrays function.
 func DrawArrays(mode uint32, first int32, count int32) {
+	defer checkError()
+	countDraw(mode, count)
 	gl.DrawArrays(mode, first, count)
 }
 
+// Wrapper for gl.DrawArraysInstanced function.
+func DrawArraysInstanced(mode uint32, first int32, count int32, instances int32) {
+	defer checkError()
+	countDrawInstanced(mode, count, instances)
+	gl.DrawArraysInstanced(mode, first, count, instances)
+}
+
+// Wrapper for gl.VertexAttribDivisor function. The attribute with non 0
+// divisor is advanced once per divisor instances instead of once per vertex.
+func VertexAttribDivisor(index uint32, divisor uint32) {
+	defer checkError()
+	gl.VertexAttribDivisor(index, divisor)
+}
+
 // Wrapper for gl.TexParameteri function.
 func TexParameteri(target uint32, pname uint32, param int32) {
+	defer checkError()
 	gl.TexParameteri(target, pname, param)
 }
 
 // Wrapper fro gl.TexParameterfv function.
 func TexParameterfv(target uint32, pname uint32, params *float32) {
+	defer checkError()
 	gl.TexParameterfv(target, pname, params)
 }
 
 // Wrapper for gl.ClearColor function.
 func ClearColor(red float32, green float32, blue float32, alpha float32) {
+	defer checkError()
 	gl.ClearColor(red, green, blue, alpha)
 }
 
 // Wrapper fro gl.Clear function.
 func Clear(mask uint32) {
+	defer checkError()
 	gl.Clear(mask)
 }
 
 // Wrapper for gl.Enable function.
 func Enable(cap uint32) {
+	if cache.setCapability(cap, true) {
+		return
+	}
+	defer checkError()
 	gl.Enable(cap)
 }
 
+// Wrapper for gl.Disable function.
+func Disable(cap uint32) {
+	if cache.setCapability(cap, false) {
+		return
+	}
+	defer checkError()
+	gl.Disable(cap)
+}
+
 // Wrapper for gl.DepthFunc function.
 func DepthFunc(xfunc uint32) {
+	if cache.depthFunc(xfunc) {
+		return
+	}
+	defer checkError()
 	gl.DepthFunc(xfunc)
 }
 
+// Wrapper for gl.DepthMask function.
+func DepthMask(flag bool) {
+	if cache.depthMask(flag) {
+		return
+	}
+	defer checkError()
+	gl.DepthMask(flag)
+}
+
+// Wrapper for gl.BlendFunc function.
+func BlendFunc(sfactor uint32, dfactor uint32) {
+	if cache.blendFunc(sfactor, dfactor) {
+		return
+	}
+	defer checkError()
+	gl.BlendFunc(sfactor, dfactor)
+}
+
 // Wrapper for gl.Viewport function.
 func Viewport(x int32, y int32, width int32, height int32) {
+	if cache.setViewport([4]int32{x, y, width, height}) {
+		return
+	}
+	defer checkError()
 	gl.Viewport(x, y, width, height)
 }
+
+// Wrapper for gl.PolygonMode function.
+func PolygonMode(face uint32, mode uint32) {
+	defer checkError()
+	gl.PolygonMode(face, mode)
+}
+
+// Wrapper for gl.GenFramebuffers function.
+func GenFramebuffers() uint32 {
+	defer checkError()
+	var framebuffer uint32
+	gl.GenFramebuffers(1, &framebuffer)
+	return framebuffer
+}
+
+// Wrapper for gl.BindFramebuffer function.
+func BindFramebuffer(target, framebuffer uint32) {
+	defer checkError()
+	gl.BindFramebuffer(target, framebuffer)
+}
+
+// Wrapper for gl.DeleteFramebuffers function.
+func DeleteFramebuffers(framebuffer uint32) {
+	defer checkError()
+	gl.DeleteFramebuffers(1, &framebuffer)
+}
+
+// Wrapper for gl.CheckFramebufferStatus function.
+func CheckFramebufferStatus(target uint32) uint32 {
+	defer checkError()
+	return gl.CheckFramebufferStatus(target)
+}
+
+// Wrapper for gl.GenRenderbuffers function.
+func GenRenderbuffers() uint32 {
+	defer checkError()
+	var renderbuffer uint32
+	gl.GenRenderbuffers(1, &renderbuffer)
+	return renderbuffer
+}
+
+// Wrapper for gl.BindRenderbuffer function.
+func BindRenderbuffer(target, renderbuffer uint32) {
+	defer checkError()
+	gl.BindRenderbuffer(target, renderbuffer)
+}
+
+// Wrapper for gl.DeleteRenderbuffers function.
+func DeleteRenderbuffers(renderbuffer uint32) {
+	defer checkError()
+	gl.DeleteRenderbuffers(1, &renderbuffer)
+}
+
+// Wrapper for gl.RenderbufferStorage function.
+func RenderbufferStorage(target, internalformat uint32, width, height int32) {
+	defer checkError()
+	gl.RenderbufferStorage(target, internalformat, width, height)
+}
+
+// Wrapper for gl.FramebufferRenderbuffer function.
+func FramebufferRenderbuffer(target, attachment, renderbuffertarget, renderbuffer uint32) {
+	defer checkError()
+	gl.FramebufferRenderbuffer(target, attachment, renderbuffertarget, renderbuffer)
+}
+
+// Wrapper for gl.ReadPixels function.
+func ReadPixels(x, y, width, height int32, format, xtype uint32, pixels unsafe.Pointer) {
+	defer checkError()
+	gl.ReadPixels(x, y, width, height, format, xtype, pixels)
+}
+
+// Wrapper for gl.GetIntegerv function.
+func GetIntegerv(pname uint32, data *int32) {
+	defer checkError()
+	gl.GetIntegerv(pname, data)
+}
+
+// Wrapper for gl.GetFloatv function.
+func GetFloatv(pname uint32, data *float32) {
+	defer checkError()
+	gl.GetFloatv(pname, data)
+}
+
+// Wrapper for gl.GenQueries function.
+func GenQueries() uint32 {
+	defer checkError()
+	var query uint32
+	gl.GenQueries(1, &query)
+	return query
+}
+
+// Wrapper for gl.DeleteQueries function.
+func DeleteQueries(query uint32) {
+	defer checkError()
+	gl.DeleteQueries(1, &query)
+}
+
+// Wrapper for gl.BeginQuery function.
+func BeginQuery(target, query uint32) {
+	defer checkError()
+	gl.BeginQuery(target, query)
+}
+
+// Wrapper for gl.EndQuery function.
+func EndQuery(target uint32) {
+	defer checkError()
+	gl.EndQuery(target)
+}
+
+// Wrapper for gl.GetQueryObjectiv function.
+func GetQueryObjectiv(query, pname uint32, params *int32) {
+	defer checkError()
+	gl.GetQueryObjectiv(query, pname, params)
+}
+
+// Wrapper for gl.GetQueryObjectui64v function.
+func GetQueryObjectui64v(query, pname uint32, params *uint64) {
+	defer checkError()
+	gl.GetQueryObjectui64v(query, pname, params)
+}
+
+// countDraw increments the draw call counter and the triangle counter
+// in case of triangle mode.
+func countDraw(mode uint32, count int32) {
+	countDrawInstanced(mode, count, 1)
+}
+
+// countDrawInstanced counts an instanced draw call as one draw call, and the
+// triangles of every instance.
+func countDrawInstanced(mode uint32, count int32, instances int32) {
+	drawCalls++
+	if mode == gl.TRIANGLES {
+		triangles += int(count/3) * int(instances)
+	}
+}
+
+// ResetDrawCounters sets the draw call and the triangle counters to 0.
+func ResetDrawCounters() {
+	drawCalls = 0
+	triangles = 0
+}
+
+// GetDrawCalls returns the number of the draw calls since the last ResetDrawCounters call.
+func GetDrawCalls() int {
+	return drawCalls
+}
+
+// GetTriangles returns the number of the drawn triangles since the last ResetDrawCounters call.
+func GetTriangles() int {
+	return triangles
+}
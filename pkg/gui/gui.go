@@ -0,0 +1,403 @@
+package gui
+
+import (
+	"fmt"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/text"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// The vertex shader passes the screen coordinates through. The texture
+	// coordinates of the solid rectangles are negative, the fragment shader
+	// uses the atlas only for the glyphs.
+	VERTEX_SHADER = `
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec2 vTexCoord;
+layout(location = 2) in vec3 vColor;
+out vec2 texCoord;
+out vec3 color;
+void main()
+{
+    gl_Position = vec4(vVertex, 1.0);
+    texCoord = vTexCoord;
+    color = vColor;
+}
+`
+	FRAGMENT_SHADER = `
+#version 410
+in vec2 texCoord;
+in vec3 color;
+out vec4 FragColor;
+uniform sampler2D fontAtlas;
+void main()
+{
+    if (texCoord.x >= 0.0 && texture(fontAtlas, texCoord).a < 0.5) {
+        discard;
+    }
+    FragColor = vec4(color, 1.0);
+}
+`
+	// PADDING is the space around the widgets in pixels.
+	PADDING = float32(4)
+
+	// the layers are drawn in this order, the later ones are nearer.
+	layerPanel  = 0
+	layerWidget = 1
+	layerHandle = 2
+	layerText   = 3
+	layers      = 4
+	// the number of floats of a vertex: position, texture coordinates, color.
+	vertexSize = 8
+)
+
+var (
+	PanelColor  = mgl32.Vec3{0.15, 0.15, 0.15}
+	TitleColor  = mgl32.Vec3{0.25, 0.25, 0.45}
+	WidgetColor = mgl32.Vec3{0.3, 0.3, 0.3}
+	HotColor    = mgl32.Vec3{0.4, 0.4, 0.4}
+	ActiveColor = mgl32.Vec3{0.5, 0.5, 0.5}
+	HandleColor = mgl32.Vec3{0.3, 0.5, 0.8}
+	TextColor   = mgl32.Vec3{1, 1, 1}
+)
+
+// Input is the source of the mouse state. The application.Application
+// satisfies it, its state is maintained by the mouse callbacks.
+type Input interface {
+	GetCursorPos() (float64, float64)
+	GetMouseButtonState(glfw.MouseButton) bool
+}
+
+type Shader interface {
+	Use()
+	DrawTriangles(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+}
+
+// rect is a screen rectangle in pixels, from the top left corner.
+type rect struct {
+	x, y, width, height float32
+}
+
+func (r rect) contains(x, y float32) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+// panel is the layout state of the current panel. The widgets are placed
+// under each other, the cursor is the top of the next row.
+type panel struct {
+	title   string
+	x, y    float32
+	width   float32
+	cursorY float32
+}
+
+// Context is an immediate mode gui. The widgets are declared in every frame
+// between the Begin and the End calls, the functions of the widgets return
+// the interaction results immediately.
+type Context struct {
+	shader Shader
+	font   *text.Font
+
+	screenWidth  float32
+	screenHeight float32
+
+	// the mouse state of the current frame.
+	mouseX, mouseY float32
+	mouseDown      bool
+	mousePressed   bool
+	mouseReleased  bool
+
+	// the id of the widget under the cursor and the id of the widget that
+	// is interacted (pressed, dragged).
+	hot    string
+	active string
+	// the cursor is over a panel in the current and in the previous frame.
+	hovered    bool
+	wantsMouse bool
+
+	panel    *panel
+	layers   [layers][]float32
+	vertices []float32
+}
+
+// NewShader returns the shader of the gui with the atlas texture of the font.
+// It has to be called after the gl initialization.
+func NewShader(font *text.Font) *shader.Shader {
+	s := shader.NewShaderFromSource(VERTEX_SHADER, FRAGMENT_SHADER)
+	s.AddTextureFromImage(font.GetAtlas(), wrapper.CLAMP_TO_EDGE, wrapper.CLAMP_TO_EDGE, wrapper.LINEAR, wrapper.LINEAR, "fontAtlas")
+	return s
+}
+
+// New returns a gui context with 800x800 screen size.
+func New(shader Shader, font *text.Font) *Context {
+	return &Context{
+		shader:       shader,
+		font:         font,
+		screenWidth:  800,
+		screenHeight: 800,
+	}
+}
+
+// SetScreenSize updates the screen size in pixels.
+func (c *Context) SetScreenSize(width, height float32) {
+	c.screenWidth = width
+	c.screenHeight = height
+}
+
+// WantsMouse returns true if the cursor is over a panel or a widget is
+// interacted. In this case the mouse events shouldn't be handled by the scene.
+func (c *Context) WantsMouse() bool {
+	return c.wantsMouse || c.active != ""
+}
+
+// Begin starts a new frame. It reads the mouse state from the input.
+func (c *Context) Begin(input Input) {
+	x, y := input.GetCursorPos()
+	down := input.GetMouseButtonState(glfw.MouseButtonLeft)
+	c.mouseX, c.mouseY = float32(x), float32(y)
+	c.mousePressed = down && !c.mouseDown
+	c.mouseReleased = !down && c.mouseDown
+	c.mouseDown = down
+	c.hot = ""
+	c.hovered = false
+	c.panel = nil
+	for i := range c.layers {
+		c.layers[i] = c.layers[i][:0]
+	}
+}
+
+// End closes the current panel and draws the gui.
+func (c *Context) End() {
+	c.closePanel()
+	c.wantsMouse = c.hovered
+	if !c.mouseDown {
+		c.active = ""
+	}
+	c.vertices = c.vertices[:0]
+	for _, layer := range c.layers {
+		c.vertices = append(c.vertices, layer...)
+	}
+	if len(c.vertices) == 0 {
+		return
+	}
+	c.shader.Use()
+	c.shader.BindBufferData(c.vertices)
+	c.shader.BindVertexArray()
+	// setup points
+	c.shader.VertexAttribPointer(0, 3, 4*vertexSize, 0)
+	// setup texture coordinates
+	c.shader.VertexAttribPointer(1, 2, 4*vertexSize, 4*3)
+	// setup color
+	c.shader.VertexAttribPointer(2, 3, 4*vertexSize, 4*5)
+	c.shader.DrawTriangles(int32(len(c.vertices) / vertexSize))
+	c.shader.Close(3)
+}
+
+// Panel starts a new panel with a title bar. Its top left corner is at the
+// given screen position (pixels), the following widgets are placed on it.
+func (c *Context) Panel(title string, x, y, width float32) {
+	c.closePanel()
+	c.panel = &panel{title: title, x: x, y: y, width: width}
+	titleBar := rect{x, y, width, c.rowHeight()}
+	c.appendRectangle(layerWidget, titleBar, TitleColor)
+	c.appendText(title, x+PADDING, y+PADDING, TextColor)
+	c.panel.cursorY = y + titleBar.height + PADDING
+}
+
+// closePanel draws the background of the current panel, its height is known
+// after the last widget.
+func (c *Context) closePanel() {
+	if c.panel == nil {
+		return
+	}
+	background := rect{c.panel.x, c.panel.y, c.panel.width, c.panel.cursorY - c.panel.y}
+	c.appendRectangle(layerPanel, background, PanelColor)
+	if background.contains(c.mouseX, c.mouseY) {
+		c.hovered = true
+	}
+	c.panel = nil
+}
+
+// Label displays the text.
+func (c *Context) Label(label string) {
+	if c.panel == nil {
+		return
+	}
+	_, height := c.font.Measure(label)
+	c.appendText(label, c.panel.x+PADDING, c.panel.cursorY, TextColor)
+	c.panel.cursorY += height + PADDING
+}
+
+// Button displays a button. It returns true in the frame when the button is clicked.
+func (c *Context) Button(label string) bool {
+	r, ok := c.row()
+	if !ok {
+		return false
+	}
+	id := c.id(label)
+	hovered := c.interact(id, r)
+	c.appendRectangle(layerWidget, r, c.widgetColor(id))
+	width, _ := c.font.Measure(label)
+	c.appendText(label, r.x+(r.width-width)/2, r.y+PADDING/2, TextColor)
+	return hovered && c.mouseReleased && c.active == id
+}
+
+// Checkbox displays a checkbox with the label. The value is toggled on click.
+// It returns true if the value has been changed.
+func (c *Context) Checkbox(label string, value *bool) bool {
+	r, ok := c.row()
+	if !ok {
+		return false
+	}
+	id := c.id(label)
+	hovered := c.interact(id, r)
+	box := rect{r.x, r.y, r.height, r.height}
+	c.appendRectangle(layerWidget, box, c.widgetColor(id))
+	if *value {
+		c.appendRectangle(layerHandle, rect{box.x + PADDING, box.y + PADDING, box.width - 2*PADDING, box.height - 2*PADDING}, HandleColor)
+	}
+	c.appendText(label, box.x+box.width+PADDING, r.y+PADDING/2, TextColor)
+	if hovered && c.mouseReleased && c.active == id {
+		*value = !*value
+		return true
+	}
+	return false
+}
+
+// Slider displays a horizontal slider with the label and the value. The value
+// is set from the cursor position while the slider is dragged. It returns
+// true if the value has been changed.
+func (c *Context) Slider(label string, value *float32, min, max float32) bool {
+	if c.panel == nil {
+		return false
+	}
+	return c.slider(c.id(label), label, value, min, max)
+}
+
+// ColorPicker displays the label with the color and a slider for every
+// component of the color. It returns true if the color has been changed.
+func (c *Context) ColorPicker(label string, color *mgl32.Vec3) bool {
+	r, ok := c.row()
+	if !ok {
+		return false
+	}
+	c.appendText(label, r.x, r.y+PADDING/2, TextColor)
+	c.appendRectangle(layerHandle, rect{r.x + r.width - 2*r.height, r.y, 2 * r.height, r.height}, *color)
+	changed := false
+	for i, name := range []string{"R", "G", "B"} {
+		if c.slider(c.id(label+"/"+name), name, &color[i], 0, 1) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (c *Context) slider(id, label string, value *float32, min, max float32) bool {
+	r, ok := c.row()
+	if !ok || max <= min {
+		return false
+	}
+	c.interact(id, r)
+	changed := false
+	if c.active == id && c.mouseDown {
+		ratio := mgl32.Clamp((c.mouseX-r.x)/r.width, 0, 1)
+		newValue := min + ratio*(max-min)
+		if newValue != *value {
+			*value = newValue
+			changed = true
+		}
+	}
+	c.appendRectangle(layerWidget, r, c.widgetColor(id))
+	ratio := mgl32.Clamp((*value-min)/(max-min), 0, 1)
+	c.appendRectangle(layerHandle, rect{r.x, r.y, r.width * ratio, r.height}, HandleColor)
+	c.appendText(fmt.Sprintf("%s: %.3f", label, *value), r.x+PADDING, r.y+PADDING/2, TextColor)
+	return changed
+}
+
+// rowHeight returns the height of a single line widget.
+func (c *Context) rowHeight() float32 {
+	return c.font.GetLineHeight() + PADDING
+}
+
+// row returns the rectangle of the next widget of the panel.
+func (c *Context) row() (rect, bool) {
+	if c.panel == nil {
+		return rect{}, false
+	}
+	r := rect{c.panel.x + PADDING, c.panel.cursorY, c.panel.width - 2*PADDING, c.rowHeight()}
+	c.panel.cursorY += r.height + PADDING
+	return r, true
+}
+
+// id returns the identifier of the widget. The labels have to be unique in a panel.
+func (c *Context) id(label string) string {
+	return c.panel.title + "/" + label
+}
+
+// interact updates the hot and the active widgets. It returns true if the
+// cursor is over the widget.
+func (c *Context) interact(id string, r rect) bool {
+	hovered := r.contains(c.mouseX, c.mouseY)
+	if hovered && (c.active == "" || c.active == id) {
+		c.hot = id
+		if c.mousePressed {
+			c.active = id
+		}
+	}
+	return hovered
+}
+
+func (c *Context) widgetColor(id string) mgl32.Vec3 {
+	if c.active == id {
+		return ActiveColor
+	}
+	if c.hot == id {
+		return HotColor
+	}
+	return WidgetColor
+}
+
+// toScreen transforms the pixel coordinates to screen coordinates [-1, 1].
+func (c *Context) toScreen(x, y float32) (float32, float32) {
+	return x/c.screenWidth*2 - 1, 1 - y/c.screenHeight*2
+}
+
+// appendQuad appends the 2 triangles of the rectangle to the given layer.
+// The nearer layers have smaller depth.
+func (c *Context) appendQuad(layer int, r rect, u0, v0, u1, v1 float32, color mgl32.Vec3) {
+	if r.width <= 0 || r.height <= 0 {
+		return
+	}
+	depth := -1 + float32(layers-1-layer)*0.01
+	left, top := c.toScreen(r.x, r.y)
+	right, bottom := c.toScreen(r.x+r.width, r.y+r.height)
+	corners := [6][4]float32{
+		{left, top, u0, v0}, {left, bottom, u0, v1}, {right, bottom, u1, v1},
+		{left, top, u0, v0}, {right, bottom, u1, v1}, {right, top, u1, v0},
+	}
+	for _, p := range corners {
+		c.layers[layer] = append(c.layers[layer], p[0], p[1], depth, p[2], p[3], color.X(), color.Y(), color.Z())
+	}
+}
+
+// appendRectangle appends a solid rectangle to the given layer.
+func (c *Context) appendRectangle(layer int, r rect, color mgl32.Vec3) {
+	c.appendQuad(layer, r, -1, -1, -1, -1, color)
+}
+
+// appendText appends the glyph quads of the text to the text layer. The
+// position is the top left corner of the text.
+func (c *Context) appendText(s string, x, y float32, color mgl32.Vec3) {
+	for _, q := range c.font.Layout(s) {
+		c.appendQuad(layerText, rect{x + q.X, y + q.Y, q.Width, q.Height}, q.U0, q.V0, q.U1, q.V1, color)
+	}
+}
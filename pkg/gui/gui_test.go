@@ -0,0 +1,170 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/akosgarai/opengl_playground/pkg/text"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+type testShader struct {
+	drawnPoints *int32
+}
+
+func (t testShader) Use() {
+}
+func (t testShader) DrawTriangles(i int32) {
+	*t.drawnPoints = i
+}
+func (t testShader) Close(i int) {
+}
+func (t testShader) VertexAttribPointer(i uint32, c int32, s int32, o int) {
+}
+func (t testShader) BindVertexArray() {
+}
+func (t testShader) BindBufferData(d []float32) {
+}
+
+type testInput struct {
+	x, y float64
+	down bool
+}
+
+func (t testInput) GetCursorPos() (float64, float64) {
+	return t.x, t.y
+}
+func (t testInput) GetMouseButtonState(b glfw.MouseButton) bool {
+	return b == glfw.MouseButtonLeft && t.down
+}
+
+func testContext(t *testing.T, points *int32) *Context {
+	font, err := text.LoadTTF(goregular.TTF, 16, text.DEFAULT_CHARSET)
+	if err != nil {
+		t.Fatalf("LoadTTF failed: %s", err.Error())
+	}
+	return New(testShader{drawnPoints: points}, font)
+}
+
+// rowCenter returns the center of the n-th widget row of a panel at the origin.
+func rowCenter(c *Context, n int) (float64, float64) {
+	rowTop := c.rowHeight() + PADDING + float32(n)*(c.rowHeight()+PADDING)
+	return 100, float64(rowTop + c.rowHeight()/2)
+}
+
+func TestButton(t *testing.T) {
+	var points int32
+	c := testContext(t, &points)
+	x, y := rowCenter(c, 0)
+	frame := func(input testInput) bool {
+		c.Begin(input)
+		c.Panel("Test", 0, 0, 200)
+		clicked := c.Button("Click")
+		c.End()
+		return clicked
+	}
+	if frame(testInput{x, y, false}) {
+		t.Error("Button shouldn't be clicked without press")
+	}
+	if !c.WantsMouse() {
+		t.Error("The cursor is over the panel")
+	}
+	if frame(testInput{x, y, true}) {
+		t.Error("Button should be clicked on release")
+	}
+	if !frame(testInput{x, y, false}) {
+		t.Error("Button should be clicked")
+	}
+	// press outside, release over the button.
+	frame(testInput{500, 500, true})
+	if c.WantsMouse() {
+		t.Error("The cursor isn't over the panel")
+	}
+	if frame(testInput{x, y, false}) {
+		t.Error("Button shouldn't be clicked if the press was outside")
+	}
+	if points == 0 {
+		t.Error("The gui should be drawn")
+	}
+}
+func TestCheckbox(t *testing.T) {
+	c := testContext(t, new(int32))
+	x, y := rowCenter(c, 0)
+	value := false
+	for _, down := range []bool{true, false} {
+		c.Begin(testInput{x, y, down})
+		c.Panel("Test", 0, 0, 200)
+		changed := c.Checkbox("Enabled", &value)
+		c.End()
+		if changed == down {
+			t.Errorf("Invalid change state, down: '%v'.", down)
+		}
+	}
+	if !value {
+		t.Error("The value should be toggled")
+	}
+}
+func TestSlider(t *testing.T) {
+	c := testContext(t, new(int32))
+	_, y := rowCenter(c, 0)
+	value := float32(0.5)
+	frame := func(x float64, down bool) bool {
+		c.Begin(testInput{x, y, down})
+		c.Panel("Test", 0, 0, 200+2*PADDING)
+		changed := c.Slider("Value", &value, 0, 10)
+		c.End()
+		return changed
+	}
+	if !frame(float64(PADDING+50), true) || value != 2.5 {
+		t.Errorf("Invalid value after press '%f'.", value)
+	}
+	// the dragged slider follows the cursor outside of the widget.
+	if !frame(1000, true) || value != 10 {
+		t.Errorf("Value should be clamped '%f'.", value)
+	}
+	if !c.WantsMouse() {
+		t.Error("The dragged slider wants the mouse")
+	}
+	if frame(float64(PADDING+50), false) || value != 10 {
+		t.Error("Released slider shouldn't change")
+	}
+}
+func TestColorPicker(t *testing.T) {
+	c := testContext(t, new(int32))
+	// the first row is the label, the sliders are the next ones.
+	_, y := rowCenter(c, 2)
+	color := mgl32.Vec3{0, 0, 0}
+	c.Begin(testInput{float64(PADDING + 100), y, true})
+	c.Panel("Test", 0, 0, 200+2*PADDING)
+	if !c.ColorPicker("Color", &color) {
+		t.Error("The color should be changed")
+	}
+	c.End()
+	if color.X() != 0 || color.Y() != 0.5 || color.Z() != 0 {
+		t.Errorf("Invalid color '%v'.", color)
+	}
+	c.Begin(testInput{1000, y, true})
+	c.Panel("Test", 0, 0, 200+2*PADDING)
+	c.ColorPicker("Color", &color)
+	c.End()
+	if color.Y() != 1 || color.X() != 0 {
+		t.Errorf("Only the green component should be changed '%v'.", color)
+	}
+}
+func TestWithoutPanel(t *testing.T) {
+	var points int32
+	c := testContext(t, &points)
+	c.Begin(testInput{})
+	value := true
+	ratio := float32(0.5)
+	color := mgl32.Vec3{1, 1, 1}
+	if c.Button("Click") || c.Checkbox("Enabled", &value) || c.Slider("Ratio", &ratio, 0, 1) || c.ColorPicker("Color", &color) {
+		t.Error("Widgets without panel shouldn't be displayed")
+	}
+	c.End()
+	if points != 0 {
+		t.Error("Nothing should be drawn")
+	}
+}
@@ -0,0 +1,335 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// bindingConfig is the json representation of a binding. Exactly one of the
+// source fields has to be set.
+type bindingConfig struct {
+	Key           string   `json:"key,omitempty"`
+	Mouse         string   `json:"mouse,omitempty"`
+	MouseDelta    string   `json:"mouse_delta,omitempty"`
+	Scroll        string   `json:"scroll,omitempty"`
+	GamepadButton string   `json:"gamepad_button,omitempty"`
+	GamepadAxis   string   `json:"gamepad_axis,omitempty"`
+	Mods          []string `json:"mods,omitempty"`
+	Scale         *float32 `json:"scale,omitempty"`
+}
+
+// config is the json representation of the map.
+type config struct {
+	Actions map[string][]bindingConfig `json:"actions"`
+	Axes    map[string][]bindingConfig `json:"axes"`
+}
+
+var (
+	keyNames = map[string]glfw.Key{
+		"SPACE":         glfw.KeySpace,
+		"APOSTROPHE":    glfw.KeyApostrophe,
+		"COMMA":         glfw.KeyComma,
+		"MINUS":         glfw.KeyMinus,
+		"PERIOD":        glfw.KeyPeriod,
+		"SLASH":         glfw.KeySlash,
+		"SEMICOLON":     glfw.KeySemicolon,
+		"EQUAL":         glfw.KeyEqual,
+		"LEFT_BRACKET":  glfw.KeyLeftBracket,
+		"BACKSLASH":     glfw.KeyBackslash,
+		"RIGHT_BRACKET": glfw.KeyRightBracket,
+		"GRAVE_ACCENT":  glfw.KeyGraveAccent,
+		"ESCAPE":        glfw.KeyEscape,
+		"ENTER":         glfw.KeyEnter,
+		"TAB":           glfw.KeyTab,
+		"BACKSPACE":     glfw.KeyBackspace,
+		"INSERT":        glfw.KeyInsert,
+		"DELETE":        glfw.KeyDelete,
+		"RIGHT":         glfw.KeyRight,
+		"LEFT":          glfw.KeyLeft,
+		"DOWN":          glfw.KeyDown,
+		"UP":            glfw.KeyUp,
+		"PAGE_UP":       glfw.KeyPageUp,
+		"PAGE_DOWN":     glfw.KeyPageDown,
+		"HOME":          glfw.KeyHome,
+		"END":           glfw.KeyEnd,
+		"LEFT_SHIFT":    glfw.KeyLeftShift,
+		"LEFT_CONTROL":  glfw.KeyLeftControl,
+		"LEFT_ALT":      glfw.KeyLeftAlt,
+		"LEFT_SUPER":    glfw.KeyLeftSuper,
+		"RIGHT_SHIFT":   glfw.KeyRightShift,
+		"RIGHT_CONTROL": glfw.KeyRightControl,
+		"RIGHT_ALT":     glfw.KeyRightAlt,
+		"RIGHT_SUPER":   glfw.KeyRightSuper,
+	}
+	mouseButtonNames = map[string]glfw.MouseButton{
+		"LEFT":   glfw.MouseButtonLeft,
+		"RIGHT":  glfw.MouseButtonRight,
+		"MIDDLE": glfw.MouseButtonMiddle,
+		"4":      glfw.MouseButton4,
+		"5":      glfw.MouseButton5,
+	}
+	modNames = map[string]glfw.ModifierKey{
+		"SHIFT":   glfw.ModShift,
+		"CONTROL": glfw.ModControl,
+		"ALT":     glfw.ModAlt,
+		"SUPER":   glfw.ModSuper,
+	}
+	gamepadButtonNames = map[string]glfw.GamepadButton{
+		"A":            glfw.ButtonA,
+		"B":            glfw.ButtonB,
+		"X":            glfw.ButtonX,
+		"Y":            glfw.ButtonY,
+		"LEFT_BUMPER":  glfw.ButtonLeftBumper,
+		"RIGHT_BUMPER": glfw.ButtonRightBumper,
+		"BACK":         glfw.ButtonBack,
+		"START":        glfw.ButtonStart,
+		"GUIDE":        glfw.ButtonGuide,
+		"LEFT_THUMB":   glfw.ButtonLeftThumb,
+		"RIGHT_THUMB":  glfw.ButtonRightThumb,
+		"DPAD_UP":      glfw.ButtonDpadUp,
+		"DPAD_RIGHT":   glfw.ButtonDpadRight,
+		"DPAD_DOWN":    glfw.ButtonDpadDown,
+		"DPAD_LEFT":    glfw.ButtonDpadLeft,
+	}
+	gamepadAxisNames = map[string]glfw.GamepadAxis{
+		"LEFT_X":        glfw.AxisLeftX,
+		"LEFT_Y":        glfw.AxisLeftY,
+		"RIGHT_X":       glfw.AxisRightX,
+		"RIGHT_Y":       glfw.AxisRightY,
+		"LEFT_TRIGGER":  glfw.AxisLeftTrigger,
+		"RIGHT_TRIGGER": glfw.AxisRightTrigger,
+	}
+	axisNames = map[string]int{
+		"X": AXIS_X,
+		"Y": AXIS_Y,
+	}
+)
+
+func init() {
+	// the letters, the digits, the function and the keypad keys.
+	for c := 'A'; c <= 'Z'; c++ {
+		keyNames[string(c)] = glfw.KeyA + glfw.Key(c-'A')
+	}
+	for d := 0; d <= 9; d++ {
+		keyNames[fmt.Sprint(d)] = glfw.Key0 + glfw.Key(d)
+		keyNames[fmt.Sprintf("KP_%d", d)] = glfw.KeyKP0 + glfw.Key(d)
+	}
+	for f := 1; f <= 12; f++ {
+		keyNames[fmt.Sprintf("F%d", f)] = glfw.KeyF1 + glfw.Key(f-1)
+	}
+}
+
+// KeyByName returns the key of the given name (eg: "W", "SPACE", "F1"). The
+// names are case insensitive.
+func KeyByName(name string) (glfw.Key, bool) {
+	key, ok := keyNames[strings.ToUpper(name)]
+	return key, ok
+}
+
+// KeyName returns the name of the key, or empty string if it's unknown.
+func KeyName(key glfw.Key) string {
+	for name, k := range keyNames {
+		if k == key {
+			return name
+		}
+	}
+	return ""
+}
+
+// Load reads the json config of the actions and the axes.
+//
+//	{
+//		"actions": {"jump": [{"key": "SPACE"}, {"mouse": "RIGHT", "mods": ["SHIFT"]}]},
+//		"axes": {"move_x": [{"key": "D"}, {"key": "A", "scale": -1}, {"gamepad_axis": "LEFT_X"}], "look_x": [{"mouse_delta": "X"}]}
+//	}
+func Load(r io.Reader) (*Map, error) {
+	var c config
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, err
+	}
+	m := New()
+	for name, bindings := range c.Actions {
+		for _, bc := range bindings {
+			b, err := bc.binding()
+			if err != nil {
+				return nil, fmt.Errorf("Invalid binding of action '%s': %s", name, err.Error())
+			}
+			m.BindAction(name, b)
+		}
+	}
+	for name, bindings := range c.Axes {
+		for _, bc := range bindings {
+			b, err := bc.binding()
+			if err != nil {
+				return nil, fmt.Errorf("Invalid binding of axis '%s': %s", name, err.Error())
+			}
+			m.BindAxis(name, b)
+		}
+	}
+	return m, nil
+}
+
+// LoadFile reads the config file.
+func LoadFile(path string) (*Map, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Save writes the json config of the current bindings, so that the runtime
+// rebindings could be persisted.
+func (m *Map) Save(w io.Writer) error {
+	c := config{
+		Actions: make(map[string][]bindingConfig),
+		Axes:    make(map[string][]bindingConfig),
+	}
+	for name, a := range m.actions {
+		for _, b := range a.bindings {
+			c.Actions[name] = append(c.Actions[name], newBindingConfig(b))
+		}
+	}
+	for name, bindings := range m.axes {
+		for _, b := range bindings {
+			c.Axes[name] = append(c.Axes[name], newBindingConfig(b))
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(c)
+}
+
+// SaveFile writes the config to the given file.
+func (m *Map) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Save(f)
+}
+
+// binding returns the binding of the config.
+func (bc bindingConfig) binding() (Binding, error) {
+	var b Binding
+	sources := 0
+	if bc.Key != "" {
+		key, ok := KeyByName(bc.Key)
+		if !ok {
+			return b, fmt.Errorf("Unknown key '%s'.", bc.Key)
+		}
+		b = KeyBinding(key, 0)
+		sources++
+	}
+	if bc.Mouse != "" {
+		button, ok := mouseButtonNames[strings.ToUpper(bc.Mouse)]
+		if !ok {
+			return b, fmt.Errorf("Unknown mouse button '%s'.", bc.Mouse)
+		}
+		b = MouseButtonBinding(button, 0)
+		sources++
+	}
+	if bc.MouseDelta != "" {
+		axis, ok := axisNames[strings.ToUpper(bc.MouseDelta)]
+		if !ok {
+			return b, fmt.Errorf("Unknown axis '%s'.", bc.MouseDelta)
+		}
+		b = MouseDeltaBinding(axis)
+		sources++
+	}
+	if bc.Scroll != "" {
+		axis, ok := axisNames[strings.ToUpper(bc.Scroll)]
+		if !ok {
+			return b, fmt.Errorf("Unknown axis '%s'.", bc.Scroll)
+		}
+		b = ScrollBinding(axis)
+		sources++
+	}
+	if bc.GamepadButton != "" {
+		button, ok := gamepadButtonNames[strings.ToUpper(bc.GamepadButton)]
+		if !ok {
+			return b, fmt.Errorf("Unknown gamepad button '%s'.", bc.GamepadButton)
+		}
+		b = GamepadButtonBinding(button)
+		sources++
+	}
+	if bc.GamepadAxis != "" {
+		axis, ok := gamepadAxisNames[strings.ToUpper(bc.GamepadAxis)]
+		if !ok {
+			return b, fmt.Errorf("Unknown gamepad axis '%s'.", bc.GamepadAxis)
+		}
+		b = GamepadAxisBinding(axis)
+		sources++
+	}
+	if sources != 1 {
+		return b, fmt.Errorf("Exactly one source has to be set, instead of '%d'.", sources)
+	}
+	for _, name := range bc.Mods {
+		mod, ok := modNames[strings.ToUpper(name)]
+		if !ok {
+			return b, fmt.Errorf("Unknown modifier '%s'.", name)
+		}
+		b.Mods |= mod
+	}
+	if bc.Scale != nil {
+		b.Scale = *bc.Scale
+	}
+	return b, nil
+}
+
+// newBindingConfig returns the config of the binding.
+func newBindingConfig(b Binding) bindingConfig {
+	var bc bindingConfig
+	switch b.Source {
+	case SOURCE_KEY:
+		bc.Key = KeyName(b.Key)
+	case SOURCE_MOUSE_BUTTON:
+		for name, button := range mouseButtonNames {
+			if button == b.Button {
+				bc.Mouse = name
+			}
+		}
+	case SOURCE_MOUSE_DELTA:
+		bc.MouseDelta = axisName(b.Axis)
+	case SOURCE_SCROLL:
+		bc.Scroll = axisName(b.Axis)
+	case SOURCE_GAMEPAD_BUTTON:
+		for name, button := range gamepadButtonNames {
+			if button == b.GamepadButton {
+				bc.GamepadButton = name
+			}
+		}
+	case SOURCE_GAMEPAD_AXIS:
+		for name, axis := range gamepadAxisNames {
+			if axis == b.GamepadAxis {
+				bc.GamepadAxis = name
+			}
+		}
+	}
+	for name, mod := range modNames {
+		if b.Mods&mod != 0 {
+			bc.Mods = append(bc.Mods, name)
+		}
+	}
+	sort.Strings(bc.Mods)
+	if b.Scale != 1 {
+		scale := b.Scale
+		bc.Scale = &scale
+	}
+	return bc
+}
+
+func axisName(axis int) string {
+	if axis == AXIS_Y {
+		return "Y"
+	}
+	return "X"
+}
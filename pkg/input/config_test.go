@@ -0,0 +1,92 @@
+package input
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+const testConfig = `{
+	"actions": {
+		"jump": [{"key": "space"}, {"mouse": "RIGHT", "mods": ["SHIFT", "control"]}],
+		"start": [{"gamepad_button": "start"}]
+	},
+	"axes": {
+		"strafe": [{"key": "D"}, {"key": "A", "scale": -1}],
+		"look_y": [{"mouse_delta": "Y", "scale": 0.01}],
+		"zoom": [{"scroll": "y"}],
+		"walk": [{"gamepad_axis": "left_y", "scale": -1}]
+	}
+}`
+
+func TestKeyByName(t *testing.T) {
+	for name, key := range map[string]glfw.Key{"w": glfw.KeyW, "7": glfw.Key7, "F12": glfw.KeyF12, "KP_3": glfw.KeyKP3} {
+		if k, ok := KeyByName(name); !ok || k != key {
+			t.Errorf("Invalid key of '%s'.", name)
+		}
+	}
+	if KeyName(glfw.KeyW) != "W" {
+		t.Error("Invalid key name")
+	}
+}
+func TestLoad(t *testing.T) {
+	m, err := Load(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("Load failed: %s", err.Error())
+	}
+	jump := m.GetActionBindings("jump")
+	if len(jump) != 2 || jump[0].Key != glfw.KeySpace || jump[1].Button != glfw.MouseButtonRight || jump[1].Mods != glfw.ModShift|glfw.ModControl {
+		t.Errorf("Invalid jump bindings '%v'.", jump)
+	}
+	strafe := m.GetAxisBindings("strafe")
+	if len(strafe) != 2 || strafe[0].Scale != 1 || strafe[1].Scale != -1 {
+		t.Errorf("Invalid strafe bindings '%v'.", strafe)
+	}
+	if b := m.GetAxisBindings("look_y"); len(b) != 1 || b[0].Source != SOURCE_MOUSE_DELTA || b[0].Axis != AXIS_Y {
+		t.Error("Invalid mouse delta binding")
+	}
+	if b := m.GetActionBindings("start"); len(b) != 1 || b[0] != GamepadButtonBinding(glfw.ButtonStart) {
+		t.Errorf("Invalid gamepad button binding '%v'.", b)
+	}
+	if b := m.GetAxisBindings("walk"); len(b) != 1 || b[0] != GamepadAxisBinding(glfw.AxisLeftY).WithScale(-1) {
+		t.Errorf("Invalid gamepad axis binding '%v'.", b)
+	}
+	for _, invalid := range []string{
+		`{"actions": {"jump": [{"key": "unknown"}]}}`,
+		`{"actions": {"jump": [{"key": "A", "mouse": "LEFT"}]}}`,
+		`{"axes": {"zoom": [{"scroll": "Z"}]}}`,
+		`{"axes": {"walk": [{"gamepad_axis": "MIDDLE_Y"}]}}`,
+		`{"actions": {"jump": [{"key": "A", "mods": ["HYPER"]}]}}`,
+		`{"actions":`,
+	} {
+		if _, err := Load(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Invalid config should be an error '%s'.", invalid)
+		}
+	}
+}
+func TestSave(t *testing.T) {
+	m, err := Load(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("Load failed: %s", err.Error())
+	}
+	m.RebindAction("jump", KeyBinding(glfw.KeyJ, glfw.ModAlt))
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %s", err.Error())
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load of the saved config failed: %s", err.Error())
+	}
+	if b := loaded.GetActionBindings("jump"); len(b) != 1 || b[0] != KeyBinding(glfw.KeyJ, glfw.ModAlt) {
+		t.Errorf("Invalid saved binding '%v'.", b)
+	}
+	if b := loaded.GetAxisBindings("look_y"); len(b) != 1 || b[0].Scale != 0.01 {
+		t.Error("Invalid saved scale")
+	}
+	if b := loaded.GetAxisBindings("walk"); len(b) != 1 || b[0] != GamepadAxisBinding(glfw.AxisLeftY).WithScale(-1) {
+		t.Error("Invalid saved gamepad axis")
+	}
+}
@@ -0,0 +1,418 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+const (
+	// The sources of the bindings.
+	SOURCE_KEY            = 0
+	SOURCE_MOUSE_BUTTON   = 1
+	SOURCE_MOUSE_DELTA    = 2
+	SOURCE_SCROLL         = 3
+	SOURCE_GAMEPAD_BUTTON = 4
+	SOURCE_GAMEPAD_AXIS   = 5
+
+	// The axes of the mouse delta and the scroll bindings.
+	AXIS_X = 0
+	AXIS_Y = 1
+)
+
+// Binding is an input source of an action or an axis. The key and the mouse
+// button bindings are active if the modifiers are also pressed. The value of
+// the binding is multiplied with the scale.
+type Binding struct {
+	Source        int
+	Key           glfw.Key
+	Button        glfw.MouseButton
+	GamepadButton glfw.GamepadButton
+	GamepadAxis   glfw.GamepadAxis
+	Axis          int
+	Mods          glfw.ModifierKey
+	Scale         float32
+}
+
+// KeyBinding returns a binding of the key with the given modifiers.
+func KeyBinding(key glfw.Key, mods glfw.ModifierKey) Binding {
+	return Binding{Source: SOURCE_KEY, Key: key, Mods: mods, Scale: 1}
+}
+
+// MouseButtonBinding returns a binding of the mouse button with the given modifiers.
+func MouseButtonBinding(button glfw.MouseButton, mods glfw.ModifierKey) Binding {
+	return Binding{Source: SOURCE_MOUSE_BUTTON, Button: button, Mods: mods, Scale: 1}
+}
+
+// MouseDeltaBinding returns a binding of the cursor movement (pixels) on the given axis.
+func MouseDeltaBinding(axis int) Binding {
+	return Binding{Source: SOURCE_MOUSE_DELTA, Axis: axis, Scale: 1}
+}
+
+// ScrollBinding returns a binding of the scroll offset on the given axis.
+func ScrollBinding(axis int) Binding {
+	return Binding{Source: SOURCE_SCROLL, Axis: axis, Scale: 1}
+}
+
+// GamepadButtonBinding returns a binding of the button of the gamepad.
+func GamepadButtonBinding(button glfw.GamepadButton) Binding {
+	return Binding{Source: SOURCE_GAMEPAD_BUTTON, GamepadButton: button, Scale: 1}
+}
+
+// GamepadAxisBinding returns a binding of the analog axis of the gamepad. The
+// sticks are in [-1, 1], the triggers are in [0, 1].
+func GamepadAxisBinding(axis glfw.GamepadAxis) Binding {
+	return Binding{Source: SOURCE_GAMEPAD_AXIS, GamepadAxis: axis, Scale: 1}
+}
+
+// WithScale returns the binding with the given scale. The negative scale
+// could be used for the opposite direction of an axis (eg: A key on the x axis).
+func (b Binding) WithScale(scale float32) Binding {
+	b.Scale = scale
+	return b
+}
+
+// action is the state of a named action. The pressed and the released flags
+// are valid for a single update.
+type action struct {
+	bindings []Binding
+	down     bool
+	pressed  bool
+	released bool
+}
+
+// Map maps the named actions and axes to the input sources. The state of the
+// sources is maintained by the event functions (eg: KeyEvent), the state of
+// the actions and the axes is calculated by the Update function.
+type Map struct {
+	actions map[string]*action
+	axes    map[string][]Binding
+	values  map[string]float32
+
+	keys    map[glfw.Key]bool
+	buttons map[glfw.MouseButton]bool
+	// the keys and the buttons that have been pressed or released since the
+	// last update. The short taps are also detected.
+	keyPresses     map[glfw.Key]bool
+	keyReleases    map[glfw.Key]bool
+	buttonPresses  map[glfw.MouseButton]bool
+	buttonReleases map[glfw.MouseButton]bool
+	cursorX        float64
+	cursorY        float64
+	cursorSet      bool
+	delta, scroll  [2]float64
+	pendingDelta   [2]float64
+	pendingScroll  [2]float64
+	gamepad        glfw.GamepadState
+	listening      string
+}
+
+// New returns an empty map.
+func New() *Map {
+	return &Map{
+		actions:        make(map[string]*action),
+		axes:           make(map[string][]Binding),
+		values:         make(map[string]float32),
+		keys:           make(map[glfw.Key]bool),
+		buttons:        make(map[glfw.MouseButton]bool),
+		keyPresses:     make(map[glfw.Key]bool),
+		keyReleases:    make(map[glfw.Key]bool),
+		buttonPresses:  make(map[glfw.MouseButton]bool),
+		buttonReleases: make(map[glfw.MouseButton]bool),
+	}
+}
+
+// BindAction appends the bindings to the action.
+func (m *Map) BindAction(name string, bindings ...Binding) {
+	a, ok := m.actions[name]
+	if !ok {
+		a = &action{}
+		m.actions[name] = a
+	}
+	a.bindings = append(a.bindings, bindings...)
+}
+
+// BindAxis appends the bindings to the axis.
+func (m *Map) BindAxis(name string, bindings ...Binding) {
+	m.axes[name] = append(m.axes[name], bindings...)
+}
+
+// RebindAction replaces the bindings of the action.
+func (m *Map) RebindAction(name string, bindings ...Binding) {
+	m.UnbindAction(name)
+	m.BindAction(name, bindings...)
+}
+
+// RebindAxis replaces the bindings of the axis.
+func (m *Map) RebindAxis(name string, bindings ...Binding) {
+	m.UnbindAxis(name)
+	m.BindAxis(name, bindings...)
+}
+
+// UnbindAction removes the action.
+func (m *Map) UnbindAction(name string) {
+	delete(m.actions, name)
+}
+
+// UnbindAxis removes the axis.
+func (m *Map) UnbindAxis(name string) {
+	delete(m.axes, name)
+	delete(m.values, name)
+}
+
+// GetActionBindings returns the bindings of the action.
+func (m *Map) GetActionBindings(name string) []Binding {
+	if a, ok := m.actions[name]; ok {
+		return a.bindings
+	}
+	return nil
+}
+
+// GetAxisBindings returns the bindings of the axis.
+func (m *Map) GetAxisBindings(name string) []Binding {
+	return m.axes[name]
+}
+
+// ListenAction rebinds the action to the next pressed key or mouse button
+// with the pressed modifiers, or to the next pressed gamepad button. The event isn't handled as normal input. The
+// ESCAPE key cancels the listening.
+func (m *Map) ListenAction(name string) {
+	m.listening = name
+}
+
+// IsListening returns true if the map waits for the binding of an action.
+func (m *Map) IsListening() bool {
+	return m.listening != ""
+}
+
+// KeyEvent updates the state of the key. Its inputs are the same as the
+// inputs of the glfw key callback.
+func (m *Map) KeyEvent(key glfw.Key, action glfw.Action) {
+	if action == glfw.Repeat {
+		return
+	}
+	if m.listening != "" && action == glfw.Press && !isModifier(key) {
+		if key != glfw.KeyEscape {
+			m.RebindAction(m.listening, KeyBinding(key, m.mods()))
+		}
+		m.listening = ""
+		return
+	}
+	if action == glfw.Press {
+		m.keys[key] = true
+		m.keyPresses[key] = true
+	} else {
+		m.keys[key] = false
+		m.keyReleases[key] = true
+	}
+}
+
+// MouseButtonEvent updates the state of the mouse button.
+func (m *Map) MouseButtonEvent(button glfw.MouseButton, action glfw.Action) {
+	if m.listening != "" && action == glfw.Press {
+		m.RebindAction(m.listening, MouseButtonBinding(button, m.mods()))
+		m.listening = ""
+		return
+	}
+	if action == glfw.Press {
+		m.buttons[button] = true
+		m.buttonPresses[button] = true
+	} else if action == glfw.Release {
+		m.buttons[button] = false
+		m.buttonReleases[button] = true
+	}
+}
+
+// CursorPosEvent updates the cursor position. The movement is accumulated
+// until the next update.
+func (m *Map) CursorPosEvent(x, y float64) {
+	if m.cursorSet {
+		m.pendingDelta[AXIS_X] += x - m.cursorX
+		m.pendingDelta[AXIS_Y] += y - m.cursorY
+	}
+	m.cursorX, m.cursorY = x, y
+	m.cursorSet = true
+}
+
+// ResetCursor forgets the cursor position, so that the next position isn't a
+// movement (eg: the cursor jumps on capture).
+func (m *Map) ResetCursor() {
+	m.cursorSet = false
+}
+
+// GamepadEvent updates the state of the gamepad. The axes are expected to be
+// filtered with the dead zone. During the listening the newly pressed button
+// is bound to the action.
+func (m *Map) GamepadEvent(state glfw.GamepadState) {
+	if m.listening != "" {
+		for button, a := range state.Buttons {
+			if a == glfw.Press && m.gamepad.Buttons[button] != glfw.Press {
+				m.RebindAction(m.listening, GamepadButtonBinding(glfw.GamepadButton(button)))
+				m.listening = ""
+				break
+			}
+		}
+	}
+	m.gamepad = state
+}
+
+// ScrollEvent accumulates the scroll offsets until the next update.
+func (m *Map) ScrollEvent(xOffset, yOffset float64) {
+	m.pendingScroll[AXIS_X] += xOffset
+	m.pendingScroll[AXIS_Y] += yOffset
+}
+
+// Update calculates the state of the actions and the axes from the events
+// since the previous update.
+func (m *Map) Update() {
+	m.delta, m.pendingDelta = m.pendingDelta, [2]float64{}
+	m.scroll, m.pendingScroll = m.pendingScroll, [2]float64{}
+	for _, a := range m.actions {
+		wasDown := a.down
+		a.down = false
+		tapped, untapped := false, false
+		for _, b := range a.bindings {
+			if m.value(b) > 0 {
+				a.down = true
+			}
+			p, r := m.edges(b)
+			tapped = tapped || p
+			untapped = untapped || r
+		}
+		// a short tap between the updates is pressed and released in the same update.
+		a.pressed = !wasDown && (a.down || tapped)
+		a.released = !a.down && (wasDown || untapped)
+	}
+	for name, bindings := range m.axes {
+		var value float32
+		for _, b := range bindings {
+			value += m.value(b)
+		}
+		m.values[name] = value
+	}
+	for k := range m.keyPresses {
+		delete(m.keyPresses, k)
+	}
+	for k := range m.keyReleases {
+		delete(m.keyReleases, k)
+	}
+	for b := range m.buttonPresses {
+		delete(m.buttonPresses, b)
+	}
+	for b := range m.buttonReleases {
+		delete(m.buttonReleases, b)
+	}
+}
+
+// IsDown returns true if a binding of the action is active.
+func (m *Map) IsDown(name string) bool {
+	if a, ok := m.actions[name]; ok {
+		return a.down
+	}
+	return false
+}
+
+// Pressed returns true if the action has been pressed since the previous update.
+func (m *Map) Pressed(name string) bool {
+	if a, ok := m.actions[name]; ok {
+		return a.pressed
+	}
+	return false
+}
+
+// Released returns true if the action has been released since the previous update.
+func (m *Map) Released(name string) bool {
+	if a, ok := m.actions[name]; ok {
+		return a.released
+	}
+	return false
+}
+
+// Axis returns the sum of the scaled values of the axis bindings.
+func (m *Map) Axis(name string) float32 {
+	return m.values[name]
+}
+
+// mods returns the pressed modifiers.
+func (m *Map) mods() glfw.ModifierKey {
+	var mods glfw.ModifierKey
+	if m.keys[glfw.KeyLeftShift] || m.keys[glfw.KeyRightShift] {
+		mods |= glfw.ModShift
+	}
+	if m.keys[glfw.KeyLeftControl] || m.keys[glfw.KeyRightControl] {
+		mods |= glfw.ModControl
+	}
+	if m.keys[glfw.KeyLeftAlt] || m.keys[glfw.KeyRightAlt] {
+		mods |= glfw.ModAlt
+	}
+	if m.keys[glfw.KeyLeftSuper] || m.keys[glfw.KeyRightSuper] {
+		mods |= glfw.ModSuper
+	}
+	return mods
+}
+
+// value returns the current value of the binding.
+func (m *Map) value(b Binding) float32 {
+	switch b.Source {
+	case SOURCE_KEY:
+		if m.keys[b.Key] && m.mods()&b.Mods == b.Mods {
+			return b.Scale
+		}
+	case SOURCE_MOUSE_BUTTON:
+		if m.buttons[b.Button] && m.mods()&b.Mods == b.Mods {
+			return b.Scale
+		}
+	case SOURCE_MOUSE_DELTA:
+		return float32(m.delta[b.Axis]) * b.Scale
+	case SOURCE_SCROLL:
+		return float32(m.scroll[b.Axis]) * b.Scale
+	case SOURCE_GAMEPAD_BUTTON:
+		if m.gamepad.Buttons[b.GamepadButton] == glfw.Press {
+			return b.Scale
+		}
+	case SOURCE_GAMEPAD_AXIS:
+		return m.gamepad.Axes[b.GamepadAxis] * b.Scale
+	}
+	return 0
+}
+
+// edges returns the press and release events of the key and the mouse button
+// bindings since the previous update.
+func (m *Map) edges(b Binding) (bool, bool) {
+	if m.mods()&b.Mods != b.Mods {
+		return false, false
+	}
+	switch b.Source {
+	case SOURCE_KEY:
+		return m.keyPresses[b.Key], m.keyReleases[b.Key]
+	case SOURCE_MOUSE_BUTTON:
+		return m.buttonPresses[b.Button], m.buttonReleases[b.Button]
+	}
+	return false, false
+}
+
+// isModifier returns true if the key is a modifier key.
+func isModifier(key glfw.Key) bool {
+	switch key {
+	case glfw.KeyLeftShift, glfw.KeyRightShift, glfw.KeyLeftControl, glfw.KeyRightControl,
+		glfw.KeyLeftAlt, glfw.KeyRightAlt, glfw.KeyLeftSuper, glfw.KeyRightSuper:
+		return true
+	}
+	return false
+}
+
+// NewCameraMap returns a map with the camera movement axes of the examples.
+// The axes are named after the camera functions, their values could be
+// multiplied with the speed: "walk" (W, S, left stick), "strafe" (D, A, left
+// stick), "lift" (E, Q, left and right trigger). The "look_x" and "look_y"
+// axes are mapped to the right stick, their values could be passed to the
+// UpdateDirection of the camera.
+func NewCameraMap() *Map {
+	m := New()
+	// the y axis of the sticks goes down.
+	m.BindAxis("walk", KeyBinding(glfw.KeyW, 0), KeyBinding(glfw.KeyS, 0).WithScale(-1), GamepadAxisBinding(glfw.AxisLeftY).WithScale(-1))
+	m.BindAxis("strafe", KeyBinding(glfw.KeyD, 0), KeyBinding(glfw.KeyA, 0).WithScale(-1), GamepadAxisBinding(glfw.AxisLeftX))
+	m.BindAxis("lift", KeyBinding(glfw.KeyE, 0), KeyBinding(glfw.KeyQ, 0).WithScale(-1),
+		GamepadAxisBinding(glfw.AxisLeftTrigger), GamepadAxisBinding(glfw.AxisRightTrigger).WithScale(-1))
+	m.BindAxis("look_x", GamepadAxisBinding(glfw.AxisRightX))
+	m.BindAxis("look_y", GamepadAxisBinding(glfw.AxisRightY).WithScale(-1))
+	return m
+}
@@ -0,0 +1,156 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestAction(t *testing.T) {
+	m := New()
+	m.BindAction("jump", KeyBinding(glfw.KeySpace, 0), MouseButtonBinding(glfw.MouseButtonRight, 0))
+	m.KeyEvent(glfw.KeySpace, glfw.Press)
+	m.Update()
+	if !m.IsDown("jump") || !m.Pressed("jump") || m.Released("jump") {
+		t.Error("Jump should be pressed")
+	}
+	m.KeyEvent(glfw.KeySpace, glfw.Repeat)
+	m.Update()
+	if !m.IsDown("jump") || m.Pressed("jump") {
+		t.Error("Jump should be held")
+	}
+	// the other binding keeps the action down.
+	m.MouseButtonEvent(glfw.MouseButtonRight, glfw.Press)
+	m.KeyEvent(glfw.KeySpace, glfw.Release)
+	m.Update()
+	if !m.IsDown("jump") || m.Pressed("jump") || m.Released("jump") {
+		t.Error("Jump should be held by the mouse button")
+	}
+	m.MouseButtonEvent(glfw.MouseButtonRight, glfw.Release)
+	m.Update()
+	if m.IsDown("jump") || !m.Released("jump") {
+		t.Error("Jump should be released")
+	}
+	if m.IsDown("unknown") || m.Pressed("unknown") || m.Released("unknown") {
+		t.Error("Unknown action shouldn't be active")
+	}
+}
+func TestTap(t *testing.T) {
+	m := New()
+	m.BindAction("fire", KeyBinding(glfw.KeyF, 0))
+	m.KeyEvent(glfw.KeyF, glfw.Press)
+	m.KeyEvent(glfw.KeyF, glfw.Release)
+	m.Update()
+	if m.IsDown("fire") || !m.Pressed("fire") || !m.Released("fire") {
+		t.Error("The tap should be pressed and released")
+	}
+	m.Update()
+	if m.Pressed("fire") || m.Released("fire") {
+		t.Error("The edges are valid for a single update")
+	}
+}
+func TestMods(t *testing.T) {
+	m := New()
+	m.BindAction("save", KeyBinding(glfw.KeyS, glfw.ModControl))
+	m.KeyEvent(glfw.KeyS, glfw.Press)
+	m.Update()
+	if m.IsDown("save") || m.Pressed("save") {
+		t.Error("Save needs the control modifier")
+	}
+	m.KeyEvent(glfw.KeyS, glfw.Release)
+	m.KeyEvent(glfw.KeyRightControl, glfw.Press)
+	m.KeyEvent(glfw.KeyS, glfw.Press)
+	m.Update()
+	if !m.IsDown("save") || !m.Pressed("save") {
+		t.Error("Save should be pressed")
+	}
+}
+func TestAxis(t *testing.T) {
+	m := NewCameraMap()
+	m.BindAxis("look_x", MouseDeltaBinding(AXIS_X).WithScale(0.5))
+	m.BindAxis("zoom", ScrollBinding(AXIS_Y))
+	m.KeyEvent(glfw.KeyW, glfw.Press)
+	m.KeyEvent(glfw.KeyA, glfw.Press)
+	m.KeyEvent(glfw.KeyD, glfw.Press)
+	m.CursorPosEvent(100, 100)
+	m.CursorPosEvent(110, 90)
+	m.CursorPosEvent(120, 80)
+	m.ScrollEvent(0, 1)
+	m.ScrollEvent(0, 2)
+	m.Update()
+	if m.Axis("walk") != 1 || m.Axis("strafe") != 0 || m.Axis("lift") != 0 {
+		t.Error("Invalid movement axes")
+	}
+	if m.Axis("look_x") != 10 {
+		t.Errorf("Invalid mouse delta axis '%f'.", m.Axis("look_x"))
+	}
+	if m.Axis("zoom") != 3 {
+		t.Errorf("Invalid scroll axis '%f'.", m.Axis("zoom"))
+	}
+	m.Update()
+	if m.Axis("look_x") != 0 || m.Axis("zoom") != 0 {
+		t.Error("The deltas should be reset")
+	}
+	m.ResetCursor()
+	m.CursorPosEvent(500, 500)
+	m.Update()
+	if m.Axis("look_x") != 0 {
+		t.Errorf("The jump after the reset shouldn't be a movement '%f'.", m.Axis("look_x"))
+	}
+}
+func TestGamepad(t *testing.T) {
+	m := NewCameraMap()
+	m.BindAction("jump", GamepadButtonBinding(glfw.ButtonA))
+	var state glfw.GamepadState
+	state.Buttons[glfw.ButtonA] = glfw.Press
+	state.Axes[glfw.AxisLeftY] = -0.5
+	state.Axes[glfw.AxisRightTrigger] = 1
+	state.Axes[glfw.AxisRightX] = 0.25
+	m.GamepadEvent(state)
+	m.Update()
+	if !m.Pressed("jump") || !m.IsDown("jump") {
+		t.Error("Jump should be pressed")
+	}
+	if m.Axis("walk") != 0.5 || m.Axis("lift") != -1 || m.Axis("look_x") != 0.25 {
+		t.Errorf("Invalid gamepad axes '%f', '%f', '%f'.", m.Axis("walk"), m.Axis("lift"), m.Axis("look_x"))
+	}
+	m.GamepadEvent(glfw.GamepadState{})
+	m.Update()
+	if !m.Released("jump") || m.Axis("walk") != 0 {
+		t.Error("Jump should be released")
+	}
+	m.ListenAction("jump")
+	state.Buttons[glfw.ButtonA] = glfw.Release
+	state.Buttons[glfw.ButtonStart] = glfw.Press
+	m.GamepadEvent(state)
+	if b := m.GetActionBindings("jump"); m.IsListening() || len(b) != 1 || b[0].GamepadButton != glfw.ButtonStart {
+		t.Errorf("Invalid listened gamepad binding '%v'.", b)
+	}
+}
+func TestRebind(t *testing.T) {
+	m := New()
+	m.BindAction("jump", KeyBinding(glfw.KeySpace, 0))
+	m.RebindAction("jump", KeyBinding(glfw.KeyJ, 0))
+	if b := m.GetActionBindings("jump"); len(b) != 1 || b[0].Key != glfw.KeyJ {
+		t.Error("Jump should be rebound")
+	}
+	m.ListenAction("jump")
+	m.KeyEvent(glfw.KeyLeftShift, glfw.Press)
+	m.KeyEvent(glfw.KeyK, glfw.Press)
+	if m.IsListening() {
+		t.Error("The listening should be finished")
+	}
+	if b := m.GetActionBindings("jump"); len(b) != 1 || b[0].Key != glfw.KeyK || b[0].Mods != glfw.ModShift {
+		t.Errorf("Invalid listened binding '%v'.", b)
+	}
+	m.ListenAction("jump")
+	m.KeyEvent(glfw.KeyEscape, glfw.Press)
+	if m.IsListening() || m.GetActionBindings("jump")[0].Key != glfw.KeyK {
+		t.Error("The escape should cancel the listening")
+	}
+	m.UnbindAxis("walk")
+	m.UnbindAction("jump")
+	if m.GetActionBindings("jump") != nil {
+		t.Error("Jump should be removed")
+	}
+}
@@ -0,0 +1,163 @@
+package lod
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/vao"
+)
+
+const (
+	// DEFAULT_HYSTERESIS is the default ratio of the hysteresis band around
+	// the level limits.
+	DEFAULT_HYSTERESIS = 0.1
+)
+
+// View is the state of the camera that is needed for the projected size
+// calculation. The Fov is the vertical field of view in radians, the Height
+// is the height of the viewport in pixels.
+type View struct {
+	Position mgl32.Vec3
+	Fov      float32
+	Height   float32
+	// OrthoSize is the half height of the orthographic view volume. It's 0
+	// for perspective projection.
+	OrthoSize float32
+}
+
+// ProjectedSize returns the approximate height of the sphere on the screen in
+// pixels. It returns the height of the viewport if the camera is inside the
+// sphere. With orthographic projection the size doesn't depend on the distance.
+func (v View) ProjectedSize(s bounds.Sphere) float32 {
+	if v.OrthoSize > 0 {
+		return s.Radius / v.OrthoSize * v.Height
+	}
+	distance := s.Center.Sub(v.Position).Len()
+	if distance <= s.Radius {
+		return v.Height
+	}
+	halfFov := float64(v.Fov) / 2
+	return s.Radius / (distance * float32(math.Tan(halfFov))) * v.Height
+}
+
+// Level is a level of detail. It's used if the projected size is at least
+// MinSize pixels. The Precision is the tessellation of the procedural primitives.
+type Level struct {
+	MinSize   float32
+	Precision int
+}
+
+// Selector selects the level of detail based on the projected size. The
+// levels are ordered by the MinSize, the first one is the most detailed. The
+// selected level is changed only if the size is out of the limits with the
+// hysteresis, so that the level doesn't pop back and forth near a limit.
+type Selector struct {
+	levels     []Level
+	hysteresis float32
+	current    int
+	selected   bool
+}
+
+// NewSelector returns a selector of the given levels with DEFAULT_HYSTERESIS.
+// It panics without levels.
+func NewSelector(levels ...Level) *Selector {
+	if len(levels) == 0 {
+		panic("The selector needs at least one level.")
+	}
+	sorted := append([]Level{}, levels...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinSize > sorted[j].MinSize
+	})
+	return &Selector{
+		levels:     sorted,
+		hysteresis: DEFAULT_HYSTERESIS,
+	}
+}
+
+// SetHysteresis updates the ratio of the hysteresis band. With 0.1 the
+// selector changes to a more detailed level if the size is 10% above its
+// limit, and it changes to a less detailed level if the size is 10% below the
+// limit of the current level.
+func (s *Selector) SetHysteresis(h float32) {
+	s.hysteresis = h
+}
+
+// Len returns the number of the levels.
+func (s *Selector) Len() int {
+	return len(s.levels)
+}
+
+// Levels returns the levels in order, the first one is the most detailed.
+func (s *Selector) Levels() []Level {
+	return s.levels
+}
+
+// Select updates the current level based on the projected size and returns its index.
+func (s *Selector) Select(size float32) int {
+	if !s.selected {
+		s.selected = true
+		s.current = len(s.levels) - 1
+		for i := range s.levels {
+			if size >= s.levels[i].MinSize {
+				s.current = i
+				break
+			}
+		}
+		return s.current
+	}
+	for s.current > 0 && size >= s.levels[s.current-1].MinSize*(1+s.hysteresis) {
+		s.current--
+	}
+	for s.current < len(s.levels)-1 && size < s.levels[s.current].MinSize*(1-s.hysteresis) {
+		s.current++
+	}
+	return s.current
+}
+
+// Index returns the index of the current level.
+func (s *Selector) Index() int {
+	return s.current
+}
+
+// Current returns the current level.
+func (s *Selector) Current() Level {
+	return s.levels[s.current]
+}
+
+// Cache stores the generated vertex data of the levels, so that they are
+// generated only once. The owner has to clear it if the data is changed.
+type Cache struct {
+	levels map[int]*vao.VAO
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{
+		levels: make(map[int]*vao.VAO),
+	}
+}
+
+// Get returns the vao of the given key. If it's missing, it's generated with
+// the build function.
+func (c *Cache) Get(key int, build func(*vao.VAO)) *vao.VAO {
+	if v, ok := c.levels[key]; ok {
+		return v
+	}
+	v := vao.NewVAO()
+	build(v)
+	c.levels[key] = v
+	return v
+}
+
+// Len returns the number of the cached levels.
+func (c *Cache) Len() int {
+	return len(c.levels)
+}
+
+// Clear removes the cached levels.
+func (c *Cache) Clear() {
+	c.levels = make(map[int]*vao.VAO)
+}
@@ -0,0 +1,114 @@
+package lod
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/vao"
+)
+
+func testSelector() *Selector {
+	// the levels are given in wrong order, the selector sorts them.
+	return NewSelector(
+		Level{MinSize: 0, Precision: 4},
+		Level{MinSize: 200, Precision: 32},
+		Level{MinSize: 50, Precision: 12},
+	)
+}
+func TestNewSelector(t *testing.T) {
+	s := testSelector()
+	if s.Len() != 3 {
+		t.Errorf("Invalid number of levels '%d'.", s.Len())
+	}
+	levels := s.Levels()
+	if levels[0].Precision != 32 || levels[1].Precision != 12 || levels[2].Precision != 4 {
+		t.Errorf("Invalid level order '%v'.", levels)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("It should panic without levels")
+			}
+		}()
+		NewSelector()
+	}()
+}
+func TestSelect(t *testing.T) {
+	s := testSelector()
+	if index := s.Select(100); index != 1 || s.Current().Precision != 12 {
+		t.Errorf("Invalid first selection '%d'.", index)
+	}
+	testData := []struct {
+		size  float32
+		index int
+	}{
+		// inside the hysteresis band of the 200 limit.
+		{210, 1},
+		{221, 0},
+		{190, 0},
+		{179, 1},
+		{46, 1},
+		{44, 2},
+		{54, 2},
+		{56, 1},
+		{0, 2},
+		{1000, 0},
+	}
+	for _, tt := range testData {
+		if index := s.Select(tt.size); index != tt.index || s.Index() != tt.index {
+			t.Errorf("Invalid level for the size '%f'. Instead of '%d', we have '%d'.", tt.size, tt.index, index)
+		}
+	}
+	s.SetHysteresis(0)
+	s.Select(199)
+	if s.Index() != 1 {
+		t.Error("The level should be changed at the limit without hysteresis")
+	}
+}
+func TestProjectedSize(t *testing.T) {
+	view := View{Position: mgl32.Vec3{0, 0, 0}, Fov: mgl32.DegToRad(90), Height: 600}
+	// with 90 degrees fov the half height of the screen is the distance.
+	size := view.ProjectedSize(bounds.Sphere{Center: mgl32.Vec3{0, 0, -10}, Radius: 1})
+	if !mgl32.FloatEqualThreshold(size, 60, 1e-3) {
+		t.Errorf("Invalid projected size '%f'.", size)
+	}
+	far := view.ProjectedSize(bounds.Sphere{Center: mgl32.Vec3{0, 0, -20}, Radius: 1})
+	if far >= size {
+		t.Error("The farther sphere should be smaller")
+	}
+	if inside := view.ProjectedSize(bounds.Sphere{Center: mgl32.Vec3{0, 0, 0}, Radius: 1}); inside != 600 {
+		t.Errorf("Invalid size from inside '%f'.", inside)
+	}
+	view.OrthoSize = 10
+	if ortho := view.ProjectedSize(bounds.Sphere{Center: mgl32.Vec3{0, 0, -20}, Radius: 1}); ortho != 60 {
+		t.Errorf("Invalid orthographic size '%f'.", ortho)
+	}
+}
+func TestCache(t *testing.T) {
+	c := NewCache()
+	builds := 0
+	build := func(v *vao.VAO) {
+		builds++
+		v.AppendVectors(mgl32.Vec3{}, mgl32.Vec3{})
+	}
+	first := c.Get(10, build)
+	if c.Get(10, build) != first || builds != 1 {
+		t.Error("The cached level should be reused")
+	}
+	if len(first.Get()) != 6 {
+		t.Error("Invalid cached data")
+	}
+	c.Get(20, build)
+	if c.Len() != 2 || builds != 2 {
+		t.Error("Invalid number of cached levels")
+	}
+	c.Clear()
+	if c.Len() != 0 {
+		t.Error("The cache should be empty")
+	}
+	if c.Get(10, build) == first || builds != 3 {
+		t.Error("The level should be rebuilt after clear")
+	}
+}
@@ -0,0 +1,343 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	glbMagic     = 0x46546C67
+	glbChunkJSON = 0x4E4F534A
+	glbChunkBIN  = 0x004E4942
+
+	gltfUnsignedByte  = 5121
+	gltfUnsignedShort = 5123
+	gltfUnsignedInt   = 5125
+	gltfFloat         = 5126
+
+	gltfModeTriangles = 4
+)
+
+type gltfDocument struct {
+	Scene  *int `json:"scene"`
+	Scenes []struct {
+		Nodes []int `json:"nodes"`
+	} `json:"scenes"`
+	Nodes []struct {
+		Mesh        *int      `json:"mesh"`
+		Children    []int     `json:"children"`
+		Matrix      []float32 `json:"matrix"`
+		Translation []float32 `json:"translation"`
+		Rotation    []float32 `json:"rotation"`
+		Scale       []float32 `json:"scale"`
+	} `json:"nodes"`
+	Meshes []struct {
+		Primitives []struct {
+			Attributes map[string]int `json:"attributes"`
+			Indices    *int           `json:"indices"`
+			Mode       *int           `json:"mode"`
+		} `json:"primitives"`
+	} `json:"meshes"`
+	Accessors []struct {
+		BufferView    *int   `json:"bufferView"`
+		ByteOffset    int    `json:"byteOffset"`
+		ComponentType int    `json:"componentType"`
+		Count         int    `json:"count"`
+		Type          string `json:"type"`
+	} `json:"accessors"`
+	BufferViews []struct {
+		Buffer     int `json:"buffer"`
+		ByteOffset int `json:"byteOffset"`
+		ByteLength int `json:"byteLength"`
+		ByteStride int `json:"byteStride"`
+	} `json:"bufferViews"`
+	Buffers []struct {
+		URI        string `json:"uri"`
+		ByteLength int    `json:"byteLength"`
+	} `json:"buffers"`
+}
+
+// LoadGLTFFile reads a gltf 2.0 ('.gltf' with embedded or external buffers,
+// or '.glb') file. Only the triangle primitives with float positions are
+// processed. The node transformations of the default scene are applied.
+func LoadGLTFFile(path string) (*Mesh, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadGLTF(data, filepath.Dir(path))
+}
+
+// LoadGLTF reads a gltf or glb content. The external buffers are loaded
+// relative to the given directory.
+func LoadGLTF(data []byte, dir string) (*Mesh, error) {
+	var binChunk []byte
+	if len(data) >= 12 && binary.LittleEndian.Uint32(data) == glbMagic {
+		var err error
+		data, binChunk, err = splitGLB(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var doc gltfDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	buffers := make([][]byte, len(doc.Buffers))
+	for i, b := range doc.Buffers {
+		switch {
+		case b.URI == "":
+			if binChunk == nil {
+				return nil, fmt.Errorf("Buffer %d: missing binary chunk.", i)
+			}
+			buffers[i] = binChunk
+		case strings.HasPrefix(b.URI, "data:"):
+			comma := strings.Index(b.URI, ",")
+			if comma < 0 {
+				return nil, fmt.Errorf("Buffer %d: invalid data uri.", i)
+			}
+			content, err := base64.StdEncoding.DecodeString(b.URI[comma+1:])
+			if err != nil {
+				return nil, err
+			}
+			buffers[i] = content
+		default:
+			content, err := ioutil.ReadFile(filepath.Join(dir, b.URI))
+			if err != nil {
+				return nil, err
+			}
+			buffers[i] = content
+		}
+	}
+	loader := &gltfLoader{doc: &doc, buffers: buffers, mesh: &Mesh{}}
+	var roots []int
+	if len(doc.Scenes) > 0 {
+		scene := 0
+		if doc.Scene != nil {
+			scene = *doc.Scene
+		}
+		if scene >= len(doc.Scenes) {
+			return nil, fmt.Errorf("Invalid scene index '%d'.", scene)
+		}
+		roots = doc.Scenes[scene].Nodes
+	}
+	if len(doc.Nodes) == 0 {
+		// without nodes every mesh is drawn without transformation.
+		for i := range doc.Meshes {
+			if err := loader.addMesh(i, mgl32.Ident4()); err != nil {
+				return nil, err
+			}
+		}
+		return loader.mesh, nil
+	}
+	for _, node := range roots {
+		if err := loader.addNode(node, mgl32.Ident4(), 0); err != nil {
+			return nil, err
+		}
+	}
+	return loader.mesh, nil
+}
+func splitGLB(data []byte) ([]byte, []byte, error) {
+	var jsonChunk, binChunk []byte
+	offset := 12
+	for offset+8 <= len(data) {
+		length := int(binary.LittleEndian.Uint32(data[offset:]))
+		chunkType := binary.LittleEndian.Uint32(data[offset+4:])
+		start := offset + 8
+		if start+length > len(data) {
+			return nil, nil, fmt.Errorf("Invalid glb chunk length.")
+		}
+		switch chunkType {
+		case glbChunkJSON:
+			jsonChunk = data[start : start+length]
+		case glbChunkBIN:
+			binChunk = data[start : start+length]
+		}
+		offset = start + length
+	}
+	if jsonChunk == nil {
+		return nil, nil, fmt.Errorf("Missing glb json chunk.")
+	}
+	return bytes.TrimRight(jsonChunk, " \x00"), binChunk, nil
+}
+
+type gltfLoader struct {
+	doc     *gltfDocument
+	buffers [][]byte
+	mesh    *Mesh
+}
+
+func (l *gltfLoader) addNode(index int, parent mgl32.Mat4, depth int) error {
+	if index < 0 || index >= len(l.doc.Nodes) || depth > len(l.doc.Nodes) {
+		return fmt.Errorf("Invalid node index '%d'.", index)
+	}
+	node := l.doc.Nodes[index]
+	local := mgl32.Ident4()
+	if len(node.Matrix) == 16 {
+		copy(local[:], node.Matrix)
+	} else {
+		if len(node.Translation) == 3 {
+			local = local.Mul4(mgl32.Translate3D(node.Translation[0], node.Translation[1], node.Translation[2]))
+		}
+		if len(node.Rotation) == 4 {
+			q := mgl32.Quat{W: node.Rotation[3], V: mgl32.Vec3{node.Rotation[0], node.Rotation[1], node.Rotation[2]}}
+			local = local.Mul4(q.Mat4())
+		}
+		if len(node.Scale) == 3 {
+			local = local.Mul4(mgl32.Scale3D(node.Scale[0], node.Scale[1], node.Scale[2]))
+		}
+	}
+	transformation := parent.Mul4(local)
+	if node.Mesh != nil {
+		if err := l.addMesh(*node.Mesh, transformation); err != nil {
+			return err
+		}
+	}
+	for _, child := range node.Children {
+		if err := l.addNode(child, transformation, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (l *gltfLoader) addMesh(index int, transformation mgl32.Mat4) error {
+	if index < 0 || index >= len(l.doc.Meshes) {
+		return fmt.Errorf("Invalid mesh index '%d'.", index)
+	}
+	normalMatrix := transformation.Mat3().Inv().Transpose()
+	for _, primitive := range l.doc.Meshes[index].Primitives {
+		if primitive.Mode != nil && *primitive.Mode != gltfModeTriangles {
+			continue
+		}
+		positionAccessor, ok := primitive.Attributes["POSITION"]
+		if !ok {
+			continue
+		}
+		positions, err := l.readVec3(positionAccessor)
+		if err != nil {
+			return err
+		}
+		var normals []mgl32.Vec3
+		if normalAccessor, ok := primitive.Attributes["NORMAL"]; ok {
+			if normals, err = l.readVec3(normalAccessor); err != nil {
+				return err
+			}
+		}
+		var indices []int
+		if primitive.Indices != nil {
+			if indices, err = l.readIndices(*primitive.Indices); err != nil {
+				return err
+			}
+		} else {
+			for i := range positions {
+				indices = append(indices, i)
+			}
+		}
+		for i := 0; i+2 < len(indices); i += 3 {
+			var points [3]mgl32.Vec3
+			var triangleNormals []mgl32.Vec3
+			for j := 0; j < 3; j++ {
+				vertex := indices[i+j]
+				if vertex >= len(positions) {
+					return fmt.Errorf("Index '%d' is out of range.", vertex)
+				}
+				points[j] = mgl32.TransformCoordinate(positions[vertex], transformation)
+				if vertex < len(normals) {
+					triangleNormals = append(triangleNormals, normalMatrix.Mul3x1(normals[vertex]).Normalize())
+				}
+			}
+			l.mesh.addTriangle(points, triangleNormals)
+		}
+	}
+	return nil
+}
+
+// accessorData returns the bytes of the accessor, the element stride and the element count.
+func (l *gltfLoader) accessorData(index int, elementSize int) ([]byte, int, int, error) {
+	if index < 0 || index >= len(l.doc.Accessors) {
+		return nil, 0, 0, fmt.Errorf("Invalid accessor index '%d'.", index)
+	}
+	accessor := l.doc.Accessors[index]
+	if accessor.BufferView == nil || *accessor.BufferView >= len(l.doc.BufferViews) {
+		return nil, 0, 0, fmt.Errorf("Accessor %d: invalid buffer view.", index)
+	}
+	view := l.doc.BufferViews[*accessor.BufferView]
+	if view.Buffer >= len(l.buffers) {
+		return nil, 0, 0, fmt.Errorf("Accessor %d: invalid buffer.", index)
+	}
+	stride := view.ByteStride
+	if stride == 0 {
+		stride = elementSize
+	}
+	if accessor.Count == 0 {
+		return nil, stride, 0, nil
+	}
+	start := view.ByteOffset + accessor.ByteOffset
+	end := start + stride*(accessor.Count-1) + elementSize
+	buffer := l.buffers[view.Buffer]
+	if end > len(buffer) || end > view.ByteOffset+view.ByteLength {
+		return nil, 0, 0, fmt.Errorf("Accessor %d: data is out of range.", index)
+	}
+	return buffer[start:end], stride, accessor.Count, nil
+}
+func (l *gltfLoader) readVec3(index int) ([]mgl32.Vec3, error) {
+	if index >= 0 && index < len(l.doc.Accessors) {
+		accessor := l.doc.Accessors[index]
+		if accessor.ComponentType != gltfFloat || accessor.Type != "VEC3" {
+			return nil, fmt.Errorf("Accessor %d: float VEC3 is expected.", index)
+		}
+	}
+	data, stride, count, err := l.accessorData(index, 12)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]mgl32.Vec3, count)
+	for i := 0; i < count; i++ {
+		for j := 0; j < 3; j++ {
+			result[i][j] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*stride+j*4:]))
+		}
+	}
+	return result, nil
+}
+func (l *gltfLoader) readIndices(index int) ([]int, error) {
+	if index < 0 || index >= len(l.doc.Accessors) {
+		return nil, fmt.Errorf("Invalid accessor index '%d'.", index)
+	}
+	var size int
+	componentType := l.doc.Accessors[index].ComponentType
+	switch componentType {
+	case gltfUnsignedByte:
+		size = 1
+	case gltfUnsignedShort:
+		size = 2
+	case gltfUnsignedInt:
+		size = 4
+	default:
+		return nil, fmt.Errorf("Accessor %d: invalid index component type '%d'.", index, componentType)
+	}
+	data, stride, count, err := l.accessorData(index, size)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, count)
+	for i := 0; i < count; i++ {
+		switch size {
+		case 1:
+			result[i] = int(data[i*stride])
+		case 2:
+			result[i] = int(binary.LittleEndian.Uint16(data[i*stride:]))
+		case 4:
+			result[i] = int(binary.LittleEndian.Uint32(data[i*stride:]))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Mesh is a triangle list. Every 3 vertices form a triangle, and every vertex
+// has a normal vector with the same index.
+type Mesh struct {
+	Vertices []mgl32.Vec3
+	Normals  []mgl32.Vec3
+}
+
+// addTriangle appends the given triangle to the mesh. If the normal vectors
+// are missing, the face normal is used for every vertex.
+func (m *Mesh) addTriangle(points [3]mgl32.Vec3, normals []mgl32.Vec3) {
+	if len(normals) != 3 {
+		n := faceNormal(points)
+		normals = []mgl32.Vec3{n, n, n}
+	}
+	m.Vertices = append(m.Vertices, points[0], points[1], points[2])
+	m.Normals = append(m.Normals, normals[0], normals[1], normals[2])
+}
+
+// TriangleCount returns the number of the triangles of the mesh.
+func (m *Mesh) TriangleCount() int {
+	return len(m.Vertices) / 3
+}
+
+// Bounds returns the minimum and maximum corners of the axis aligned
+// bounding box of the mesh.
+func (m *Mesh) Bounds() (mgl32.Vec3, mgl32.Vec3) {
+	if len(m.Vertices) == 0 {
+		return mgl32.Vec3{}, mgl32.Vec3{}
+	}
+	min := m.Vertices[0]
+	max := m.Vertices[0]
+	for _, v := range m.Vertices[1:] {
+		for i := 0; i < 3; i++ {
+			if v[i] < min[i] {
+				min[i] = v[i]
+			}
+			if v[i] > max[i] {
+				max[i] = v[i]
+			}
+		}
+	}
+	return min, max
+}
+
+// faceNormal returns the normal vector of the triangle. In case of
+// degenerated triangle it returns null vector.
+func faceNormal(points [3]mgl32.Vec3) mgl32.Vec3 {
+	n := points[1].Sub(points[0]).Cross(points[2].Sub(points[0]))
+	if n.Len() == 0 {
+		return n
+	}
+	return n.Normalize()
+}
+
+// LoadFile loads the mesh from the given file. The format is based on the
+// extension: '.obj', '.stl', '.gltf', '.glb'.
+func LoadFile(path string) (*Mesh, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".obj":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return LoadOBJ(f)
+	case ".stl":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return LoadSTL(f)
+	case ".gltf", ".glb":
+		return LoadGLTFFile(path)
+	}
+	return nil, fmt.Errorf("Unsupported model format '%s'.", filepath.Ext(path))
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const testOBJ = `# square
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vn 0 0 1
+f 1//1 2//1 3//1 4//1
+f -4 -3 -2
+`
+
+const testASCIISTL = `solid test
+facet normal 0 0 1
+  outer loop
+    vertex 0 0 0
+    vertex 1 0 0
+    vertex 1 1 0
+  endloop
+endfacet
+facet normal 0 0 0
+  outer loop
+    vertex 0 0 0
+    vertex 1 1 0
+    vertex 0 1 0
+  endloop
+endfacet
+endsolid test
+`
+
+func binarySTL(triangles [][4]mgl32.Vec3) []byte {
+	var buf bytes.Buffer
+	header := make([]byte, 80)
+	copy(header, "solid binary")
+	buf.Write(header)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(triangles)))
+	for _, t := range triangles {
+		for _, v := range t {
+			binary.Write(&buf, binary.LittleEndian, [3]float32{v[0], v[1], v[2]})
+		}
+		binary.Write(&buf, binary.LittleEndian, uint16(0))
+	}
+	return buf.Bytes()
+}
+
+// gltfBuffer returns a buffer with 3 positions and 3 uint16 indices.
+func gltfBuffer() []byte {
+	var buf bytes.Buffer
+	for _, v := range []float32{0, 0, 0, 1, 0, 0, 0, 1, 0} {
+		binary.Write(&buf, binary.LittleEndian, math.Float32bits(v))
+	}
+	binary.Write(&buf, binary.LittleEndian, []uint16{0, 1, 2, 0})
+	return buf.Bytes()
+}
+
+const gltfJSON = `{
+  "scene": 0,
+  "scenes": [{"nodes": [0]}],
+  "nodes": [{"mesh": 0, "translation": [0, 0, 5]}],
+  "meshes": [{"primitives": [{"attributes": {"POSITION": 0}, "indices": 1}]}],
+  "accessors": [
+    {"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"},
+    {"bufferView": 1, "componentType": 5123, "count": 3, "type": "SCALAR"}
+  ],
+  "bufferViews": [
+    {"buffer": 0, "byteOffset": 0, "byteLength": 36},
+    {"buffer": 0, "byteOffset": 36, "byteLength": 6}
+  ],
+  "buffers": [{"byteLength": 44BUFFER_URI}]
+}`
+
+func TestLoadOBJ(t *testing.T) {
+	mesh, err := LoadOBJ(strings.NewReader(testOBJ))
+	if err != nil {
+		t.Fatalf("LoadOBJ failed: %s", err.Error())
+	}
+	if mesh.TriangleCount() != 3 {
+		t.Errorf("Invalid number of triangles. Instead of '3', we have '%d'.", mesh.TriangleCount())
+	}
+	if mesh.Vertices[4] != (mgl32.Vec3{1, 1, 0}) {
+		t.Error("Invalid fan triangulation")
+	}
+	if mesh.Normals[8] != (mgl32.Vec3{0, 0, 1}) {
+		t.Error("Invalid calculated normal")
+	}
+	if _, err := LoadOBJ(strings.NewReader("v 0 0 0\nf 1 2 3\n")); err == nil {
+		t.Error("Invalid index should fail")
+	}
+}
+func TestLoadSTL(t *testing.T) {
+	mesh, err := LoadSTL(strings.NewReader(testASCIISTL))
+	if err != nil {
+		t.Fatalf("LoadSTL failed: %s", err.Error())
+	}
+	if mesh.TriangleCount() != 2 {
+		t.Errorf("Invalid number of triangles. Instead of '2', we have '%d'.", mesh.TriangleCount())
+	}
+	if mesh.Normals[3] != (mgl32.Vec3{0, 0, 1}) {
+		t.Error("Invalid calculated normal")
+	}
+	data := binarySTL([][4]mgl32.Vec3{
+		{{0, 0, 1}, {0, 0, 0}, {1, 0, 0}, {1, 1, 0}},
+	})
+	mesh, err = LoadSTL(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadSTL failed: %s", err.Error())
+	}
+	if mesh.TriangleCount() != 1 || mesh.Vertices[2] != (mgl32.Vec3{1, 1, 0}) {
+		t.Error("Invalid binary stl mesh")
+	}
+	if _, err := LoadSTL(strings.NewReader("invalid")); err == nil {
+		t.Error("Invalid content should fail")
+	}
+}
+func TestLoadGLTF(t *testing.T) {
+	uri := `, "uri": "data:application/octet-stream;base64,` + base64.StdEncoding.EncodeToString(gltfBuffer()) + `"`
+	mesh, err := LoadGLTF([]byte(strings.Replace(gltfJSON, "BUFFER_URI", uri, 1)), "")
+	if err != nil {
+		t.Fatalf("LoadGLTF failed: %s", err.Error())
+	}
+	if mesh.TriangleCount() != 1 {
+		t.Errorf("Invalid number of triangles. Instead of '1', we have '%d'.", mesh.TriangleCount())
+	}
+	if mesh.Vertices[1] != (mgl32.Vec3{1, 0, 5}) {
+		t.Errorf("Node transformation hasn't been applied. '%v'", mesh.Vertices[1])
+	}
+}
+func TestLoadGLB(t *testing.T) {
+	content := []byte(strings.Replace(gltfJSON, "BUFFER_URI", "", 1))
+	for len(content)%4 != 0 {
+		content = append(content, ' ')
+	}
+	bin := gltfBuffer()
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{glbMagic, 2, uint32(12 + 8 + len(content) + 8 + len(bin))})
+	binary.Write(&buf, binary.LittleEndian, []uint32{uint32(len(content)), glbChunkJSON})
+	buf.Write(content)
+	binary.Write(&buf, binary.LittleEndian, []uint32{uint32(len(bin)), glbChunkBIN})
+	buf.Write(bin)
+	mesh, err := LoadGLTF(buf.Bytes(), "")
+	if err != nil {
+		t.Fatalf("LoadGLTF failed: %s", err.Error())
+	}
+	if mesh.TriangleCount() != 1 || mesh.Normals[0] != (mgl32.Vec3{0, 0, 1}) {
+		t.Error("Invalid glb mesh")
+	}
+}
+func TestBounds(t *testing.T) {
+	mesh, _ := LoadOBJ(strings.NewReader(testOBJ))
+	min, max := mesh.Bounds()
+	if min != (mgl32.Vec3{0, 0, 0}) || max != (mgl32.Vec3{1, 1, 0}) {
+		t.Error("Invalid bounds")
+	}
+}
+func TestLoadFile(t *testing.T) {
+	if _, err := LoadFile("model.unknown"); err == nil {
+		t.Error("Unknown extension should fail")
+	}
+	if _, err := LoadFile("missing.obj"); err == nil {
+		t.Error("Missing file should fail")
+	}
+}
@@ -0,0 +1,362 @@
+package model
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	sh "github.com/akosgarai/opengl_playground/pkg/shader"
+	"github.com/akosgarai/opengl_playground/pkg/vao"
+)
+
+const (
+	DRAW_MODE_COLOR  = 0
+	DRAW_MODE_LIGHT  = 1
+	DRAW_MODE_NORMAL = 2
+)
+
+type Shader interface {
+	Use()
+	SetUniformMat4(string, mgl32.Mat4)
+	SetUniform3f(string, float32, float32, float32)
+	SetUniform1f(string, float32)
+	DrawTriangles(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+}
+
+// Model is the drawable form of a loaded mesh.
+type Model struct {
+	vao    *vao.VAO
+	shader Shader
+	mesh   *Mesh
+	// the level of detail chain: the meshes of the levels, the selector and
+	// the generated vao of the levels.
+	meshes []*Mesh
+	level  int
+	detail *lod.Selector
+	cache  *lod.Cache
+
+	position mgl32.Vec3
+	scale    float32
+	color    mgl32.Vec3
+
+	direction mgl32.Vec3
+	speed     float32
+	// rotation parameters
+	// angle has to be in radian
+	angle float32
+	axis  mgl32.Vec3
+
+	material *material.Material
+	// drawMode map:
+	// 0 - the vertices are drawn with the color.
+	// 1 - the vertices are drawn with normal vectors, the color is calculated from the material.
+	// 2 - the vertices are drawn with the color calculated from the normal vectors.
+	drawMode int
+}
+
+// New returns a model of the given mesh. It's placed to the origo with scale 1.
+func New(mesh *Mesh, shader Shader) *Model {
+	color := mgl32.Vec3{1, 1, 1}
+	return &Model{
+		vao:    vao.NewVAO(),
+		shader: shader,
+		mesh:   mesh,
+		cache:  lod.NewCache(),
+
+		position: mgl32.Vec3{0, 0, 0},
+		scale:    1,
+		color:    color,
+
+		direction: mgl32.Vec3{0, 0, 0},
+		speed:     0,
+
+		angle:    0,
+		axis:     mgl32.Vec3{0, 0, 0},
+		material: material.New(color, color, color, 36.0),
+		drawMode: DRAW_MODE_COLOR,
+	}
+}
+
+// Log returns the string representation of the model.
+func (m *Model) Log() string {
+	logString := "Model:\n"
+	logString += " - Position : Coordinate: Vector{" + trans.Vec3ToString(m.position) + "}, scale: " + trans.Float32ToString(m.scale) + ", color: Vector{" + trans.Vec3ToString(m.color) + "}\n"
+	logString += " - Triangles : " + trans.IntegerToString(m.mesh.TriangleCount()) + "\n"
+	logString += " - Movement : Direction: Vector{" + trans.Vec3ToString(m.direction) + "}, speed: " + trans.Float32ToString(m.speed) + "\n"
+	logString += " - Rotation : Axis: Vector{" + trans.Vec3ToString(m.axis) + "}, angle: " + trans.Float32ToString(m.angle) + "\n"
+	logString += m.material.Log() + "\n"
+	return logString
+}
+
+// GetMesh returns the mesh of the model.
+func (m *Model) GetMesh() *Mesh {
+	return m.mesh
+}
+
+// SetPosition updates the position of the model.
+func (m *Model) SetPosition(p mgl32.Vec3) {
+	m.position = p
+}
+
+// GetPosition returns the position of the model.
+func (m *Model) GetPosition() mgl32.Vec3 {
+	return m.position
+}
+
+// SetScale updates the scale of the model.
+func (m *Model) SetScale(s float32) {
+	m.scale = s
+}
+
+// GetScale returns the scale of the model.
+func (m *Model) GetScale() float32 {
+	return m.scale
+}
+
+// SetColor updates the color of the model.
+func (m *Model) SetColor(c mgl32.Vec3) {
+	m.color = c
+	m.cache.Clear()
+}
+
+// SetDirection updates the direction vector.
+func (m *Model) SetDirection(dir mgl32.Vec3) {
+	m.direction = dir
+}
+
+// SetSpeed updates the speed.
+func (m *Model) SetSpeed(speed float32) {
+	m.speed = speed
+}
+
+// GetDirection returns the direction vector.
+func (m *Model) GetDirection() mgl32.Vec3 {
+	return m.direction
+}
+
+// GetSpeed returns the speed.
+func (m *Model) GetSpeed() float32 {
+	return m.speed
+}
+
+// SetAngle updates the rotation angle of the model.
+func (m *Model) SetAngle(angle float32) {
+	m.angle = angle
+}
+
+// SetAxis updates the rotation axis of the model.
+func (m *Model) SetAxis(axis mgl32.Vec3) {
+	m.axis = axis
+}
+
+// GetAngle returns the rotation angle of the model.
+func (m *Model) GetAngle() float32 {
+	return m.angle
+}
+
+// GetAxis returns the rotation axis of the model.
+func (m *Model) GetAxis() mgl32.Vec3 {
+	return m.axis
+}
+
+// SetMaterial updates the material of the model.
+func (m *Model) SetMaterial(mat *material.Material) {
+	m.material = mat
+}
+
+// GetMaterial returns the material of the model.
+func (m *Model) GetMaterial() *material.Material {
+	return m.material
+}
+
+// DrawMode updates the draw mode after validation. If it fails, it keeps the original value.
+func (m *Model) DrawMode(mode int) {
+	if mode != DRAW_MODE_COLOR && mode != DRAW_MODE_LIGHT && mode != DRAW_MODE_NORMAL {
+		return
+	}
+	if m.drawMode != mode {
+		m.cache.Clear()
+	}
+	m.drawMode = mode
+}
+
+// GetDrawMode returns the current draw mode.
+func (m *Model) GetDrawMode() int {
+	return m.drawMode
+}
+func (m *Model) setupVao() {
+	m.vao.Clear()
+	for i, v := range m.mesh.Vertices {
+		switch m.drawMode {
+		case DRAW_MODE_LIGHT:
+			m.vao.AppendVectors(v, m.mesh.Normals[i])
+		case DRAW_MODE_NORMAL:
+			m.vao.AppendVectors(v, m.mesh.Normals[i].Mul(0.5).Add(mgl32.Vec3{0.5, 0.5, 0.5}))
+		default:
+			m.vao.AppendVectors(v, m.color)
+		}
+	}
+}
+
+// buildVao uses the cached vao of the current level. It's generated only for
+// the first time.
+func (m *Model) buildVao() {
+	m.vao = m.cache.Get(m.level, func(v *vao.VAO) {
+		m.vao = v
+		m.setupVao()
+	})
+
+	m.shader.BindBufferData(m.vao.Get())
+
+	m.shader.BindVertexArray()
+	// setup points
+	m.shader.VertexAttribPointer(0, 3, 4*6, 0)
+	// setup color or normal
+	m.shader.VertexAttribPointer(1, 3, 4*6, 4*3)
+}
+func (m *Model) modelTransformation() mgl32.Mat4 {
+	rotation := mgl32.Ident4()
+	if m.axis.Len() > 0 {
+		rotation = mgl32.HomogRotate3D(m.angle, m.axis)
+	}
+	return mgl32.Translate3D(
+		m.position.X(),
+		m.position.Y(),
+		m.position.Z()).Mul4(rotation).Mul4(mgl32.Scale3D(
+		m.scale,
+		m.scale,
+		m.scale))
+}
+func (m *Model) setupColorUniform() {
+	if m.drawMode == DRAW_MODE_LIGHT {
+		diffuse := m.material.GetDiffuse()
+		ambient := m.material.GetAmbient()
+		specular := m.material.GetSpecular()
+		shininess := m.material.GetShininess()
+		m.shader.SetUniform3f("material.diffuse", diffuse.X(), diffuse.Y(), diffuse.Z())
+		m.shader.SetUniform3f("material.ambient", ambient.X(), ambient.Y(), ambient.Z())
+		m.shader.SetUniform3f("material.specular", specular.X(), specular.Y(), specular.Z())
+		m.shader.SetUniform1f("material.shininess", shininess)
+		m.shader.SetUniform1f("material.alpha", m.material.GetAlpha())
+	}
+}
+
+// DrawWithUniforms sets the model, view, projection uniforms and draws the model.
+func (m *Model) DrawWithUniforms(view, projection mgl32.Mat4) {
+	m.shader.Use()
+	m.shader.SetUniformMat4("view", view)
+	m.shader.SetUniformMat4("projection", projection)
+	m.shader.SetUniformMat4("model", m.modelTransformation())
+	m.setupColorUniform()
+	m.draw()
+}
+
+// Draw draws the model without transformations.
+func (m *Model) Draw() {
+	m.shader.Use()
+	m.setupColorUniform()
+	m.draw()
+}
+func (m *Model) draw() {
+	m.buildVao()
+	m.shader.DrawTriangles(int32(len(m.vao.Get()) / 6))
+	m.shader.Close(2)
+}
+
+// GetRenderKeys returns the shader, the material, the texture set of the shader
+// and the mesh for the render queue.
+func (m *Model) GetRenderKeys() (interface{}, interface{}, interface{}, interface{}) {
+	return m.shader, m.material, sh.TextureKeyOf(m.shader), m.mesh
+}
+
+// GetTransform returns the model transformation.
+func (m *Model) GetTransform() mgl32.Mat4 {
+	return m.modelTransformation()
+}
+
+// IsTransparent returns true if the material of the model is transparent.
+func (m *Model) IsTransparent() bool {
+	return m.material.IsTransparent()
+}
+
+// Update moves the model based on the direction, speed and the delta time.
+func (m *Model) Update(dt float64) {
+	delta := float32(dt)
+	motionVector := m.direction
+	if motionVector.Len() > 0 {
+		motionVector = motionVector.Normalize().Mul(delta * m.speed)
+	}
+	m.position = (m.position).Add(motionVector)
+}
+
+// GetBoundingBox returns the axis aligned bounding box of the transformed mesh.
+func (m *Model) GetBoundingBox() bounds.AABB {
+	min, max := m.mesh.Bounds()
+	return bounds.AABB{Min: min, Max: max}.Transform(m.modelTransformation())
+}
+
+// GetBoundingSphere returns the bounding sphere of the transformed mesh.
+func (m *Model) GetBoundingSphere() bounds.Sphere {
+	min, max := m.mesh.Bounds()
+	return bounds.AABB{Min: min, Max: max}.BoundingSphere().Transform(m.modelTransformation())
+}
+
+// SetLOD sets the level of detail chain. The meshes are the meshes of the
+// levels of the selector in the same order, the first one is the most
+// detailed. The mesh of the model is updated in the SelectLOD calls. It
+// panics if the number of the meshes and the levels are different.
+func (m *Model) SetLOD(selector *lod.Selector, meshes []*Mesh) {
+	if selector.Len() != len(meshes) {
+		panic("The number of the meshes has to be the number of the levels.")
+	}
+	m.detail = selector
+	m.meshes = meshes
+	m.level = 0
+	m.mesh = meshes[0]
+	m.cache.Clear()
+}
+
+// SelectLOD selects the mesh based on the projected size of the model. It
+// does nothing without level of detail chain.
+func (m *Model) SelectLOD(view lod.View) {
+	if m.detail == nil {
+		return
+	}
+	m.level = m.detail.Select(view.ProjectedSize(m.GetBoundingSphere()))
+	m.mesh = m.meshes[m.level]
+}
+
+// GetTriangles returns the triangles of the transformed mesh.
+func (m *Model) GetTriangles() [][3]mgl32.Vec3 {
+	M := m.modelTransformation()
+	triangles := make([][3]mgl32.Vec3, 0, m.mesh.TriangleCount())
+	for i := 0; i+2 < len(m.mesh.Vertices); i += 3 {
+		triangles = append(triangles, [3]mgl32.Vec3{
+			mgl32.TransformCoordinate(m.mesh.Vertices[i], M),
+			mgl32.TransformCoordinate(m.mesh.Vertices[i+1], M),
+			mgl32.TransformCoordinate(m.mesh.Vertices[i+2], M),
+		})
+	}
+	return triangles
+}
+
+// IDCount returns the number of the ids of the model. It's 1.
+func (m *Model) IDCount() int {
+	return 1
+}
+
+// DrawIDs draws the model with the id shader and the color of the first id.
+func (m *Model) DrawIDs(idShader picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	picking.SetID(idShader, first)
+	original := m.shader
+	m.shader = idShader
+	m.DrawWithUniforms(view, projection)
+	m.shader = original
+}
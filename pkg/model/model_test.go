@@ -0,0 +1,200 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking/pickingtest"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+)
+
+type testShader struct {
+}
+
+func (t testShader) Use() {
+}
+func (t testShader) SetUniformMat4(s string, m mgl32.Mat4) {
+}
+func (t testShader) DrawTriangles(i int32) {
+}
+func (t testShader) Close(i int) {
+}
+func (t testShader) VertexAttribPointer(i uint32, c int32, s int32, o int) {
+}
+func (t testShader) BindVertexArray() {
+}
+func (t testShader) BindBufferData(d []float32) {
+}
+func (t testShader) SetUniform3f(s string, f1, f2, f3 float32) {
+}
+func (t testShader) SetUniform1f(s string, f1 float32) {
+}
+
+var shader testShader
+
+func testModel() *Model {
+	mesh, _ := LoadOBJ(strings.NewReader(testOBJ))
+	return New(mesh, shader)
+}
+func TestNew(t *testing.T) {
+	model := testModel()
+	if model.position != (mgl32.Vec3{0, 0, 0}) {
+		t.Error("Invalid position")
+	}
+	if model.scale != 1 {
+		t.Error("Invalid scale")
+	}
+	if model.drawMode != DRAW_MODE_COLOR {
+		t.Error("Invalid draw mode")
+	}
+}
+func TestLog(t *testing.T) {
+	model := testModel()
+	if len(model.Log()) < 10 {
+		t.Error("Log too short")
+	}
+}
+func TestSetters(t *testing.T) {
+	model := testModel()
+	position := mgl32.Vec3{1, 2, 3}
+	model.SetPosition(position)
+	if model.GetPosition() != position {
+		t.Error("Position mismatch")
+	}
+	model.SetScale(2)
+	if model.scale != 2 {
+		t.Error("Scale mismatch")
+	}
+	model.SetColor(mgl32.Vec3{1, 0, 0})
+	if model.color != (mgl32.Vec3{1, 0, 0}) {
+		t.Error("Color mismatch")
+	}
+	model.SetAngle(1)
+	model.SetAxis(mgl32.Vec3{0, 1, 0})
+	if model.angle != 1 || model.axis != (mgl32.Vec3{0, 1, 0}) {
+		t.Error("Rotation mismatch")
+	}
+	model.SetMaterial(material.Chrome)
+	if model.material != material.Chrome {
+		t.Error("Material mismatch")
+	}
+}
+func TestDrawMode(t *testing.T) {
+	model := testModel()
+	model.DrawMode(5)
+	if model.GetDrawMode() != DRAW_MODE_COLOR {
+		t.Error("Invalid draw mode should be ignored")
+	}
+	model.DrawMode(DRAW_MODE_NORMAL)
+	if model.GetDrawMode() != DRAW_MODE_NORMAL {
+		t.Error("Draw mode hasn't been updated")
+	}
+	model.Draw()
+	// the first vertex normal is {0,0,1} -> color {0.5,0.5,1}
+	if model.vao.Get()[5] != 1 || model.vao.Get()[3] != 0.5 {
+		t.Error("Invalid normal color")
+	}
+}
+func TestDraw(t *testing.T) {
+	model := testModel()
+	model.DrawMode(DRAW_MODE_LIGHT)
+	model.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+	if len(model.vao.Get()) != model.GetMesh().TriangleCount()*3*6 {
+		t.Error("Invalid vao length")
+	}
+}
+func TestRenderKeys(t *testing.T) {
+	model := testModel()
+	s, m, tex, mesh := model.GetRenderKeys()
+	if s != shader || m != model.material || tex != nil || mesh != model.mesh {
+		t.Error("Invalid render keys")
+	}
+	model.SetPosition(mgl32.Vec3{1, 2, 3})
+	if model.GetTransform().Col(3).Vec3() != (mgl32.Vec3{1, 2, 3}) {
+		t.Error("Invalid transform")
+	}
+	if model.IsTransparent() {
+		t.Error("Model should be opaque")
+	}
+	model.material.SetAlpha(0.5)
+	if !model.IsTransparent() {
+		t.Error("Model with transparent material should be transparent")
+	}
+}
+func TestUpdate(t *testing.T) {
+	model := testModel()
+	model.SetDirection(mgl32.Vec3{1, 0, 0})
+	model.SetSpeed(2)
+	model.Update(3)
+	if model.GetPosition() != (mgl32.Vec3{6, 0, 0}) {
+		t.Error("Position mismatch after update")
+	}
+}
+func TestModelBounds(t *testing.T) {
+	model := testModel()
+	model.SetPosition(mgl32.Vec3{1, 0, 0})
+	model.SetScale(2)
+	box := model.GetBoundingBox()
+	if box.Min != (mgl32.Vec3{1, 0, 0}) || box.Max != (mgl32.Vec3{3, 2, 0}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+	sphere := model.GetBoundingSphere()
+	if sphere.Center != (mgl32.Vec3{2, 1, 0}) || !mgl32.FloatEqual(sphere.Radius, mgl32.Vec3{1, 1, 0}.Len()) {
+		t.Errorf("Invalid bounding sphere '%v'.", sphere)
+	}
+}
+func TestModelLOD(t *testing.T) {
+	model := testModel()
+	low, _ := LoadOBJ(strings.NewReader(testOBJ))
+	low.Vertices = low.Vertices[:3]
+	low.Normals = low.Normals[:3]
+	selector := lod.NewSelector(lod.Level{MinSize: 100}, lod.Level{MinSize: 0})
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("It should panic with wrong number of meshes")
+			}
+		}()
+		model.SetLOD(selector, []*Mesh{low})
+	}()
+	high := model.GetMesh()
+	model.SetLOD(selector, []*Mesh{high, low})
+	model.SelectLOD(lod.View{Position: mgl32.Vec3{0.5, 0.5, 100}, Fov: 90, Height: 600})
+	if model.GetMesh() != low {
+		t.Error("The far model should use the low detail mesh")
+	}
+	model.buildVao()
+	if len(model.vao.Get()) != 3*6 {
+		t.Errorf("Invalid number of items in the vao '%d'.", len(model.vao.Get()))
+	}
+	model.SelectLOD(lod.View{Position: mgl32.Vec3{0.5, 0.5, 1}, Fov: 90, Height: 600})
+	if model.GetMesh() != high {
+		t.Error("The near model should use the high detail mesh")
+	}
+	model.buildVao()
+	if len(model.vao.Get()) != high.TriangleCount()*3*6 {
+		t.Errorf("Invalid number of items in the vao '%d'.", len(model.vao.Get()))
+	}
+}
+func TestModelTriangles(t *testing.T) {
+	model := testModel()
+	model.SetPosition(mgl32.Vec3{0, 0, 1})
+	triangles := model.GetTriangles()
+	if len(triangles) != model.GetMesh().TriangleCount() {
+		t.Fatalf("Invalid number of triangles '%d'.", len(triangles))
+	}
+	if triangles[0][0] != (mgl32.Vec3{0, 0, 1}) || triangles[0][1] != (mgl32.Vec3{1, 0, 1}) {
+		t.Errorf("Invalid transformed triangle '%v'.", triangles[0])
+	}
+}
+
+func TestDrawIDs(t *testing.T) {
+	model := testModel()
+	pickingtest.DrawSingleID(t, model, 3)
+	if model.shader != shader {
+		t.Error("The shader should be restored")
+	}
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// LoadOBJ reads a wavefront obj file. Only the vertex positions ('v'), the
+// normal vectors ('vn') and the faces ('f') are processed, the other
+// statements (texture coordinates, groups, materials) are ignored. The
+// polygons are triangulated as triangle fans.
+func LoadOBJ(r io.Reader) (*Mesh, error) {
+	var positions, normals []mgl32.Vec3
+	mesh := &Mesh{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "v", "vn":
+			v, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", lineNumber, err.Error())
+			}
+			if fields[0] == "v" {
+				positions = append(positions, v)
+			} else {
+				normals = append(normals, v)
+			}
+		case "f":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("Line %d: face needs at least 3 vertices.", lineNumber)
+			}
+			var facePositions, faceNormals []mgl32.Vec3
+			for _, field := range fields[1:] {
+				p, n, err := parseFaceVertex(field, positions, normals)
+				if err != nil {
+					return nil, fmt.Errorf("Line %d: %s", lineNumber, err.Error())
+				}
+				facePositions = append(facePositions, p)
+				if n != nil {
+					faceNormals = append(faceNormals, *n)
+				}
+			}
+			hasNormals := len(faceNormals) == len(facePositions)
+			for i := 1; i < len(facePositions)-1; i++ {
+				var triangleNormals []mgl32.Vec3
+				if hasNormals {
+					triangleNormals = []mgl32.Vec3{faceNormals[0], faceNormals[i], faceNormals[i+1]}
+				}
+				mesh.addTriangle([3]mgl32.Vec3{facePositions[0], facePositions[i], facePositions[i+1]}, triangleNormals)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
+
+// parseVec3 returns the vector from the first 3 fields.
+func parseVec3(fields []string) (mgl32.Vec3, error) {
+	var v mgl32.Vec3
+	if len(fields) < 3 {
+		return v, fmt.Errorf("Vector needs 3 components.")
+	}
+	for i := 0; i < 3; i++ {
+		f, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return v, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+// parseFaceVertex processes the 'v', 'v/vt', 'v//vn', 'v/vt/vn' face vertex
+// formats. The indices start from 1, the negative ones are relative to the
+// end of the current lists.
+func parseFaceVertex(field string, positions, normals []mgl32.Vec3) (mgl32.Vec3, *mgl32.Vec3, error) {
+	parts := strings.Split(field, "/")
+	pi, err := objIndex(parts[0], len(positions))
+	if err != nil {
+		return mgl32.Vec3{}, nil, err
+	}
+	if len(parts) < 3 || parts[2] == "" {
+		return positions[pi], nil, nil
+	}
+	ni, err := objIndex(parts[2], len(normals))
+	if err != nil {
+		return mgl32.Vec3{}, nil, err
+	}
+	return positions[pi], &normals[ni], nil
+}
+func objIndex(value string, length int) (int, error) {
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 {
+		index = length + index
+	} else {
+		index--
+	}
+	if index < 0 || index >= length {
+		return 0, fmt.Errorf("Index '%s' is out of range.", value)
+	}
+	return index, nil
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// LoadSTL reads an ascii or a binary stl file. The binary files could also
+// start with 'solid', so the format is decided based on the size of the
+// content. The stored facet normals are used only if they are not null
+// vectors, otherwise the face normal is calculated.
+func LoadSTL(r io.Reader) (*Mesh, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= stlHeaderSize+4 {
+		count := binary.LittleEndian.Uint32(data[stlHeaderSize:])
+		if len(data) == stlHeaderSize+4+int(count)*stlTriangleSize {
+			return loadBinarySTL(data[stlHeaderSize+4:], int(count)), nil
+		}
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("solid")) {
+		return nil, fmt.Errorf("Invalid stl content.")
+	}
+	return loadASCIISTL(data)
+}
+func loadBinarySTL(data []byte, count int) *Mesh {
+	mesh := &Mesh{}
+	readVec3 := func(offset int) mgl32.Vec3 {
+		var v mgl32.Vec3
+		for i := 0; i < 3; i++ {
+			v[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[offset+i*4:]))
+		}
+		return v
+	}
+	for i := 0; i < count; i++ {
+		offset := i * stlTriangleSize
+		normal := readVec3(offset)
+		points := [3]mgl32.Vec3{readVec3(offset + 12), readVec3(offset + 24), readVec3(offset + 36)}
+		mesh.addTriangle(points, stlNormals(normal))
+	}
+	return mesh
+}
+func loadASCIISTL(data []byte) (*Mesh, error) {
+	mesh := &Mesh{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var normal mgl32.Vec3
+	var points []mgl32.Vec3
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "facet":
+			if len(fields) < 5 || fields[1] != "normal" {
+				return nil, fmt.Errorf("Line %d: invalid facet.", lineNumber)
+			}
+			n, err := parseVec3(fields[2:])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", lineNumber, err.Error())
+			}
+			normal = n
+			points = points[:0]
+		case "vertex":
+			v, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", lineNumber, err.Error())
+			}
+			points = append(points, v)
+		case "endfacet":
+			if len(points) != 3 {
+				return nil, fmt.Errorf("Line %d: facet needs 3 vertices.", lineNumber)
+			}
+			mesh.addTriangle([3]mgl32.Vec3{points[0], points[1], points[2]}, stlNormals(normal))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
+func stlNormals(normal mgl32.Vec3) []mgl32.Vec3 {
+	if normal.Len() == 0 {
+		return nil
+	}
+	return []mgl32.Vec3{normal, normal, normal}
+}
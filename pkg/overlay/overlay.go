@@ -0,0 +1,172 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// The vertex shader passes the screen coordinates through. The depth of
+	// the layers is near the nearest one, so that the graph is drawn on top of the scene.
+	VERTEX_SHADER = `
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec3 vColor;
+out vec3 vSmoothColor;
+void main()
+{
+    gl_Position = vec4(vVertex, 1.0);
+    vSmoothColor = vColor;
+}
+`
+	FRAGMENT_SHADER = `
+#version 410
+in vec3 vSmoothColor;
+out vec4 FragColor;
+void main()
+{
+    FragColor = vec4(vSmoothColor, 1.0);
+}
+`
+	// the height of the gpu time marks and the reference lines in screen coordinates.
+	LINE_WIDTH = float32(0.005)
+
+	// the layers are drawn in this order, the later ones are nearer.
+	layerBackground = 0
+	layerBar        = 1
+	layerMark       = 2
+	layers          = 3
+)
+
+var (
+	BackgroundColor = mgl32.Vec3{0.1, 0.1, 0.1}
+	UpdateColor     = mgl32.Vec3{0.2, 0.8, 0.2}
+	DrawColor       = mgl32.Vec3{0.2, 0.4, 0.9}
+	GPUColor        = mgl32.Vec3{0.9, 0.2, 0.2}
+	ReferenceColor  = mgl32.Vec3{0.9, 0.9, 0.2}
+	// the reference lines of the 60 and 30 fps.
+	ReferenceTimes = []time.Duration{time.Second / 60, time.Second / 30}
+)
+
+type Shader interface {
+	Use()
+	DrawTriangles(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+}
+
+// Graph is a frame time graph. Every frame is a bar, the update (green) and
+// the draw (blue) cpu times are stacked, the gpu time is marked with a red line.
+type Graph struct {
+	shader Shader
+
+	// the bottom left corner and the size of the graph in screen coordinates [-1, 1].
+	position mgl32.Vec2
+	size     mgl32.Vec2
+	// scale is the frame time that belongs to the full height of the graph.
+	scale time.Duration
+
+	vertices []float32
+}
+
+// NewShader returns the shader of the graph. It has to be called after the gl initialization.
+func NewShader() *shader.Shader {
+	return shader.NewShaderFromSource(VERTEX_SHADER, FRAGMENT_SHADER)
+}
+
+// New returns a graph in the bottom left corner of the screen. The full height belongs to 50 ms.
+func New(shader Shader) *Graph {
+	return &Graph{
+		shader:   shader,
+		position: mgl32.Vec2{-1.0, -1.0},
+		size:     mgl32.Vec2{0.6, 0.3},
+		scale:    50 * time.Millisecond,
+	}
+}
+
+// SetPosition updates the bottom left corner of the graph.
+func (g *Graph) SetPosition(position mgl32.Vec2) {
+	g.position = position
+}
+
+// SetSize updates the width and the height of the graph.
+func (g *Graph) SetSize(size mgl32.Vec2) {
+	g.size = size
+}
+
+// SetScale updates the frame time that belongs to the full height of the graph.
+func (g *Graph) SetScale(scale time.Duration) {
+	if scale > 0 {
+		g.scale = scale
+	}
+}
+
+// height returns the height of the given duration on the graph. It's clamped to the size.
+func (g *Graph) height(d time.Duration) float32 {
+	h := float32(d) / float32(g.scale) * g.size.Y()
+	if h > g.size.Y() {
+		return g.size.Y()
+	}
+	return h
+}
+
+// appendRectangle appends the 2 triangles of the given rectangle to the vertices.
+func (g *Graph) appendRectangle(layer int, x, y, width, height float32, color mgl32.Vec3) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	depth := -1 + float32(layers-1-layer)*0.01
+	corners := [6][2]float32{
+		{x, y}, {x + width, y}, {x + width, y + height},
+		{x, y}, {x + width, y + height}, {x, y + height},
+	}
+	for _, c := range corners {
+		g.vertices = append(g.vertices, c[0], c[1], depth, color.X(), color.Y(), color.Z())
+	}
+}
+
+// buildVertices calculates the vertices of the background, the bars and the reference lines.
+func (g *Graph) buildVertices(history []application.FrameStats) {
+	g.vertices = g.vertices[:0]
+	x, y := g.position.X(), g.position.Y()
+	g.appendRectangle(layerBackground, x, y, g.size.X(), g.size.Y(), BackgroundColor)
+	barWidth := g.size.X() / float32(application.STATS_HISTORY)
+	// the latest frame is on the right side.
+	offset := application.STATS_HISTORY - len(history)
+	for i, stats := range history {
+		barX := x + float32(offset+i)*barWidth
+		updateHeight := g.height(stats.Update)
+		g.appendRectangle(layerBar, barX, y, barWidth, updateHeight, UpdateColor)
+		drawHeight := g.height(stats.Update+stats.Draw) - updateHeight
+		g.appendRectangle(layerBar, barX, y+updateHeight, barWidth, drawHeight, DrawColor)
+		if stats.GPU > 0 {
+			g.appendRectangle(layerMark, barX, y+g.height(stats.GPU)-LINE_WIDTH, barWidth, LINE_WIDTH, GPUColor)
+		}
+	}
+	for _, reference := range ReferenceTimes {
+		if reference < g.scale {
+			g.appendRectangle(layerMark, x, y+g.height(reference), g.size.X(), LINE_WIDTH, ReferenceColor)
+		}
+	}
+}
+
+// DrawStats draws the graph of the given frame statistics. It satisfies the
+// application.Overlay interface.
+func (g *Graph) DrawStats(history []application.FrameStats) {
+	g.buildVertices(history)
+	g.shader.Use()
+	g.shader.BindBufferData(g.vertices)
+	g.shader.BindVertexArray()
+	// setup points
+	g.shader.VertexAttribPointer(0, 3, 4*6, 0)
+	// setup color
+	g.shader.VertexAttribPointer(1, 3, 4*6, 4*3)
+	g.shader.DrawTriangles(int32(len(g.vertices) / 6))
+	g.shader.Close(2)
+}
@@ -0,0 +1,79 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+)
+
+type testShader struct {
+	drawnPoints *int32
+}
+
+func (t testShader) Use() {
+}
+func (t testShader) DrawTriangles(i int32) {
+	*t.drawnPoints = i
+}
+func (t testShader) Close(i int) {
+}
+func (t testShader) VertexAttribPointer(i uint32, c int32, s int32, o int) {
+}
+func (t testShader) BindVertexArray() {
+}
+func (t testShader) BindBufferData(d []float32) {
+}
+
+func TestNew(t *testing.T) {
+	g := New(testShader{})
+	if g.scale != 50*time.Millisecond {
+		t.Error("Invalid default scale")
+	}
+	g.SetScale(0)
+	if g.scale != 50*time.Millisecond {
+		t.Error("Invalid scale shouldn't be set")
+	}
+}
+func TestHeight(t *testing.T) {
+	g := New(testShader{})
+	g.SetScale(100 * time.Millisecond)
+	if h := g.height(50 * time.Millisecond); h < 0.149 || h > 0.151 {
+		t.Errorf("Invalid height '%f'.", h)
+	}
+	if h := g.height(time.Second); h != g.size.Y() {
+		t.Error("Height should be clamped")
+	}
+}
+func TestDrawStats(t *testing.T) {
+	var points int32
+	g := New(testShader{drawnPoints: &points})
+	g.DrawStats([]application.FrameStats{})
+	// background and 2 reference lines
+	if points != 3*6 {
+		t.Errorf("Invalid number of points '%d'.", points)
+	}
+	g.DrawStats([]application.FrameStats{
+		{Update: time.Millisecond, Draw: time.Millisecond, GPU: time.Millisecond},
+		{Update: time.Millisecond},
+	})
+	if points != 7*6 {
+		t.Errorf("Invalid number of points '%d'.", points)
+	}
+	// the last bar is on the right side.
+	last := g.vertices[len(g.vertices)-2*6*6-6*6:]
+	right := g.position.X() + g.size.X()
+	if x := last[6]; x < right-0.0001 || x > right+0.0001 {
+		t.Errorf("Invalid position of the last bar '%f'.", x)
+	}
+}
+func TestLayerDepth(t *testing.T) {
+	var points int32
+	g := New(testShader{drawnPoints: &points})
+	g.DrawStats([]application.FrameStats{{Update: time.Millisecond, GPU: time.Millisecond}})
+	background, bar, mark := g.vertices[2], g.vertices[6*6+2], g.vertices[2*6*6+2]
+	// the later layers are nearer, the nearest depth is -1.
+	if !(background > bar && bar > mark && mark >= -1) {
+		t.Errorf("Invalid layer depths '%f', '%f', '%f'.", background, bar, mark)
+	}
+}
@@ -0,0 +1,114 @@
+package picking
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+)
+
+const (
+	ID_VERTEX_SHADER = `
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec3 vColor;
+layout(location = 2) in float vSize;
+uniform mat4 model;
+uniform mat4 view;
+uniform mat4 projection;
+uniform vec3 idColor;
+uniform float vertexColor;
+out vec3 vIdColor;
+void main()
+{
+    gl_Position = projection * view * model * vec4(vVertex, 1.0);
+    gl_PointSize = vSize;
+    vIdColor = vertexColor > 0.5 ? vColor : idColor;
+}
+`
+	ID_FRAGMENT_SHADER = `
+#version 410
+in vec3 vIdColor;
+out vec4 FragColor;
+void main()
+{
+    FragColor = vec4(vIdColor, 1.0);
+}
+`
+	// MAX_ID is the greatest id that could be stored in the rgb channels.
+	MAX_ID = 1<<24 - 1
+)
+
+// IDShader is the interface of the shader of the id pass. The primitives are
+// drawn with it instead of their own shader.
+type IDShader interface {
+	Use()
+	SetUniformMat4(string, mgl32.Mat4)
+	SetUniform3f(string, float32, float32, float32)
+	SetUniform1f(string, float32)
+	DrawTriangles(int32)
+	DrawPoints(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+	HasTexture() bool
+}
+
+// IDDrawable is an item that could be drawn to the id buffer. It uses the
+// IDCount consecutive ids from the first one (eg: one id for every point of
+// a point cloud).
+type IDDrawable interface {
+	IDCount() int
+	DrawIDs(s IDShader, view, projection mgl32.Mat4, first uint32)
+}
+
+// NewIDShader returns the shader of the id pass. It has to be called after
+// the gl initialization.
+func NewIDShader() *shader.Shader {
+	return shader.NewShaderFromSource(ID_VERTEX_SHADER, ID_FRAGMENT_SHADER)
+}
+
+// IDColor returns the color of the id. The 0 id (black) means the empty
+// background, the ids are stored in the lowest 24 bits.
+func IDColor(id uint32) mgl32.Vec3 {
+	return mgl32.Vec3{
+		float32(id&0xff) / 255.0,
+		float32((id>>8)&0xff) / 255.0,
+		float32((id>>16)&0xff) / 255.0,
+	}
+}
+
+// ColorID returns the id of the color of a pixel. It's the inverse of the
+// IDColor function.
+func ColorID(r, g, b uint8) uint32 {
+	return uint32(r) | uint32(g)<<8 | uint32(b)<<16
+}
+
+// SetID sets the uniforms of the id shader for an item with one id. The
+// color of the vertices is ignored.
+func SetID(s IDShader, id uint32) {
+	c := IDColor(id)
+	s.Use()
+	s.SetUniform3f("idColor", c.X(), c.Y(), c.Z())
+	s.SetUniform1f("vertexColor", 0.0)
+}
+
+// SetVertexIDs sets the uniforms of the id shader for an item that stores the
+// id colors in the vertex color attribute.
+func SetVertexIDs(s IDShader) {
+	s.Use()
+	s.SetUniform1f("vertexColor", 1.0)
+}
+
+// LayoutShader is an id shader with the texture flag of the original shader
+// of the item. The item builds the same vertex layout (and uses the same
+// cached vao) as with its own shader.
+type LayoutShader struct {
+	IDShader
+	Textured bool
+}
+
+// HasTexture returns the texture flag of the original shader.
+func (l LayoutShader) HasTexture() bool {
+	return l.Textured
+}
@@ -0,0 +1,77 @@
+package picking
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// shaderMock stores the uniforms of the id shader.
+type shaderMock struct {
+	vectors map[string]mgl32.Vec3
+	floats  map[string]float32
+}
+
+func newShaderMock() *shaderMock {
+	return &shaderMock{vectors: make(map[string]mgl32.Vec3), floats: make(map[string]float32)}
+}
+func (s *shaderMock) Use()                                  {}
+func (s *shaderMock) SetUniformMat4(n string, m mgl32.Mat4) {}
+func (s *shaderMock) SetUniform3f(n string, x, y, z float32) {
+	s.vectors[n] = mgl32.Vec3{x, y, z}
+}
+func (s *shaderMock) SetUniform1f(n string, v float32) {
+	s.floats[n] = v
+}
+func (s *shaderMock) DrawTriangles(i int32)                                  {}
+func (s *shaderMock) DrawPoints(i int32)                                     {}
+func (s *shaderMock) Close(i int)                                            {}
+func (s *shaderMock) VertexAttribPointer(i uint32, c int32, st int32, o int) {}
+func (s *shaderMock) BindVertexArray()                                       {}
+func (s *shaderMock) BindBufferData(d []float32)                             {}
+func (s *shaderMock) HasTexture() bool                                       { return false }
+
+func TestIDColor(t *testing.T) {
+	testData := []struct {
+		id    uint32
+		color mgl32.Vec3
+	}{
+		{0, mgl32.Vec3{0, 0, 0}},
+		{1, mgl32.Vec3{1.0 / 255.0, 0, 0}},
+		{256, mgl32.Vec3{0, 1.0 / 255.0, 0}},
+		{MAX_ID, mgl32.Vec3{1, 1, 1}},
+	}
+	for _, tt := range testData {
+		if c := IDColor(tt.id); c != tt.color {
+			t.Errorf("Invalid color of '%d'. Instead of '%v', we have '%v'.", tt.id, tt.color, c)
+		}
+	}
+	// the color is stored in bytes, the conversion is rounded.
+	for _, id := range []uint32{0, 1, 255, 256, 65535, 123456, MAX_ID} {
+		c := IDColor(id)
+		if result := ColorID(uint8(c.X()*255+0.5), uint8(c.Y()*255+0.5), uint8(c.Z()*255+0.5)); result != id {
+			t.Errorf("Invalid id. Instead of '%d', we have '%d'.", id, result)
+		}
+	}
+}
+func TestSetID(t *testing.T) {
+	s := newShaderMock()
+	SetVertexIDs(s)
+	if s.floats["vertexColor"] != 1.0 {
+		t.Error("The vertex colors should be used")
+	}
+	SetID(s, 2)
+	if s.floats["vertexColor"] != 0.0 || s.vectors["idColor"] != IDColor(2) {
+		t.Errorf("Invalid uniforms '%v', '%v'.", s.floats, s.vectors)
+	}
+}
+func TestLayoutShader(t *testing.T) {
+	var s IDShader = LayoutShader{IDShader: newShaderMock(), Textured: true}
+	if !s.HasTexture() {
+		t.Error("The texture flag should be kept")
+	}
+	s.SetUniform1f("vertexColor", 1.0)
+	if s.(LayoutShader).IDShader.(*shaderMock).floats["vertexColor"] != 1.0 {
+		t.Error("The calls should be passed to the id shader")
+	}
+}
@@ -0,0 +1,191 @@
+package picking
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+)
+
+// epsilon is the tolerance of the parallel ray and triangle.
+const epsilon = 1e-7
+
+// Camera is the interface of the cameras that could build the rays.
+type Camera interface {
+	GetViewMatrix() mgl32.Mat4
+	GetProjectionMatrix() mgl32.Mat4
+}
+
+// Pickable is an item that could be picked. The bounding box is tested
+// first, the triangles (in world coordinates) are tested only if the ray
+// hits the box.
+type Pickable interface {
+	GetBoundingBox() bounds.AABB
+	GetTriangles() [][3]mgl32.Vec3
+}
+
+// Ray is a half line in world coordinates. The direction is a unit vector.
+type Ray struct {
+	Origin    mgl32.Vec3
+	Direction mgl32.Vec3
+}
+
+// NewRay returns the ray of the given point in normalized device coordinates
+// (eg: the output of the MouseCoordinates of the application). The ray starts
+// on the near plane and goes through the far plane.
+func NewRay(x, y float32, view, projection mgl32.Mat4) Ray {
+	inverse := projection.Mul4(view).Inv()
+	near := mgl32.TransformCoordinate(mgl32.Vec3{x, y, -1}, inverse)
+	far := mgl32.TransformCoordinate(mgl32.Vec3{x, y, 1}, inverse)
+	return Ray{Origin: near, Direction: far.Sub(near).Normalize()}
+}
+
+// NewCameraRay returns the ray of the given point in normalized device
+// coordinates with the view and projection matrices of the camera.
+func NewCameraRay(x, y float32, c Camera) Ray {
+	return NewRay(x, y, c.GetViewMatrix(), c.GetProjectionMatrix())
+}
+
+// At returns the point of the ray in the given distance.
+func (r Ray) At(distance float32) mgl32.Vec3 {
+	return r.Origin.Add(r.Direction.Mul(distance))
+}
+
+// IntersectSphere returns the distance of the first intersection point with
+// the sphere. If the origin is inside the sphere, the distance is 0.
+func (r Ray) IntersectSphere(s bounds.Sphere) (float32, bool) {
+	toCenter := s.Center.Sub(r.Origin)
+	projected := toCenter.Dot(r.Direction)
+	d2 := toCenter.Dot(toCenter) - projected*projected
+	r2 := s.Radius * s.Radius
+	if d2 > r2 {
+		return 0, false
+	}
+	half := float32(math.Sqrt(float64(r2 - d2)))
+	if projected+half < 0 {
+		return 0, false
+	}
+	if projected-half < 0 {
+		return 0, true
+	}
+	return projected - half, true
+}
+
+// IntersectAABB returns the distance of the first intersection point with the
+// box (slab method). If the origin is inside the box, the distance is 0.
+func (r Ray) IntersectAABB(b bounds.AABB) (float32, bool) {
+	tMin := float32(0)
+	tMax := float32(math.MaxFloat32)
+	for i := 0; i < 3; i++ {
+		if r.Direction[i] == 0 {
+			if r.Origin[i] < b.Min[i] || r.Origin[i] > b.Max[i] {
+				return 0, false
+			}
+			continue
+		}
+		t1 := (b.Min[i] - r.Origin[i]) / r.Direction[i]
+		t2 := (b.Max[i] - r.Origin[i]) / r.Direction[i]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tMin {
+			tMin = t1
+		}
+		if t2 < tMax {
+			tMax = t2
+		}
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
+
+// IntersectTriangle returns the distance of the intersection point with the
+// triangle (Moller - Trumbore method). Both sides of the triangle are hit.
+func (r Ray) IntersectTriangle(a, b, c mgl32.Vec3) (float32, bool) {
+	edge1 := b.Sub(a)
+	edge2 := c.Sub(a)
+	p := r.Direction.Cross(edge2)
+	det := edge1.Dot(p)
+	if det > -epsilon && det < epsilon {
+		return 0, false
+	}
+	invDet := 1 / det
+	s := r.Origin.Sub(a)
+	u := s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := s.Cross(edge1)
+	v := r.Direction.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t := edge2.Dot(q) * invDet
+	if t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// Hit is the result of the picking. The Normal is the normal vector of the
+// hit triangle, it points to the origin side of the ray.
+type Hit struct {
+	Item     Pickable
+	Point    mgl32.Vec3
+	Normal   mgl32.Vec3
+	Distance float32
+}
+
+// IntersectItem returns the nearest hit of the ray with the triangles of the item.
+func (r Ray) IntersectItem(item Pickable) (Hit, bool) {
+	if _, ok := r.IntersectAABB(item.GetBoundingBox()); !ok {
+		return Hit{}, false
+	}
+	var hit Hit
+	found := false
+	for _, triangle := range item.GetTriangles() {
+		t, ok := r.IntersectTriangle(triangle[0], triangle[1], triangle[2])
+		if !ok || found && t >= hit.Distance {
+			continue
+		}
+		found = true
+		hit.Distance = t
+		hit.Normal = triangle[1].Sub(triangle[0]).Cross(triangle[2].Sub(triangle[0]))
+	}
+	if !found {
+		return Hit{}, false
+	}
+	if hit.Normal.Dot(r.Direction) > 0 {
+		hit.Normal = hit.Normal.Mul(-1)
+	}
+	hit.Normal = hit.Normal.Normalize()
+	hit.Item = item
+	hit.Point = r.At(hit.Distance)
+	return hit, true
+}
+
+// Pick returns the nearest hit of the ray with the items. The items that
+// aren't Pickable are skipped, and the triangles of an item are tested only
+// if its box could be nearer than the current nearest hit.
+func (r Ray) Pick(items []interface{}) (Hit, bool) {
+	var nearest Hit
+	found := false
+	for _, i := range items {
+		item, ok := i.(Pickable)
+		if !ok {
+			continue
+		}
+		boxDistance, ok := r.IntersectAABB(item.GetBoundingBox())
+		if !ok || found && boxDistance >= nearest.Distance {
+			continue
+		}
+		if hit, ok := r.IntersectItem(item); ok && (!found || hit.Distance < nearest.Distance) {
+			nearest = hit
+			found = true
+		}
+	}
+	return nearest, found
+}
@@ -0,0 +1,116 @@
+package picking
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+)
+
+// itemMock is a square in the z plane with the given size around the center.
+type itemMock struct {
+	center mgl32.Vec3
+	size   float32
+}
+
+func (i *itemMock) corners() [4]mgl32.Vec3 {
+	h := i.size / 2
+	return [4]mgl32.Vec3{
+		i.center.Add(mgl32.Vec3{-h, -h, 0}),
+		i.center.Add(mgl32.Vec3{h, -h, 0}),
+		i.center.Add(mgl32.Vec3{h, h, 0}),
+		i.center.Add(mgl32.Vec3{-h, h, 0}),
+	}
+}
+func (i *itemMock) GetBoundingBox() bounds.AABB {
+	c := i.corners()
+	return bounds.NewAABB(c[:]...)
+}
+func (i *itemMock) GetTriangles() [][3]mgl32.Vec3 {
+	c := i.corners()
+	return [][3]mgl32.Vec3{{c[0], c[1], c[2]}, {c[0], c[2], c[3]}}
+}
+
+func near(a, b mgl32.Vec3) bool {
+	return a.Sub(b).Len() < 1e-4
+}
+func TestNewRay(t *testing.T) {
+	// with identity matrices the world is the ndc cube.
+	ray := NewRay(0.5, -0.5, mgl32.Ident4(), mgl32.Ident4())
+	if !near(ray.Origin, mgl32.Vec3{0.5, -0.5, -1}) || !near(ray.Direction, mgl32.Vec3{0, 0, 1}) {
+		t.Errorf("Invalid ray '%v'.", ray)
+	}
+	view := mgl32.LookAtV(mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 1, 0})
+	projection := mgl32.Perspective(mgl32.DegToRad(45), 1, 0.1, 100)
+	ray = NewRay(0, 0, view, projection)
+	if !near(ray.Origin, mgl32.Vec3{0, 0, 4.9}) || !near(ray.Direction, mgl32.Vec3{0, 0, -1}) {
+		t.Errorf("Invalid center ray '%v'.", ray)
+	}
+	right := NewRay(1, 0, view, projection)
+	if right.Direction.X() <= 0 || !near(ray.At(1), mgl32.Vec3{0, 0, 3.9}) {
+		t.Errorf("Invalid right ray '%v'.", right)
+	}
+}
+func TestIntersectVolumes(t *testing.T) {
+	ray := Ray{Origin: mgl32.Vec3{0, 0, 0}, Direction: mgl32.Vec3{0, 0, -1}}
+	if d, ok := ray.IntersectSphere(bounds.Sphere{Center: mgl32.Vec3{0, 0, -5}, Radius: 1}); !ok || !mgl32.FloatEqual(d, 4) {
+		t.Errorf("Invalid sphere hit '%f', '%t'.", d, ok)
+	}
+	if _, ok := ray.IntersectSphere(bounds.Sphere{Center: mgl32.Vec3{0, 0, 5}, Radius: 1}); ok {
+		t.Error("The sphere behind the origin shouldn't be hit")
+	}
+	if _, ok := ray.IntersectSphere(bounds.Sphere{Center: mgl32.Vec3{3, 0, -5}, Radius: 1}); ok {
+		t.Error("The sphere next to the ray shouldn't be hit")
+	}
+	if d, ok := ray.IntersectSphere(bounds.Sphere{Center: mgl32.Vec3{0, 0, 0}, Radius: 1}); !ok || d != 0 {
+		t.Error("The sphere around the origin should be hit in 0 distance")
+	}
+	box := bounds.AABB{Min: mgl32.Vec3{-1, -1, -6}, Max: mgl32.Vec3{1, 1, -4}}
+	if d, ok := ray.IntersectAABB(box); !ok || !mgl32.FloatEqual(d, 4) {
+		t.Errorf("Invalid box hit '%f', '%t'.", d, ok)
+	}
+	if _, ok := (Ray{Origin: mgl32.Vec3{2, 0, 0}, Direction: mgl32.Vec3{0, 0, -1}}).IntersectAABB(box); ok {
+		t.Error("The box next to the ray shouldn't be hit")
+	}
+	if _, ok := (Ray{Origin: mgl32.Vec3{0, 0, -10}, Direction: mgl32.Vec3{0, 0, -1}}).IntersectAABB(box); ok {
+		t.Error("The box behind the ray shouldn't be hit")
+	}
+}
+func TestIntersectTriangle(t *testing.T) {
+	a, b, c := mgl32.Vec3{-1, -1, -2}, mgl32.Vec3{1, -1, -2}, mgl32.Vec3{0, 1, -2}
+	ray := Ray{Origin: mgl32.Vec3{0, 0, 0}, Direction: mgl32.Vec3{0, 0, -1}}
+	if d, ok := ray.IntersectTriangle(a, b, c); !ok || !mgl32.FloatEqual(d, 2) {
+		t.Errorf("Invalid triangle hit '%f', '%t'.", d, ok)
+	}
+	// the back side is also hit.
+	if _, ok := ray.IntersectTriangle(a, c, b); !ok {
+		t.Error("The back side should be hit")
+	}
+	if _, ok := (Ray{Origin: mgl32.Vec3{2, 0, 0}, Direction: mgl32.Vec3{0, 0, -1}}).IntersectTriangle(a, b, c); ok {
+		t.Error("The ray next to the triangle shouldn't hit")
+	}
+	if _, ok := (Ray{Origin: mgl32.Vec3{0, 0, 0}, Direction: mgl32.Vec3{1, 0, 0}}).IntersectTriangle(a, b, c); ok {
+		t.Error("The parallel ray shouldn't hit")
+	}
+}
+func TestPick(t *testing.T) {
+	ray := Ray{Origin: mgl32.Vec3{0, 0, 0}, Direction: mgl32.Vec3{0, 0, -1}}
+	far := &itemMock{center: mgl32.Vec3{0, 0, -10}, size: 4}
+	closest := &itemMock{center: mgl32.Vec3{0, 0, -3}, size: 2}
+	aside := &itemMock{center: mgl32.Vec3{5, 0, -1}, size: 2}
+	hit, ok := ray.Pick([]interface{}{far, "not pickable", closest, aside})
+	if !ok || hit.Item != closest {
+		t.Fatalf("The closest item should be hit, '%v'.", hit)
+	}
+	if !near(hit.Point, mgl32.Vec3{0, 0, -3}) || !mgl32.FloatEqual(hit.Distance, 3) {
+		t.Errorf("Invalid hit point '%v'.", hit.Point)
+	}
+	// the normal points to the origin of the ray.
+	if !near(hit.Normal, mgl32.Vec3{0, 0, 1}) {
+		t.Errorf("Invalid hit normal '%v'.", hit.Normal)
+	}
+	if _, ok := ray.Pick([]interface{}{aside}); ok {
+		t.Error("Nothing should be hit")
+	}
+}
@@ -0,0 +1,90 @@
+package pickingtest
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+)
+
+// IDShader is a picking.IDShader that records the uniforms, the buffer data,
+// the number of the drawn vertices and the enabled vertex attributes of the
+// id pass.
+type IDShader struct {
+	Vectors   map[string]mgl32.Vec3
+	Floats    map[string]float32
+	Data      []float32
+	Triangles int32
+	Points    int32
+	Enabled   map[uint32]bool
+}
+
+// NewIDShader returns an empty recording shader.
+func NewIDShader() *IDShader {
+	return &IDShader{
+		Vectors: make(map[string]mgl32.Vec3),
+		Floats:  make(map[string]float32),
+		Enabled: make(map[uint32]bool),
+	}
+}
+
+// Color returns the last idColor uniform.
+func (s *IDShader) Color() mgl32.Vec3 {
+	return s.Vectors["idColor"]
+}
+func (s *IDShader) Use() {
+}
+func (s *IDShader) SetUniformMat4(n string, m mgl32.Mat4) {
+}
+func (s *IDShader) SetUniform3f(n string, x, y, z float32) {
+	s.Vectors[n] = mgl32.Vec3{x, y, z}
+}
+func (s *IDShader) SetUniform1f(n string, v float32) {
+	s.Floats[n] = v
+}
+func (s *IDShader) DrawTriangles(i int32) {
+	s.Triangles = i
+}
+func (s *IDShader) DrawPoints(i int32) {
+	s.Points = i
+}
+func (s *IDShader) Close(i int) {
+	for index := 0; index < i; index++ {
+		delete(s.Enabled, uint32(index))
+	}
+}
+func (s *IDShader) VertexAttribPointer(i uint32, c int32, st int32, o int) {
+	s.Enabled[i] = true
+}
+func (s *IDShader) BindVertexArray() {
+}
+func (s *IDShader) BindBufferData(d []float32) {
+	s.Data = d
+}
+func (s *IDShader) HasTexture() bool {
+	return false
+}
+
+// DrawSingleID draws the item with a new IDShader from the first id with
+// identity matrices. It reports an error if the item doesn't use exactly one
+// id, it isn't drawn with the color of the first id, or it leaves vertex
+// attributes enabled. The shader is returned for the item specific checks.
+func DrawSingleID(t *testing.T, item picking.IDDrawable, first uint32) *IDShader {
+	t.Helper()
+	s := NewIDShader()
+	item.DrawIDs(s, mgl32.Ident4(), mgl32.Ident4(), first)
+	if item.IDCount() != 1 {
+		t.Errorf("Invalid id count. Instead of '1', we have '%d'.", item.IDCount())
+	}
+	if s.Color() != picking.IDColor(first) {
+		t.Errorf("Invalid id color. Instead of '%v', we have '%v'.", picking.IDColor(first), s.Color())
+	}
+	if len(s.Data) == 0 || s.Triangles == 0 {
+		t.Error("The item hasn't been drawn")
+	}
+	if len(s.Enabled) != 0 {
+		t.Errorf("The vertex attributes should be disabled after the draw. Enabled: '%v'.", s.Enabled)
+	}
+	return s
+}
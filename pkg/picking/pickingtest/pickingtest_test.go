@@ -0,0 +1,38 @@
+package pickingtest
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/picking"
+)
+
+// single is an IDDrawable that draws one triangle with its id.
+type single struct{}
+
+func (s single) IDCount() int {
+	return 1
+}
+func (s single) DrawIDs(ids picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	picking.SetID(ids, first)
+	ids.BindBufferData([]float32{0, 0, 0, 1, 0, 0, 0, 1, 0})
+	ids.DrawTriangles(3)
+}
+
+func TestIDShader(t *testing.T) {
+	var _ picking.IDShader = NewIDShader()
+	s := NewIDShader()
+	s.SetUniform3f("idColor", 1, 0, 0)
+	s.SetUniform1f("vertexColor", 1)
+	s.DrawPoints(2)
+	if s.Color() != (mgl32.Vec3{1, 0, 0}) || s.Floats["vertexColor"] != 1 || s.Points != 2 || s.Triangles != 0 {
+		t.Error("Invalid recorded values")
+	}
+}
+func TestDrawSingleID(t *testing.T) {
+	s := DrawSingleID(t, single{}, 42)
+	if len(s.Data) != 9 || s.Triangles != 3 {
+		t.Error("Invalid recorded draw")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 )
 
@@ -22,13 +23,7 @@ type Camera struct {
 	cameraRightDirection mgl32.Vec3
 	worldUp              mgl32.Vec3
 	// Projection options.
-	projectionOptions struct {
-		fov         float32
-		aspectRatio float32
-
-		far  float32
-		near float32
-	}
+	projectionOptions
 }
 
 // Log returns the string representation of this object.
@@ -40,11 +35,7 @@ func (c *Camera) Log() string {
 	logString += "cameraRightDirection: Vector{" + trans.Vec3ToString(c.cameraRightDirection) + "}\n"
 	logString += "yaw : " + trans.Float32ToString(c.yaw) + "\n"
 	logString += "pitch : " + trans.Float32ToString(c.pitch) + "\n"
-	logString += "ProjectionOptions:\n"
-	logString += " - fov : " + trans.Float32ToString(c.projectionOptions.fov) + "\n"
-	logString += " - aspectRatio : " + trans.Float32ToString(c.projectionOptions.aspectRatio) + "\n"
-	logString += " - far : " + trans.Float32ToString(c.projectionOptions.far) + "\n"
-	logString += " - near : " + trans.Float32ToString(c.projectionOptions.near) + "\n"
+	logString += c.projectionOptions.log()
 	return logString
 }
 
@@ -84,23 +75,6 @@ func (c *Camera) Lift(amount float32) {
 	c.updateVectors()
 }
 
-// SetupProjection sets the projection related variables
-// fov - field of view
-// aspectRation - windowWidth/windowHeight
-// near - near clip plane
-// far - far clip plane
-func (c *Camera) SetupProjection(fov, aspRatio, near, far float32) {
-	c.projectionOptions.fov = fov
-	c.projectionOptions.aspectRatio = aspRatio
-	c.projectionOptions.near = near
-	c.projectionOptions.far = far
-}
-
-// GetProjectionMatrix returns the projectionMatrix of the camera
-func (c *Camera) GetProjectionMatrix() mgl32.Mat4 {
-	return mgl32.Perspective(c.projectionOptions.fov, c.projectionOptions.aspectRatio, c.projectionOptions.near, c.projectionOptions.far)
-}
-
 // GetViewMatrix gets the matrix to transform from world coordinates to
 // this camera's coordinates.
 // GetViewMatrix returns the viewMatrix of the camera
@@ -131,3 +105,23 @@ func (c *Camera) UpdateDirection(amountX, amountY float32) {
 func (c *Camera) GetPosition() mgl32.Vec3 {
 	return c.cameraPosition
 }
+
+// GetWorldUp returns the up direction of the world coordinate system.
+func (c *Camera) GetWorldUp() mgl32.Vec3 {
+	return c.worldUp
+}
+
+// GetYaw returns the yaw angle (in degree) of the camera.
+func (c *Camera) GetYaw() float32 {
+	return c.yaw
+}
+
+// GetPitch returns the pitch angle (in degree) of the camera.
+func (c *Camera) GetPitch() float32 {
+	return c.pitch
+}
+
+// GetFrustum returns the visible volume of the camera in world coordinates.
+func (c *Camera) GetFrustum() bounds.Frustum {
+	return bounds.NewFrustum(c.GetProjectionMatrix().Mul4(c.GetViewMatrix()))
+}
@@ -1,6 +1,7 @@
 package camera
 
 import (
+	"math"
 	"testing"
 
 	"github.com/go-gl/mathgl/mgl32"
@@ -172,3 +173,143 @@ func TestUpdateDirection(t *testing.T) {
 		t.Error("Invalid right direction")
 	}
 }
+func TestGetWorldUp(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	if cam.GetWorldUp() != WorldUp {
+		t.Error("Invalid worldUp")
+	}
+}
+func TestGetYawPitch(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, 10, 20)
+	if cam.GetYaw() != 10 {
+		t.Errorf("Invalid yaw instead of '%f', we have '%f'", float32(10), cam.GetYaw())
+	}
+	if cam.GetPitch() != 20 {
+		t.Errorf("Invalid pitch instead of '%f', we have '%f'", float32(20), cam.GetPitch())
+	}
+}
+func TestGetProjection(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(DefaultFov, DefaultAspRatio, DefaultNear, DefaultFar)
+	fov, aspRatio, near, far := cam.GetProjection()
+	if fov != DefaultFov || aspRatio != DefaultAspRatio || near != DefaultNear || far != DefaultFar {
+		t.Error("Invalid projection options")
+	}
+}
+func TestFov(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(DefaultFov, DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetFov(30)
+	if cam.GetFov() != 30 {
+		t.Errorf("Invalid fov '%f'.", cam.GetFov())
+	}
+	// the fov is in radians.
+	expected := mgl32.Perspective(30, DefaultAspRatio, DefaultNear, DefaultFar)
+	if cam.GetProjectionMatrix() != expected {
+		t.Error("Invalid projection matrix")
+	}
+}
+func TestSetAspectRatio(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(DefaultFov, DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetAspectRatio(2)
+	if _, aspect, _, _ := cam.GetProjection(); aspect != 2 {
+		t.Errorf("Invalid aspect ratio '%f'.", aspect)
+	}
+}
+func TestGetFrustum(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(DefaultFov, DefaultAspRatio, DefaultNear, DefaultFar)
+	frustum := cam.GetFrustum()
+	front := cam.GetPosition().Add(cam.cameraFrontDirection.Mul((DefaultNear + DefaultFar) / 2))
+	if !frustum.ContainsPoint(front) {
+		t.Errorf("The point '%v' in front of the camera should be visible.", front)
+	}
+	behind := cam.GetPosition().Sub(cam.cameraFrontDirection)
+	if frustum.ContainsPoint(behind) {
+		t.Errorf("The point '%v' behind the camera shouldn't be visible.", behind)
+	}
+}
+func TestSetupOrthographic(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupOrthographic(2, 2, DefaultNear, DefaultFar)
+	if !cam.IsOrthographic() || cam.GetProjectionMode() != PROJECTION_ORTHOGRAPHIC || cam.GetOrthoSize() != 2 {
+		t.Error("Invalid orthographic setup")
+	}
+	expected := mgl32.Ortho(-4, 4, -2, 2, DefaultNear, DefaultFar)
+	if cam.GetProjectionMatrix() != expected {
+		t.Errorf("Invalid projection matrix '%v'.", cam.GetProjectionMatrix())
+	}
+	cam.SetOrthoSize(1)
+	if cam.GetProjectionMatrix() != mgl32.Ortho(-2, 2, -1, 1, DefaultNear, DefaultFar) {
+		t.Error("The size should be updated")
+	}
+	cam.SetupProjection(DefaultFov, DefaultAspRatio, DefaultNear, DefaultFar)
+	if cam.GetProjectionMode() != PROJECTION_PERSPECTIVE {
+		t.Error("The SetupProjection should switch to perspective mode")
+	}
+}
+func TestSetProjectionMode(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(mgl32.DegToRad(90), DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetProjectionMode(5, 10)
+	if cam.GetProjectionMode() != PROJECTION_PERSPECTIVE {
+		t.Error("The invalid mode should be ignored")
+	}
+	// the point in distance 10 is projected to the same place in both modes.
+	point := cam.GetPosition().Add(cam.cameraFrontDirection.Mul(10)).Add(cam.cameraUpDirection.Mul(5))
+	ndcY := func() float32 {
+		clip := cam.GetProjectionMatrix().Mul4x1(cam.GetViewMatrix().Mul4x1(point.Vec4(1)))
+		return clip.Y() / clip.W()
+	}
+	perspective := ndcY()
+	// the half height of the perspective view in distance 10 is 10 with 90 degrees.
+	cam.SetProjectionMode(PROJECTION_ORTHOGRAPHIC, 10)
+	if cam.GetProjectionMode() != PROJECTION_ORTHOGRAPHIC || abs(cam.GetOrthoSize()-10) > 1e-4 {
+		t.Errorf("Invalid orthographic size '%f'.", cam.GetOrthoSize())
+	}
+	if orthographic := ndcY(); abs(orthographic-perspective) > 1e-4 || abs(orthographic-0.5) > 1e-4 {
+		t.Errorf("The framing should be preserved. Instead of '%f', we have '%f'.", perspective, orthographic)
+	}
+	cam.SetOrthoSize(20)
+	cam.SetProjectionMode(PROJECTION_PERSPECTIVE, 20)
+	if cam.GetProjectionMode() != PROJECTION_PERSPECTIVE || abs(cam.GetFov()-mgl32.DegToRad(90)) > 1e-3 {
+		t.Errorf("Invalid fov '%f'.", cam.GetFov())
+	}
+}
+func TestOrthographicZoom(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(mgl32.DegToRad(90), DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetProjectionMode(PROJECTION_ORTHOGRAPHIC, 10)
+	// the tangent of the half fov is halved.
+	cam.SetFov(2 * float32(math.Atan(0.5)))
+	if abs(cam.GetOrthoSize()-5) > 1e-4 {
+		t.Errorf("Invalid orthographic size '%f'.", cam.GetOrthoSize())
+	}
+}
+func TestSetLensShift(t *testing.T) {
+	cam := NewCamera(DefaultCameraPosition, WorldUp, DefaultYaw, DefaultPitch)
+	cam.SetupProjection(mgl32.DegToRad(90), DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetLensShift(mgl32.Vec2{1, 0})
+	if cam.GetLensShift() != (mgl32.Vec2{1, 0}) {
+		t.Error("Invalid lens shift")
+	}
+	// the window on the near plane is moved with its half width.
+	expected := mgl32.Frustum(0, 2*DefaultNear, -DefaultNear, DefaultNear, DefaultNear, DefaultFar)
+	for i, v := range cam.GetProjectionMatrix() {
+		if abs(v-expected[i]) > 1e-4 {
+			t.Fatalf("Invalid projection matrix '%v'.", cam.GetProjectionMatrix())
+		}
+	}
+	cam.SetupOrthographic(1, DefaultAspRatio, DefaultNear, DefaultFar)
+	cam.SetLensShift(mgl32.Vec2{0, -1})
+	if cam.GetProjectionMatrix() != mgl32.Ortho(-1, 1, -2, 0, DefaultNear, DefaultFar) {
+		t.Errorf("Invalid orthographic projection matrix '%v'.", cam.GetProjectionMatrix())
+	}
+}
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
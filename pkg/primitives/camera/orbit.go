@@ -0,0 +1,245 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+)
+
+const (
+	// ORBIT_MODE_TURNTABLE rotates around the world up with limited pitch.
+	ORBIT_MODE_TURNTABLE = 0
+	// ORBIT_MODE_ARCBALL rotates freely around the axes of the view.
+	ORBIT_MODE_ARCBALL = 1
+	// ORBIT_ZOOM_STEP is the ratio of the distance change of one zoom unit.
+	ORBIT_ZOOM_STEP = float32(0.1)
+	// the default limits of the pitch in degrees. The camera can't be above
+	// or below the target in turntable mode.
+	DEFAULT_MIN_PITCH = float32(-89)
+	DEFAULT_MAX_PITCH = float32(89)
+	// the default limits of the distance from the target.
+	DEFAULT_MIN_DISTANCE = float32(0.01)
+	DEFAULT_MAX_DISTANCE = float32(math.MaxFloat32)
+)
+
+// Orbit is a camera that looks at a target point from the given distance. It
+// rotates around the target, it could be panned and zoomed. It's for
+// inspecting a single model.
+type Orbit struct {
+	target      mgl32.Vec3
+	distance    float32
+	minDistance float32
+	maxDistance float32
+	worldUp     mgl32.Vec3
+	mode        int
+
+	// the turntable angles in degrees. The yaw rotates around the world up,
+	// the pitch is the elevation of the camera.
+	yaw      float32
+	pitch    float32
+	minPitch float32
+	maxPitch float32
+
+	// orientation is the rotation of the camera in the base frame. The
+	// camera of the identity orientation is on the +z side of the target.
+	orientation mgl32.Quat
+	// base rotates the y axis to the world up.
+	base mgl32.Quat
+
+	// Projection options.
+	projectionOptions
+}
+
+// NewOrbit returns a turntable orbit camera.
+// target - the point that the camera looks at
+// distance - the distance of the camera from the target
+// yaw - the rotation around the world up in degrees
+// pitch - the elevation of the camera in degrees
+// worldUp - the up direction in the world coordinate system
+func NewOrbit(target mgl32.Vec3, distance, yaw, pitch float32, worldUp mgl32.Vec3) *Orbit {
+	o := &Orbit{
+		target:      target,
+		minDistance: DEFAULT_MIN_DISTANCE,
+		maxDistance: DEFAULT_MAX_DISTANCE,
+		worldUp:     worldUp.Normalize(),
+		mode:        ORBIT_MODE_TURNTABLE,
+		yaw:         yaw,
+		minPitch:    DEFAULT_MIN_PITCH,
+		maxPitch:    DEFAULT_MAX_PITCH,
+		base:        mgl32.QuatBetweenVectors(mgl32.Vec3{0, 1, 0}, worldUp.Normalize()),
+	}
+	o.distance = o.clampDistance(distance)
+	o.pitch = o.clampPitch(pitch)
+	o.updateOrientation()
+	return o
+}
+
+// Log returns the string representation of this object.
+func (o *Orbit) Log() string {
+	logString := "target: Vector{" + trans.Vec3ToString(o.target) + "}\n"
+	logString += "position: Vector{" + trans.Vec3ToString(o.GetPosition()) + "}\n"
+	logString += "worldUp: Vector{" + trans.Vec3ToString(o.worldUp) + "}\n"
+	logString += "distance : " + trans.Float32ToString(o.distance) + "\n"
+	logString += "mode : " + trans.IntegerToString(o.mode) + "\n"
+	logString += "yaw : " + trans.Float32ToString(o.yaw) + "\n"
+	logString += "pitch : " + trans.Float32ToString(o.pitch) + "\n"
+	logString += o.projectionOptions.log()
+	return logString
+}
+
+// GetMode returns the ORBIT_MODE_TURNTABLE or the ORBIT_MODE_ARCBALL.
+func (o *Orbit) GetMode() int {
+	return o.mode
+}
+
+// SetMode updates the rotation mode. The turntable mode keeps the direction
+// of the camera, the roll of the arcball rotation is lost. The invalid mode is
+// ignored.
+func (o *Orbit) SetMode(mode int) {
+	if mode != ORBIT_MODE_TURNTABLE && mode != ORBIT_MODE_ARCBALL || mode == o.mode {
+		return
+	}
+	o.mode = mode
+	if mode == ORBIT_MODE_TURNTABLE {
+		direction := o.orientation.Rotate(mgl32.Vec3{0, 0, 1})
+		o.yaw = mgl32.RadToDeg(float32(math.Atan2(float64(direction.X()), float64(direction.Z()))))
+		o.pitch = o.clampPitch(mgl32.RadToDeg(float32(math.Asin(float64(mgl32.Clamp(direction.Y(), -1, 1))))))
+		o.updateOrientation()
+	}
+}
+
+// GetTarget returns the point that the camera looks at.
+func (o *Orbit) GetTarget() mgl32.Vec3 {
+	return o.target
+}
+
+// SetTarget updates the point that the camera looks at.
+func (o *Orbit) SetTarget(target mgl32.Vec3) {
+	o.target = target
+}
+
+// GetDistance returns the distance of the camera from the target.
+func (o *Orbit) GetDistance() float32 {
+	return o.distance
+}
+
+// SetDistance updates the distance of the camera from the target. It's
+// clamped to the distance limits. In orthographic mode the size is scaled
+// with the distance, so that the framing is the same as the perspective one.
+func (o *Orbit) SetDistance(distance float32) {
+	distance = o.clampDistance(distance)
+	if o.IsOrthographic() && o.distance > 0 {
+		o.size *= distance / o.distance
+	}
+	o.distance = distance
+}
+
+// SetDistanceLimits updates the minimum and the maximum distance from the target.
+func (o *Orbit) SetDistanceLimits(min, max float32) {
+	o.minDistance, o.maxDistance = min, max
+	o.SetDistance(o.distance)
+}
+
+// SetPitchLimits updates the limits of the pitch in degrees. They are used in
+// turntable mode.
+func (o *Orbit) SetPitchLimits(min, max float32) {
+	o.minPitch, o.maxPitch = min, max
+	if o.mode == ORBIT_MODE_TURNTABLE {
+		o.pitch = o.clampPitch(o.pitch)
+		o.updateOrientation()
+	}
+}
+
+// GetYaw returns the yaw angle (in degree) of the turntable mode.
+func (o *Orbit) GetYaw() float32 {
+	return o.yaw
+}
+
+// GetPitch returns the pitch angle (in degree) of the turntable mode.
+func (o *Orbit) GetPitch() float32 {
+	return o.pitch
+}
+
+// Walk moves the camera towards the target (dolly). The negative amount moves
+// it back.
+func (o *Orbit) Walk(amount float32) {
+	o.SetDistance(o.distance - amount)
+}
+
+// Zoom changes the distance exponentially, one unit (eg: the scroll offset)
+// is ORBIT_ZOOM_STEP ratio of the distance. The positive amount moves closer.
+func (o *Orbit) Zoom(amount float32) {
+	o.SetDistance(o.distance * float32(math.Pow(float64(1-ORBIT_ZOOM_STEP), float64(amount))))
+}
+
+// Strafe moves the camera and the target to the right (pan).
+func (o *Orbit) Strafe(amount float32) {
+	o.Pan(amount, 0)
+}
+
+// Lift moves the camera and the target up (pan).
+func (o *Orbit) Lift(amount float32) {
+	o.Pan(0, amount)
+}
+
+// Pan moves the camera and the target in the plane of the view. The amounts
+// are in world units, the right and the up directions of the camera.
+func (o *Orbit) Pan(right, up float32) {
+	o.target = o.target.Add(o.rightDirection().Mul(right)).Add(o.upDirection().Mul(up))
+}
+
+// UpdateDirection rotates the camera around the target (in degrees). The
+// rotation follows the drag, so that the positive amountX turns the target
+// to the right. In turntable mode the amountX changes the yaw, the amountY
+// changes the pitch within the limits. In arcball mode the camera rotates
+// around the axes of the view without limits.
+func (o *Orbit) UpdateDirection(amountX, amountY float32) {
+	if o.mode == ORBIT_MODE_ARCBALL {
+		angle := float32(math.Hypot(float64(amountX), float64(amountY)))
+		if angle == 0 {
+			return
+		}
+		axis := mgl32.Vec3{amountY, -amountX, 0}.Normalize()
+		o.orientation = o.orientation.Mul(mgl32.QuatRotate(mgl32.DegToRad(angle), axis)).Normalize()
+		return
+	}
+	o.yaw = float32(math.Mod(float64(o.yaw-amountX), 360))
+	o.pitch = o.clampPitch(o.pitch - amountY)
+	o.updateOrientation()
+}
+
+// GetPosition returns the position of the camera.
+func (o *Orbit) GetPosition() mgl32.Vec3 {
+	return o.target.Add(o.base.Rotate(o.orientation.Rotate(mgl32.Vec3{0, 0, o.distance})))
+}
+
+// GetViewMatrix returns the viewMatrix of the camera.
+func (o *Orbit) GetViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(o.GetPosition(), o.target, o.upDirection())
+}
+
+// GetFrustum returns the visible volume of the camera in world coordinates.
+func (o *Orbit) GetFrustum() bounds.Frustum {
+	return bounds.NewFrustum(o.GetProjectionMatrix().Mul4(o.GetViewMatrix()))
+}
+
+// updateOrientation calculates the orientation from the turntable angles.
+func (o *Orbit) updateOrientation() {
+	o.orientation = mgl32.QuatRotate(mgl32.DegToRad(o.yaw), mgl32.Vec3{0, 1, 0}).Mul(
+		mgl32.QuatRotate(mgl32.DegToRad(-o.pitch), mgl32.Vec3{1, 0, 0}))
+}
+func (o *Orbit) upDirection() mgl32.Vec3 {
+	return o.base.Rotate(o.orientation.Rotate(mgl32.Vec3{0, 1, 0}))
+}
+func (o *Orbit) rightDirection() mgl32.Vec3 {
+	return o.base.Rotate(o.orientation.Rotate(mgl32.Vec3{1, 0, 0}))
+}
+func (o *Orbit) clampDistance(distance float32) float32 {
+	return mgl32.Clamp(distance, o.minDistance, o.maxDistance)
+}
+func (o *Orbit) clampPitch(pitch float32) float32 {
+	return mgl32.Clamp(pitch, o.minPitch, o.maxPitch)
+}
@@ -0,0 +1,133 @@
+package camera
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func vecEqual(a, b mgl32.Vec3) bool {
+	return a.Sub(b).Len() < 1e-4
+}
+func TestNewOrbit(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{1, 0, 0}, 10, 0, 0, WorldUp)
+	if !vecEqual(orbit.GetPosition(), mgl32.Vec3{1, 0, 10}) {
+		t.Errorf("Invalid position '%v'.", orbit.GetPosition())
+	}
+	if orbit.GetMode() != ORBIT_MODE_TURNTABLE || orbit.GetTarget() != (mgl32.Vec3{1, 0, 0}) || orbit.GetDistance() != 10 {
+		t.Error("Invalid orbit setup")
+	}
+	// the pitch is limited.
+	orbit = NewOrbit(mgl32.Vec3{}, 10, 0, 120, WorldUp)
+	if orbit.GetPitch() != DEFAULT_MAX_PITCH {
+		t.Errorf("Invalid pitch '%f'.", orbit.GetPitch())
+	}
+	// the z axis is the up direction.
+	orbit = NewOrbit(mgl32.Vec3{}, 10, 0, 30, mgl32.Vec3{0, 0, 1})
+	if !mgl32.FloatEqualThreshold(orbit.GetPosition().Z(), 5, 1e-4) {
+		t.Errorf("Invalid position '%v'.", orbit.GetPosition())
+	}
+	if len(orbit.Log()) < 10 {
+		t.Error("Log too short")
+	}
+}
+func TestOrbitViewMatrix(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{1, 2, 3}, 5, 40, 20, WorldUp)
+	// the target is in front of the camera in the given distance.
+	target := orbit.GetViewMatrix().Mul4x1(mgl32.Vec3{1, 2, 3}.Vec4(1))
+	if !vecEqual(target.Vec3(), mgl32.Vec3{0, 0, -5}) {
+		t.Errorf("Invalid target in view space '%v'.", target)
+	}
+}
+func TestOrbitTurntable(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{}, 10, 0, 0, WorldUp)
+	// the target turns right, so that the camera goes to the left.
+	orbit.UpdateDirection(90, 0)
+	if !vecEqual(orbit.GetPosition(), mgl32.Vec3{-10, 0, 0}) {
+		t.Errorf("Invalid position '%v'.", orbit.GetPosition())
+	}
+	orbit.UpdateDirection(0, -30)
+	if orbit.GetPitch() != 30 || !mgl32.FloatEqualThreshold(orbit.GetPosition().Y(), 5, 1e-4) {
+		t.Errorf("Invalid pitch '%f', position '%v'.", orbit.GetPitch(), orbit.GetPosition())
+	}
+	orbit.SetPitchLimits(-10, 10)
+	if orbit.GetPitch() != 10 {
+		t.Errorf("The pitch should be clamped. '%f'.", orbit.GetPitch())
+	}
+	orbit.UpdateDirection(0, 100)
+	if orbit.GetPitch() != -10 {
+		t.Errorf("The pitch should be limited. '%f'.", orbit.GetPitch())
+	}
+}
+func TestOrbitArcball(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{}, 10, 0, 0, WorldUp)
+	orbit.SetMode(5)
+	if orbit.GetMode() != ORBIT_MODE_TURNTABLE {
+		t.Error("The invalid mode should be ignored")
+	}
+	orbit.SetMode(ORBIT_MODE_ARCBALL)
+	// the same as the turntable around the up direction of the view.
+	orbit.UpdateDirection(90, 0)
+	if !vecEqual(orbit.GetPosition(), mgl32.Vec3{-10, 0, 0}) {
+		t.Errorf("Invalid position '%v'.", orbit.GetPosition())
+	}
+	// there aren't pitch limits, the camera could go over the target.
+	orbit.UpdateDirection(0, -180)
+	if !vecEqual(orbit.GetPosition(), mgl32.Vec3{10, 0, 0}) || !vecEqual(orbit.upDirection(), mgl32.Vec3{0, -1, 0}) {
+		t.Errorf("Invalid position '%v', up '%v'.", orbit.GetPosition(), orbit.upDirection())
+	}
+	// the direction is kept in turntable mode.
+	orbit.SetMode(ORBIT_MODE_TURNTABLE)
+	if !vecEqual(orbit.GetPosition(), mgl32.Vec3{10, 0, 0}) || !vecEqual(orbit.upDirection(), WorldUp) {
+		t.Errorf("Invalid position '%v', up '%v'.", orbit.GetPosition(), orbit.upDirection())
+	}
+}
+func TestOrbitDistance(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{}, 10, 0, 0, WorldUp)
+	orbit.Walk(2)
+	if orbit.GetDistance() != 8 {
+		t.Errorf("Invalid distance '%f'.", orbit.GetDistance())
+	}
+	orbit.Zoom(1)
+	if !mgl32.FloatEqual(orbit.GetDistance(), 8*(1-ORBIT_ZOOM_STEP)) {
+		t.Errorf("Invalid zoom distance '%f'.", orbit.GetDistance())
+	}
+	orbit.SetDistanceLimits(2, 5)
+	if orbit.GetDistance() != 5 {
+		t.Errorf("The distance should be clamped. '%f'.", orbit.GetDistance())
+	}
+	orbit.Walk(10)
+	if orbit.GetDistance() != 2 {
+		t.Errorf("The distance should be limited. '%f'.", orbit.GetDistance())
+	}
+	// the orthographic size follows the distance.
+	orbit.SetupProjection(mgl32.DegToRad(90), 1, 0.1, 100)
+	orbit.SetProjectionMode(PROJECTION_ORTHOGRAPHIC, orbit.GetDistance())
+	orbit.SetDistance(4)
+	if !mgl32.FloatEqualThreshold(orbit.GetOrthoSize(), 4, 1e-4) {
+		t.Errorf("Invalid orthographic size '%f'.", orbit.GetOrthoSize())
+	}
+}
+func TestOrbitPan(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{}, 10, 0, 0, WorldUp)
+	orbit.Strafe(1)
+	orbit.Lift(2)
+	if !vecEqual(orbit.GetTarget(), mgl32.Vec3{1, 2, 0}) || !vecEqual(orbit.GetPosition(), mgl32.Vec3{1, 2, 10}) {
+		t.Errorf("Invalid target '%v', position '%v'.", orbit.GetTarget(), orbit.GetPosition())
+	}
+	orbit.SetTarget(mgl32.Vec3{})
+	if orbit.GetTarget() != (mgl32.Vec3{}) {
+		t.Error("Invalid target")
+	}
+}
+func TestOrbitFrustum(t *testing.T) {
+	orbit := NewOrbit(mgl32.Vec3{}, 10, 0, 0, WorldUp)
+	orbit.SetupProjection(45, 1, 0.1, 100)
+	frustum := orbit.GetFrustum()
+	if !frustum.ContainsPoint(orbit.GetTarget()) {
+		t.Error("The target should be visible")
+	}
+	if frustum.ContainsPoint(mgl32.Vec3{0, 0, 20}) {
+		t.Error("The point behind the camera shouldn't be visible")
+	}
+}
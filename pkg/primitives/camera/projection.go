@@ -0,0 +1,178 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+)
+
+const (
+	PROJECTION_PERSPECTIVE  = 0
+	PROJECTION_ORTHOGRAPHIC = 1
+)
+
+// projectionOptions stores the projection of the cameras. The cameras embed
+// it, so that they share the projection functions.
+type projectionOptions struct {
+	fov         float32
+	aspectRatio float32
+
+	far  float32
+	near float32
+	// mode is the PROJECTION_PERSPECTIVE or the PROJECTION_ORTHOGRAPHIC.
+	mode int
+	// size is the half height of the orthographic view volume.
+	size float32
+	// shift is the offset of the off-axis frustum in the half width and
+	// the half height of the view.
+	shift mgl32.Vec2
+}
+
+// log returns the string representation of the projection.
+func (p *projectionOptions) log() string {
+	logString := "ProjectionOptions:\n"
+	logString += " - fov : " + trans.Float32ToString(p.fov) + "\n"
+	logString += " - aspectRatio : " + trans.Float32ToString(p.aspectRatio) + "\n"
+	logString += " - far : " + trans.Float32ToString(p.far) + "\n"
+	logString += " - near : " + trans.Float32ToString(p.near) + "\n"
+	logString += " - mode : " + trans.IntegerToString(p.mode) + "\n"
+	logString += " - size : " + trans.Float32ToString(p.size) + "\n"
+	logString += " - shift : Vector{" + trans.Float32ToString(p.shift.X()) + ", " + trans.Float32ToString(p.shift.Y()) + "}\n"
+	return logString
+}
+
+// SetupProjection sets the projection related variables
+// fov - vertical field of view in radians
+// aspectRation - windowWidth/windowHeight
+// near - near clip plane
+// far - far clip plane
+// It switches to perspective mode.
+func (p *projectionOptions) SetupProjection(fov, aspRatio, near, far float32) {
+	p.mode = PROJECTION_PERSPECTIVE
+	p.fov = fov
+	p.aspectRatio = aspRatio
+	p.near = near
+	p.far = far
+}
+
+// SetupOrthographic sets the orthographic projection.
+// size - the half height of the view volume
+// aspectRation - windowWidth/windowHeight
+// near - near clip plane
+// far - far clip plane
+func (p *projectionOptions) SetupOrthographic(size, aspRatio, near, far float32) {
+	p.mode = PROJECTION_ORTHOGRAPHIC
+	p.size = size
+	p.aspectRatio = aspRatio
+	p.near = near
+	p.far = far
+}
+
+// GetFov returns the vertical field of view in radians.
+func (p *projectionOptions) GetFov() float32 {
+	return p.fov
+}
+
+// SetFov updates the vertical field of view. It's in radians. In orthographic
+// mode the size is scaled with the same ratio as the perspective view at any
+// distance, so that the fov based zoom works in both modes.
+func (p *projectionOptions) SetFov(fov float32) {
+	if p.mode == PROJECTION_ORTHOGRAPHIC && p.fov > 0 && fov > 0 {
+		p.size *= halfTan(fov) / halfTan(p.fov)
+	}
+	p.fov = fov
+}
+
+// GetOrthoSize returns the half height of the orthographic view volume.
+func (p *projectionOptions) GetOrthoSize() float32 {
+	return p.size
+}
+
+// SetOrthoSize updates the half height of the orthographic view volume. The
+// smaller size means bigger zoom.
+func (p *projectionOptions) SetOrthoSize(size float32) {
+	p.size = size
+}
+
+// GetProjectionMode returns the PROJECTION_PERSPECTIVE or the PROJECTION_ORTHOGRAPHIC.
+func (p *projectionOptions) GetProjectionMode() int {
+	return p.mode
+}
+
+// IsOrthographic returns true in orthographic mode.
+func (p *projectionOptions) IsOrthographic() bool {
+	return p.mode == PROJECTION_ORTHOGRAPHIC
+}
+
+// SetProjectionMode switches between the perspective and the orthographic
+// projection. The framing of the plane in the given distance from the camera
+// (eg: the distance of the target) is preserved: the size is calculated from
+// the fov or the fov from the size. The invalid mode is ignored.
+func (p *projectionOptions) SetProjectionMode(mode int, distance float32) {
+	if mode != PROJECTION_PERSPECTIVE && mode != PROJECTION_ORTHOGRAPHIC || mode == p.mode {
+		return
+	}
+	p.mode = mode
+	if distance <= 0 {
+		return
+	}
+	if mode == PROJECTION_ORTHOGRAPHIC {
+		p.size = distance * halfTan(p.fov)
+	} else {
+		p.fov = 2 * float32(math.Atan(float64(p.size/distance)))
+	}
+}
+
+// GetLensShift returns the offset of the off-axis frustum.
+func (p *projectionOptions) GetLensShift() mgl32.Vec2 {
+	return p.shift
+}
+
+// SetLensShift moves the view window without rotating the camera, so that the
+// frustum becomes asymmetric (eg: stereo or tiled rendering). The offset is in
+// the half width and the half height of the view, {1, 0} moves the window
+// with its half width to the right.
+func (p *projectionOptions) SetLensShift(shift mgl32.Vec2) {
+	p.shift = shift
+}
+
+// halfTan returns the tangent of the half of the angle (in radians).
+func halfTan(angle float32) float32 {
+	return float32(math.Tan(float64(angle) / 2))
+}
+
+// SetAspectRatio updates the aspect ratio (width / height) of the projection.
+// It's called when the window is resized.
+func (p *projectionOptions) SetAspectRatio(aspRatio float32) {
+	p.aspectRatio = aspRatio
+}
+
+// GetProjectionMatrix returns the projectionMatrix of the camera. It's
+// perspective or orthographic based on the mode, the lens shift makes the
+// frustum asymmetric.
+func (p *projectionOptions) GetProjectionMatrix() mgl32.Mat4 {
+	o := *p
+	if o.mode == PROJECTION_PERSPECTIVE && o.shift.Len() == 0 {
+		return mgl32.Perspective(o.fov, o.aspectRatio, o.near, o.far)
+	}
+	// the half height of the window on the near plane or of the view volume.
+	height := o.near * halfTan(o.fov)
+	if o.mode == PROJECTION_ORTHOGRAPHIC {
+		height = o.size
+	}
+	width := height * o.aspectRatio
+	left, right := width*(o.shift.X()-1), width*(o.shift.X()+1)
+	bottom, top := height*(o.shift.Y()-1), height*(o.shift.Y()+1)
+	if o.mode == PROJECTION_ORTHOGRAPHIC {
+		return mgl32.Ortho(left, right, bottom, top, o.near, o.far)
+	}
+	return mgl32.Frustum(left, right, bottom, top, o.near, o.far)
+}
+
+// GetProjection returns the projection related variables in the same order as
+// the SetupProjection function gets them: fov, aspect ratio, near, far.
+func (p *projectionOptions) GetProjection() (float32, float32, float32, float32) {
+	return p.fov, p.aspectRatio, p.near, p.far
+}
@@ -3,9 +3,12 @@ package cuboid
 import (
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	sh "github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/vao"
 )
 
@@ -125,6 +128,11 @@ func (c *Cuboid) SetMaterial(mat *material.Material) {
 	c.material = mat
 }
 
+// GetMaterial returns the material of the cuboid.
+func (c *Cuboid) GetMaterial() *material.Material {
+	return c.material
+}
+
 // SetColor updates every color with the given one.
 func (c *Cuboid) SetColor(color mgl32.Vec3) {
 	for i := 0; i < 6; i++ {
@@ -185,11 +193,32 @@ func (c *Cuboid) SetAxis(axis mgl32.Vec3) {
 	c.axis = axis
 }
 
+// GetAngle returns the rotation angle of the cuboid.
+func (c *Cuboid) GetAngle() float32 {
+	return c.angle
+}
+
+// GetAxis returns the rotation axis of the cuboid.
+func (c *Cuboid) GetAxis() mgl32.Vec3 {
+	return c.axis
+}
+
+// Coordinates returns the points of the bottom side. It's the same side that
+// was the input of the New function.
+func (c *Cuboid) Coordinates() [4]mgl32.Vec3 {
+	return c.sides[0].Coordinates()
+}
+
 // GetDirection returns the direction of the cuboid, aka the direction of the first side.
 func (c *Cuboid) GetDirection() mgl32.Vec3 {
 	return c.sides[0].GetDirection()
 }
 
+// GetSpeed returns the speed of the cuboid, aka the speed of the first side.
+func (c *Cuboid) GetSpeed() float32 {
+	return c.sides[0].GetSpeed()
+}
+
 // GetCenterPoint return the center point of the cuboid.
 // In other words it returns the cross point of the diagonals
 func (c *Cuboid) GetCenterPoint() mgl32.Vec3 {
@@ -245,12 +274,12 @@ func (c *Cuboid) Draw() {
 func (c *Cuboid) drawWithTextures() {
 	c.buildVaoWithTexture()
 	c.shader.DrawTriangles(int32(len(c.vao.Get()) / 8))
-	c.shader.Close(2)
+	c.shader.Close(3)
 }
 func (c *Cuboid) drawWithoutTextures() {
 	c.buildVaoWithoutTexture()
 	c.shader.DrawTriangles(int32(len(c.vao.Get()) / 6))
-	c.shader.Close(1)
+	c.shader.Close(2)
 }
 
 func (c *Cuboid) modelTransformation() mgl32.Mat4 {
@@ -266,9 +295,11 @@ func (c *Cuboid) setupColorUniform() {
 		c.shader.SetUniform3f("material.ambient", ambient.X(), ambient.Y(), ambient.Z())
 		c.shader.SetUniform3f("material.specular", specular.X(), specular.Y(), specular.Z())
 		c.shader.SetUniform1f("material.shininess", shininess)
+		c.shader.SetUniform1f("material.alpha", c.material.GetAlpha())
 	} else if c.drawMode == DRAW_MODE_TEXTURED_LIGHT {
 		shininess := c.material.GetShininess()
 		c.shader.SetUniform1f("material.shininess", shininess)
+		c.shader.SetUniform1f("material.alpha", c.material.GetAlpha())
 	}
 }
 
@@ -289,6 +320,25 @@ func (c *Cuboid) DrawWithUniforms(view, projection mgl32.Mat4) {
 	}
 }
 
+// GetRenderKeys returns the shader, the material and the texture set of the
+// shader for the render queue.
+func (c *Cuboid) GetRenderKeys() (interface{}, interface{}, interface{}, interface{}) {
+	return c.shader, c.material, sh.TextureKeyOf(c.shader), nil
+}
+
+// GetTransform returns the model transformation of the center point. The
+// points of the sides are in world coordinates, so that the translation of
+// the transformation is the rotated center point.
+func (c *Cuboid) GetTransform() mgl32.Mat4 {
+	center := c.GetCenterPoint()
+	return c.modelTransformation().Mul4(mgl32.Translate3D(center.X(), center.Y(), center.Z()))
+}
+
+// IsTransparent returns true if the material of the cuboid is transparent.
+func (c *Cuboid) IsTransparent() bool {
+	return c.material.IsTransparent()
+}
+
 // Update
 func (c *Cuboid) Update(dt float64) {
 	for i := 0; i < 6; i++ {
@@ -306,3 +356,50 @@ func (c *Cuboid) DrawMode(mode int) {
 		c.sides[i].DrawMode(mode)
 	}
 }
+
+// GetBoundingBox returns the axis aligned bounding box of the rotated cuboid.
+// The bottom and the top sides contain every corner.
+func (c *Cuboid) GetBoundingBox() bounds.AABB {
+	M := c.modelTransformation()
+	var points []mgl32.Vec3
+	for _, side := range c.sides[:2] {
+		for _, p := range side.Coordinates() {
+			points = append(points, mgl32.TransformCoordinate(p, M))
+		}
+	}
+	return bounds.NewAABB(points...)
+}
+
+// GetBoundingSphere returns the bounding sphere of the rotated cuboid.
+func (c *Cuboid) GetBoundingSphere() bounds.Sphere {
+	return c.GetBoundingBox().BoundingSphere()
+}
+
+// GetTriangles returns the triangles of the sides of the rotated cuboid.
+func (c *Cuboid) GetTriangles() [][3]mgl32.Vec3 {
+	M := c.modelTransformation()
+	var triangles [][3]mgl32.Vec3
+	for _, side := range c.sides {
+		var p [4]mgl32.Vec3
+		for i, point := range side.Coordinates() {
+			p[i] = mgl32.TransformCoordinate(point, M)
+		}
+		triangles = append(triangles, [3]mgl32.Vec3{p[0], p[1], p[2]}, [3]mgl32.Vec3{p[0], p[2], p[3]})
+	}
+	return triangles
+}
+
+// IDCount returns the number of the ids of the cuboid. It's 1.
+func (c *Cuboid) IDCount() int {
+	return 1
+}
+
+// DrawIDs draws the cuboid with the id shader and the color of the first id.
+// The vertex layout of the original shader is kept.
+func (c *Cuboid) DrawIDs(idShader picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	picking.SetID(idShader, first)
+	original := c.shader
+	c.shader = picking.LayoutShader{IDShader: idShader, Textured: original.HasTexture()}
+	c.DrawWithUniforms(view, projection)
+	c.shader = original
+}
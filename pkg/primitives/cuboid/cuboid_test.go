@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/picking/pickingtest"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
 )
@@ -400,3 +401,116 @@ func TestGetCenterPoint(t *testing.T) {
 		t.Log(expectedCenterPoint)
 	}
 }
+func TestGetAngle(t *testing.T) {
+	shader.HasTextureValue = false
+	bottom := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(bottom, 1, shader)
+	cube.SetAngle(float32(1.0))
+	if cube.GetAngle() != float32(1.0) {
+		t.Error("Invalid angle")
+	}
+}
+func TestGetAxis(t *testing.T) {
+	shader.HasTextureValue = false
+	bottom := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(bottom, 1, shader)
+	axis := mgl32.Vec3{0, 1, 0}
+	cube.SetAxis(axis)
+	if cube.GetAxis() != axis {
+		t.Error("Invalid axis")
+	}
+}
+func TestCoordinates(t *testing.T) {
+	shader.HasTextureValue = false
+	bottom := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(bottom, 1, shader)
+	if cube.Coordinates() != DefaultCoordinates {
+		t.Error("Invalid bottom coordinates")
+	}
+}
+func TestCuboidBounds(t *testing.T) {
+	bottom := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(bottom, 1, shader)
+	box := cube.GetBoundingBox()
+	if box.Min != (mgl32.Vec3{0, 0, -1}) || box.Max != (mgl32.Vec3{1, 1, 0}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+	sphere := cube.GetBoundingSphere()
+	if sphere.Center != (mgl32.Vec3{0.5, 0.5, -0.5}) || !mgl32.FloatEqual(sphere.Radius, mgl32.Vec3{0.5, 0.5, 0.5}.Len()) {
+		t.Errorf("Invalid bounding sphere '%v'.", sphere)
+	}
+}
+func TestCuboidTriangles(t *testing.T) {
+	bottom := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(bottom, 1, shader)
+	triangles := cube.GetTriangles()
+	if len(triangles) != 12 {
+		t.Fatalf("Invalid number of triangles '%d'.", len(triangles))
+	}
+	for _, triangle := range triangles {
+		for _, p := range triangle {
+			if p.X() != 0 && p.X() != 1 || p.Y() != 0 && p.Y() != 1 || p.Z() != 0 && p.Z() != -1 {
+				t.Errorf("The point '%v' isn't a corner.", p)
+			}
+		}
+	}
+}
+
+func TestDrawIDs(t *testing.T) {
+	givenSide := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(givenSide, 1.0, shader)
+	ids := pickingtest.DrawSingleID(t, cube, 3)
+	if len(ids.Data) != 36*6 {
+		t.Errorf("Invalid vao length '%d'.", len(ids.Data))
+	}
+	if cube.shader != shader {
+		t.Error("The shader should be restored")
+	}
+}
+func TestRenderKeys(t *testing.T) {
+	givenSide := rectangle.New(DefaultCoordinates, DefaultColors, shader)
+	cube := New(givenSide, 1.0, shader)
+	s, m, tex, mesh := cube.GetRenderKeys()
+	if s != shader || m != cube.material || tex != nil || mesh != nil {
+		t.Error("Invalid render keys")
+	}
+	cube.SetAxis(mgl32.Vec3{0, 1, 0})
+	if cube.GetTransform().Col(3).Vec3() != cube.GetCenterPoint() {
+		t.Error("Invalid transform")
+	}
+	if cube.IsTransparent() {
+		t.Error("Cuboid should be opaque")
+	}
+	glass := material.New(DefaultColors[0], DefaultColors[0], DefaultColors[0], 36.0)
+	glass.SetAlpha(0.5)
+	cube.SetMaterial(glass)
+	if !cube.IsTransparent() {
+		t.Error("Cuboid with transparent material should be transparent")
+	}
+}
+
+// uniformShader stores the float uniforms.
+type uniformShader struct {
+	testShader
+	floats map[string]float32
+}
+
+func (s *uniformShader) SetUniform1f(n string, v float32) {
+	s.floats[n] = v
+}
+func TestAlpha(t *testing.T) {
+	us := &uniformShader{floats: make(map[string]float32)}
+	givenSide := rectangle.New(DefaultCoordinates, DefaultColors, us)
+	cube := New(givenSide, 1.0, us)
+	glass := material.New(DefaultColors[0], DefaultColors[0], DefaultColors[0], 36.0)
+	glass.SetAlpha(0.5)
+	cube.SetMaterial(glass)
+	for _, mode := range []int{DRAW_MODE_LIGHT, DRAW_MODE_TEXTURED_LIGHT} {
+		us.floats = make(map[string]float32)
+		cube.DrawMode(mode)
+		cube.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+		if alpha, ok := us.floats["material.alpha"]; !ok || alpha != 0.5 {
+			t.Errorf("Invalid material.alpha in draw mode '%d'.", mode)
+		}
+	}
+}
@@ -136,3 +136,48 @@ func (l *Light) GetCutoff() float32 {
 func (l *Light) GetOuterCutoff() float32 {
 	return l.outerCutoff
 }
+
+// SetAmbient updates the ambient component of the light
+func (l *Light) SetAmbient(ambient mgl32.Vec3) {
+	l.ambient = ambient
+}
+
+// SetDiffuse updates the diffuse component of the light
+func (l *Light) SetDiffuse(diffuse mgl32.Vec3) {
+	l.diffuse = diffuse
+}
+
+// SetSpecular updates the specular component of the light
+func (l *Light) SetSpecular(specular mgl32.Vec3) {
+	l.specular = specular
+}
+
+// SetConstantTerm updates the constant term component of the light
+func (l *Light) SetConstantTerm(term float32) {
+	l.constantTerm = term
+}
+
+// SetLinearTerm updates the linear term component of the light
+func (l *Light) SetLinearTerm(term float32) {
+	l.linearTerm = term
+}
+
+// SetQuadraticTerm updates the quadratic term component of the light
+func (l *Light) SetQuadraticTerm(term float32) {
+	l.quadraticTerm = term
+}
+
+// SetDirection updates the direction of the light
+func (l *Light) SetDirection(direction mgl32.Vec3) {
+	l.direction = direction
+}
+
+// SetCutoff updates the cutoff component of the light
+func (l *Light) SetCutoff(cutoff float32) {
+	l.cutoff = cutoff
+}
+
+// SetOuterCutoff updates the outerCutoff component of the light
+func (l *Light) SetOuterCutoff(outerCutoff float32) {
+	l.outerCutoff = outerCutoff
+}
@@ -205,3 +205,25 @@ func TestNewSpotLight(t *testing.T) {
 		t.Errorf("Invalid couterCutoff component. Instead of '%f', We have '%f'.", DefaultOuterCutoff, l.outerCutoff)
 	}
 }
+func TestSetters(t *testing.T) {
+	vectorComponent := [4]mgl32.Vec3{DefaultLightPosition, DefaultAmbientComponent, DefaultDiffuseComponent, DefaultSpecularComponent}
+	l := NewPointLight(vectorComponent, [3]float32{DefaultConstantTerm, DefaultLinearTerm, DefaultQuadraticTerm})
+	l.SetAmbient(DefaultSpecularComponent)
+	l.SetDiffuse(DefaultAmbientComponent)
+	l.SetSpecular(DefaultDiffuseComponent)
+	if l.GetAmbient() != DefaultSpecularComponent || l.GetDiffuse() != DefaultAmbientComponent || l.GetSpecular() != DefaultDiffuseComponent {
+		t.Error("Invalid color components")
+	}
+	l.SetConstantTerm(2)
+	l.SetLinearTerm(0.1)
+	l.SetQuadraticTerm(0.01)
+	if l.GetConstantTerm() != 2 || l.GetLinearTerm() != 0.1 || l.GetQuadraticTerm() != 0.01 {
+		t.Error("Invalid terms")
+	}
+	l.SetDirection(DefaultLightDirection)
+	l.SetCutoff(DefaultCutoff)
+	l.SetOuterCutoff(DefaultOuterCutoff)
+	if l.GetDirection() != DefaultLightDirection || l.GetCutoff() != DefaultCutoff || l.GetOuterCutoff() != DefaultOuterCutoff {
+		t.Error("Invalid spot light components")
+	}
+}
@@ -1,6 +1,8 @@
 package material
 
 import (
+	"strings"
+
 	"github.com/go-gl/mathgl/mgl32"
 
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
@@ -11,6 +13,8 @@ type Material struct {
 	diffuse   mgl32.Vec3
 	specular  mgl32.Vec3
 	shininess float32
+	// transparency is 1 - alpha, so that the zero value is opaque.
+	transparency float32
 }
 
 func New(ambient, diffuse, specular mgl32.Vec3, shininess float32) *Material {
@@ -28,6 +32,7 @@ func (m *Material) Log() string {
 	logString += " - Diffuse: Vector{" + trans.Vec3ToString(m.diffuse) + "}\n"
 	logString += " - Specualar: Vector{" + trans.Vec3ToString(m.specular) + "}\n"
 	logString += " - Shininess: " + trans.Float32ToString(m.shininess) + "\n"
+	logString += " - Alpha: " + trans.Float32ToString(m.GetAlpha()) + "\n"
 	return logString
 }
 
@@ -51,6 +56,42 @@ func (m *Material) GetShininess() float32 {
 	return m.shininess
 }
 
+// SetAmbient updates the ambient color of the material
+func (m *Material) SetAmbient(ambient mgl32.Vec3) {
+	m.ambient = ambient
+}
+
+// SetDiffuse updates the diffuse color of the material
+func (m *Material) SetDiffuse(diffuse mgl32.Vec3) {
+	m.diffuse = diffuse
+}
+
+// SetSpecular updates the specular color of the material
+func (m *Material) SetSpecular(specular mgl32.Vec3) {
+	m.specular = specular
+}
+
+// SetShininess updates the shininess of the material. The predefined materials
+// are shared, so they have to be copied before the modification.
+func (m *Material) SetShininess(shininess float32) {
+	m.shininess = shininess
+}
+
+// GetAlpha returns the alpha of the material. 1 is opaque, 0 is invisible.
+func (m *Material) GetAlpha() float32 {
+	return 1 - m.transparency
+}
+
+// SetAlpha updates the alpha of the material. The value is clamped to [0, 1].
+func (m *Material) SetAlpha(alpha float32) {
+	m.transparency = 1 - mgl32.Clamp(alpha, 0, 1)
+}
+
+// IsTransparent returns true if the alpha is less than 1.
+func (m *Material) IsTransparent() bool {
+	return m.transparency > 0
+}
+
 var (
 	TestMaterialGreen = &Material{
 		diffuse:   mgl32.Vec3{0, 1, 0},
@@ -206,3 +247,50 @@ var (
 		shininess: 0.078125,
 	}
 )
+
+// Presets maps the lowercase name of the predefined materials to the materials.
+// The scene files are referencing the materials with these names.
+var Presets = map[string]*Material{
+	"emerald":       Emerald,
+	"jade":          Jade,
+	"obsidian":      Obsidian,
+	"pearl":         Pearl,
+	"ruby":          Ruby,
+	"turquoise":     Turquoise,
+	"brass":         Brass,
+	"bronze":        Bronze,
+	"chrome":        Chrome,
+	"copper":        Copper,
+	"gold":          Gold,
+	"silver":        Silver,
+	"blackplastic":  Blackplastic,
+	"cyanplastic":   Cyanplastic,
+	"greenplastic":  Greenplastic,
+	"redplastic":    Redplastic,
+	"whiteplastic":  Whiteplastic,
+	"yellowplastic": Yellowplastic,
+	"blackrubber":   Blackrubber,
+	"cyanrubber":    Cyanrubber,
+	"greenrubber":   Greenrubber,
+	"redrubber":     Redrubber,
+	"whiterubber":   Whiterubber,
+	"yellowrubber":  Yellowrubber,
+}
+
+// ByName returns the predefined material with the given name. The name is case insensitive.
+// The second return value is false, if the name is unknown.
+func ByName(name string) (*Material, bool) {
+	m, ok := Presets[strings.ToLower(name)]
+	return m, ok
+}
+
+// PresetName returns the name of the given material if it is one of the predefined materials.
+// Otherwise it returns an empty string.
+func PresetName(m *Material) string {
+	for name, preset := range Presets {
+		if preset == m {
+			return name
+		}
+	}
+	return ""
+}
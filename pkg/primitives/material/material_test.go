@@ -59,3 +59,55 @@ func TestGetShininess(t *testing.T) {
 		t.Errorf("Invalid shininess. Instead of '%f', we have '%f'.", DefaultShininess, material.shininess)
 	}
 }
+func TestByName(t *testing.T) {
+	mat, ok := ByName("Emerald")
+	if !ok {
+		t.Error("Emerald should be found")
+	}
+	if mat != Emerald {
+		t.Error("Invalid material")
+	}
+	_, ok = ByName("not-a-material")
+	if ok {
+		t.Error("Unknown material shouldn't be found")
+	}
+}
+func TestPresetName(t *testing.T) {
+	if PresetName(Jade) != "jade" {
+		t.Errorf("Invalid preset name. Instead of 'jade', we have '%s'.", PresetName(Jade))
+	}
+	material := New(DefaultAmbient, DefaultDiffuse, DefaultSpecular, DefaultShininess)
+	if PresetName(material) != "" {
+		t.Error("Custom material shouldn't have preset name")
+	}
+}
+func TestSetters(t *testing.T) {
+	material := New(DefaultAmbient, DefaultDiffuse, DefaultSpecular, DefaultShininess)
+	material.SetAmbient(DefaultSpecular)
+	material.SetDiffuse(DefaultAmbient)
+	material.SetSpecular(DefaultDiffuse)
+	material.SetShininess(32)
+	if material.GetAmbient() != DefaultSpecular || material.GetDiffuse() != DefaultAmbient || material.GetSpecular() != DefaultDiffuse {
+		t.Error("Invalid colors")
+	}
+	if material.GetShininess() != 32 {
+		t.Errorf("Invalid shininess '%f'.", material.GetShininess())
+	}
+}
+func TestAlpha(t *testing.T) {
+	material := New(DefaultAmbient, DefaultDiffuse, DefaultSpecular, DefaultShininess)
+	if material.GetAlpha() != 1 || material.IsTransparent() {
+		t.Error("New material should be opaque")
+	}
+	if Jade.GetAlpha() != 1 || Jade.IsTransparent() {
+		t.Error("Preset material should be opaque")
+	}
+	material.SetAlpha(0.25)
+	if material.GetAlpha() != 0.25 || !material.IsTransparent() {
+		t.Errorf("Invalid alpha '%f'.", material.GetAlpha())
+	}
+	material.SetAlpha(2)
+	if material.GetAlpha() != 1 {
+		t.Error("Alpha should be clamped")
+	}
+}
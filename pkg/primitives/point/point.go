@@ -3,6 +3,7 @@ package point
 import (
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/picking"
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
 	"github.com/akosgarai/opengl_playground/pkg/vao"
 )
@@ -32,6 +33,16 @@ type Points struct {
 	points []*Point
 }
 
+// GetCoordinate returns the current coordinate of the point.
+func (p *Point) GetCoordinate() mgl32.Vec3 {
+	return p.coordinate
+}
+
+// GetColor returns the color of the point.
+func (p *Point) GetColor() mgl32.Vec3 {
+	return p.color
+}
+
 // SetColor updates the Color of the point.
 func (p *Point) SetColor(color mgl32.Vec3) {
 	p.color = color
@@ -132,12 +143,50 @@ func (p *Points) Draw() {
 	p.draw()
 }
 func (p *Points) draw() {
+	// the empty buffer can't be bound, so that there is nothing to do.
+	if len(p.points) == 0 {
+		return
+	}
 	p.buildVao()
 
 	p.shader.DrawPoints(int32(len(p.vao.Get()) / 7))
-	p.shader.Close(2)
+	p.shader.Close(3)
 }
 
 func (p *Points) Count() int {
 	return len(p.points)
 }
+
+// Get returns the point with the given index.
+func (p *Points) Get(index int) *Point {
+	return p.points[index]
+}
+
+// IDCount returns the number of the ids of the points. Every point has its
+// own id, so that the index of the picked point could be found.
+func (p *Points) IDCount() int {
+	return len(p.points)
+}
+
+// DrawIDs draws the points with the id shader. The color of the point is
+// replaced with the color of its id, the id of the first point is the first.
+func (p *Points) DrawIDs(idShader picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	if len(p.points) == 0 {
+		return
+	}
+	picking.SetVertexIDs(idShader)
+	idShader.SetUniformMat4("view", view)
+	idShader.SetUniformMat4("projection", projection)
+	idShader.SetUniformMat4("model", mgl32.Ident4())
+	p.vao.Clear()
+	for index, item := range p.points {
+		p.vao.AppendPoint(item.coordinate, picking.IDColor(first+uint32(index)), item.size)
+	}
+	idShader.BindBufferData(p.vao.Get())
+	idShader.BindVertexArray()
+	idShader.VertexAttribPointer(0, 3, 4*7, 0)
+	idShader.VertexAttribPointer(1, 3, 4*7, 4*3)
+	idShader.VertexAttribPointer(2, 1, 4*7, 4*6)
+	idShader.DrawPoints(int32(len(p.points)))
+	idShader.Close(3)
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/picking/pickingtest"
 )
 
 type testShader struct {
@@ -53,6 +55,15 @@ func TestSetColor(t *testing.T) {
 	if point.color != color {
 		t.Error("Color should be updated")
 	}
+	if point.GetColor() != color {
+		t.Error("Invalid color")
+	}
+}
+func TestGetCoordinate(t *testing.T) {
+	point := getPoint()
+	if point.GetCoordinate() != point.coordinate {
+		t.Error("Invalid coordinate")
+	}
 }
 func TestSetSpeed(t *testing.T) {
 	point := getPoint()
@@ -207,3 +218,40 @@ func TestCount(t *testing.T) {
 		t.Error("Invalid points count")
 	}
 }
+func TestGet(t *testing.T) {
+	points := New(shader)
+	coords := mgl32.Vec3{1, 2, 3}
+	col := mgl32.Vec3{1, 0, 0}
+	size := float32(3.0)
+	point := points.Add(coords, col, size)
+	if points.Get(0) != point {
+		t.Error("Invalid point")
+	}
+}
+
+func TestDrawIDs(t *testing.T) {
+	points := New(shader)
+	ids := pickingtest.NewIDShader()
+	points.DrawIDs(ids, mgl32.Ident4(), mgl32.Ident4(), 1)
+	if ids.Points != 0 || points.IDCount() != 0 {
+		t.Error("Empty points shouldn't be drawn")
+	}
+	points.Add(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{1, 0, 0}, 3)
+	points.Add(mgl32.Vec3{1, 0, 0}, mgl32.Vec3{1, 0, 0}, 3)
+	points.DrawIDs(ids, mgl32.Ident4(), mgl32.Ident4(), 255)
+	if points.IDCount() != 2 || ids.Points != 2 || ids.Floats["vertexColor"] != 1.0 {
+		t.Errorf("Invalid id pass. Count: '%d', drawn: '%d'.", points.IDCount(), ids.Points)
+	}
+	// the size attribute is disabled too.
+	if len(ids.Enabled) != 0 {
+		t.Errorf("The vertex attributes should be disabled. Enabled: '%v'.", ids.Enabled)
+	}
+	// the colors of the 255 and the 256 ids.
+	if len(ids.Data) != 14 || ids.Data[3] != 1 || ids.Data[4] != 0 || ids.Data[10] != 0 || ids.Data[11] != 1.0/255.0 {
+		t.Errorf("Invalid id colors '%v'.", ids.Data)
+	}
+	// the original color is kept.
+	if points.Get(0).color != (mgl32.Vec3{1, 0, 0}) {
+		t.Error("The color of the point shouldn't be changed")
+	}
+}
@@ -3,7 +3,11 @@ package rectangle
 import (
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	sh "github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/vao"
 )
 
@@ -30,6 +34,10 @@ type Rectangle struct {
 	precision int
 	vao       *vao.VAO
 	shader    Shader
+	// the generated vao of the precisions and the level of detail selector.
+	// The cache is cleared if the vertices are changed.
+	cache  *lod.Cache
+	detail *lod.Selector
 
 	colors [4]mgl32.Vec3
 	points [4]mgl32.Vec3
@@ -47,6 +55,9 @@ type Rectangle struct {
 	// 0 - normal draw with colors
 	// 1 - draw with normal vectors.
 	drawMode int
+	// alpha is the alpha of the vertex colors in color draw mode, and the
+	// material.alpha uniform in the light draw modes.
+	alpha float32
 }
 
 func New(points, color [4]mgl32.Vec3, shader Shader) *Rectangle {
@@ -54,6 +65,7 @@ func New(points, color [4]mgl32.Vec3, shader Shader) *Rectangle {
 		precision: 1,
 		vao:       vao.NewVAO(),
 		shader:    shader,
+		cache:     lod.NewCache(),
 		colors:    color,
 		points:    points,
 		direction: mgl32.Vec3{0, 0, 0},
@@ -61,6 +73,7 @@ func New(points, color [4]mgl32.Vec3, shader Shader) *Rectangle {
 		angle:     0,
 		axis:      mgl32.Vec3{0, 0, 0},
 		drawMode:  DRAW_MODE_COLOR,
+		alpha:     1,
 	}
 }
 
@@ -112,11 +125,13 @@ func (r *Rectangle) SetColor(color mgl32.Vec3) {
 	for i := 0; i < 4; i++ {
 		r.colors[i] = color
 	}
+	r.cache.Clear()
 }
 
 // SetIndexColor updates the color of the given index.
 func (r *Rectangle) SetIndexColor(index int, color mgl32.Vec3) {
 	r.colors[index] = color
+	r.cache.Clear()
 }
 
 // SetDirection updates the direction vector.
@@ -150,10 +165,36 @@ func (r *Rectangle) SetAxis(axis mgl32.Vec3) {
 	r.axis = axis
 }
 
+// SetAlpha updates the alpha of the rectangle.
+func (r *Rectangle) SetAlpha(alpha float32) {
+	r.alpha = alpha
+	r.cache.Clear()
+}
+
+// GetAlpha returns the alpha of the rectangle.
+func (r *Rectangle) GetAlpha() float32 {
+	return r.alpha
+}
+
 // GetDirection returns the direction of the rectangle
 func (r *Rectangle) GetDirection() mgl32.Vec3 {
 	return r.direction
 }
+
+// GetSpeed returns the speed of the rectangle.
+func (r *Rectangle) GetSpeed() float32 {
+	return r.speed
+}
+
+// GetAngle returns the rotation angle of the rectangle.
+func (r *Rectangle) GetAngle() float32 {
+	return r.angle
+}
+
+// GetAxis returns the rotation axis of the rectangle.
+func (r *Rectangle) GetAxis() mgl32.Vec3 {
+	return r.axis
+}
 func (r *Rectangle) appendRectangleToVao(coordinates, data2 [4]mgl32.Vec3) {
 	indicies := [6]int{0, 1, 2, 0, 2, 3}
 	if r.shader.HasTexture() {
@@ -166,6 +207,10 @@ func (r *Rectangle) appendRectangleToVao(coordinates, data2 [4]mgl32.Vec3) {
 		for i := 0; i < 6; i++ {
 			r.vao.AppendTextureVectors(coordinates[indicies[i]], data2[indicies[i]], textureCoords[indicies[i]])
 		}
+	} else if r.hasColorAlpha() {
+		for i := 0; i < 6; i++ {
+			r.vao.AppendColorAlpha(coordinates[indicies[i]], data2[indicies[i]].Vec4(r.alpha))
+		}
 	} else {
 		for i := 0; i < 6; i++ {
 			r.vao.AppendVectors(coordinates[indicies[i]], data2[indicies[i]])
@@ -173,6 +218,20 @@ func (r *Rectangle) appendRectangleToVao(coordinates, data2 [4]mgl32.Vec3) {
 	}
 }
 
+// hasColorAlpha returns true if the vertex colors contain the alpha component.
+// In this case the color is a vec4 in the vao.
+func (r *Rectangle) hasColorAlpha() bool {
+	return r.drawMode == DRAW_MODE_COLOR && r.IsTransparent()
+}
+
+// vertexSize returns the number of the float32 values of a vertex without texture.
+func (r *Rectangle) vertexSize() int32 {
+	if r.hasColorAlpha() {
+		return 7
+	}
+	return 6
+}
+
 func (r *Rectangle) insertEverythingToVao() {
 	verticalStep := (r.points[1].Sub(r.points[0])).Mul(1.0 / float32(r.precision))
 	horisontalStep := (r.points[3].Sub(r.points[0])).Mul(1.0 / float32(r.precision))
@@ -213,9 +272,17 @@ func (r *Rectangle) SetupExternalVao(v *vao.VAO) *vao.VAO {
 	return v
 }
 
+// setupCachedVao uses the cached vao of the precision. It's generated only
+// for the first time.
+func (r *Rectangle) setupCachedVao() {
+	r.vao = r.cache.Get(r.precision, func(v *vao.VAO) {
+		r.vao = v
+		r.insertEverythingToVao()
+	})
+}
 func (r *Rectangle) buildVaoWithTexture() {
 	// Create the vao object
-	r.setupVao()
+	r.setupCachedVao()
 	r.shader.BindBufferData(r.vao.Get())
 	r.shader.BindVertexArray()
 	// setup points
@@ -227,15 +294,16 @@ func (r *Rectangle) buildVaoWithTexture() {
 }
 func (r *Rectangle) buildVaoWithoutTexture() {
 	// Create the vao object
-	r.setupVao()
+	r.setupCachedVao()
 
 	r.shader.BindBufferData(r.vao.Get())
 
 	r.shader.BindVertexArray()
+	size := r.vertexSize()
 	// setup points
-	r.shader.VertexAttribPointer(0, 3, 4*6, 0)
+	r.shader.VertexAttribPointer(0, 3, 4*size, 0)
 	// setup color
-	r.shader.VertexAttribPointer(1, 3, 4*6, 4*3)
+	r.shader.VertexAttribPointer(1, size-3, 4*size, 4*3)
 }
 
 // Draw is for drawing the rectangle to the screen.
@@ -252,20 +320,22 @@ func (r *Rectangle) Draw() {
 func (r *Rectangle) drawWithTextures() {
 	r.buildVaoWithTexture()
 	r.shader.DrawTriangles(int32(len(r.vao.Get()) / 8))
-	r.shader.Close(2)
+	r.shader.Close(3)
 }
 func (r *Rectangle) drawWithoutTextures() {
 	r.buildVaoWithoutTexture()
-	r.shader.DrawTriangles(int32(len(r.vao.Get()) / 6))
-	r.shader.Close(1)
+	r.shader.DrawTriangles(int32(len(r.vao.Get())) / r.vertexSize())
+	r.shader.Close(2)
 }
 func (r *Rectangle) modelTransformation() mgl32.Mat4 {
 	return mgl32.HomogRotate3D(r.angle, r.axis)
 }
 func (r *Rectangle) setupColorUniform() {
 	if r.drawMode == DRAW_MODE_LIGHT {
+		r.shader.SetUniform1f("material.alpha", r.alpha)
 	} else if r.drawMode == DRAW_MODE_TEXTURED_LIGHT {
 		r.shader.SetUniform1f("material.shininess", 32.0)
+		r.shader.SetUniform1f("material.alpha", r.alpha)
 	}
 }
 
@@ -283,12 +353,47 @@ func (r *Rectangle) DrawWithUniforms(view, projection mgl32.Mat4) {
 		r.drawWithTextures()
 	}
 }
+
+// materialKey is the material of the rectangle for the render queue. The
+// rectangle doesn't have material object, the material uniforms depend on the
+// draw mode and the alpha.
+type materialKey struct {
+	drawMode int
+	alpha    float32
+}
+
+// GetRenderKeys returns the shader, the material and the texture set of the
+// shader for the render queue. The material is nil in color draw mode, where
+// the material uniforms aren't used.
+func (r *Rectangle) GetRenderKeys() (interface{}, interface{}, interface{}, interface{}) {
+	var material interface{}
+	if r.drawMode != DRAW_MODE_COLOR {
+		material = materialKey{drawMode: r.drawMode, alpha: r.alpha}
+	}
+	return r.shader, material, sh.TextureKeyOf(r.shader), nil
+}
+
+// GetTransform returns the model transformation of the center point. The
+// points of the rectangle are in world coordinates, so that the translation
+// of the transformation is the rotated center point.
+func (r *Rectangle) GetTransform() mgl32.Mat4 {
+	center := r.points[0].Add(r.points[2]).Mul(0.5)
+	return r.modelTransformation().Mul4(mgl32.Translate3D(center.X(), center.Y(), center.Z()))
+}
+
+// IsTransparent returns true if the alpha is less than 1.
+func (r *Rectangle) IsTransparent() bool {
+	return r.alpha < 1
+}
 func (r *Rectangle) Update(dt float64) {
 	delta := float32(dt)
 	motionVector := r.direction
 	if motionVector.Len() > 0 {
 		motionVector = motionVector.Normalize().Mul(delta * r.speed)
 	}
+	if motionVector.Len() > 0 {
+		r.cache.Clear()
+	}
 	for i := 0; i < 4; i++ {
 		r.points[i] = (r.points[i]).Add(motionVector)
 	}
@@ -304,6 +409,65 @@ func (r *Rectangle) GetNormal() mgl32.Vec3 {
 // DrawMode updates the draw mode after validation. If it fails, it keeps the original value.
 func (s *Rectangle) DrawMode(mode int) {
 	if mode == DRAW_MODE_COLOR || mode == DRAW_MODE_LIGHT || mode == DRAW_MODE_TEXTURED_LIGHT {
+		if s.drawMode != mode {
+			s.cache.Clear()
+		}
 		s.drawMode = mode
 	}
 }
+
+// GetBoundingBox returns the axis aligned bounding box of the rotated rectangle.
+func (r *Rectangle) GetBoundingBox() bounds.AABB {
+	M := r.modelTransformation()
+	var points []mgl32.Vec3
+	for _, p := range r.points {
+		points = append(points, mgl32.TransformCoordinate(p, M))
+	}
+	return bounds.NewAABB(points...)
+}
+
+// GetBoundingSphere returns the bounding sphere of the rotated rectangle.
+func (r *Rectangle) GetBoundingSphere() bounds.Sphere {
+	return r.GetBoundingBox().BoundingSphere()
+}
+
+// SetLOD sets the level of detail selector. The precision of the rectangle is
+// updated to the precision of the selected level in the SelectLOD calls.
+func (r *Rectangle) SetLOD(selector *lod.Selector) {
+	r.detail = selector
+}
+
+// SelectLOD selects the level of detail based on the projected size of the
+// rectangle. It does nothing without selector.
+func (r *Rectangle) SelectLOD(view lod.View) {
+	if r.detail == nil {
+		return
+	}
+	r.detail.Select(view.ProjectedSize(r.GetBoundingSphere()))
+	r.precision = r.detail.Current().Precision
+}
+
+// GetTriangles returns the 2 triangles of the rotated rectangle.
+func (r *Rectangle) GetTriangles() [][3]mgl32.Vec3 {
+	M := r.modelTransformation()
+	var p [4]mgl32.Vec3
+	for i := range r.points {
+		p[i] = mgl32.TransformCoordinate(r.points[i], M)
+	}
+	return [][3]mgl32.Vec3{{p[0], p[1], p[2]}, {p[0], p[2], p[3]}}
+}
+
+// IDCount returns the number of the ids of the rectangle. It's 1.
+func (r *Rectangle) IDCount() int {
+	return 1
+}
+
+// DrawIDs draws the rectangle with the id shader and the color of the first
+// id. The vertex layout of the original shader is kept.
+func (r *Rectangle) DrawIDs(idShader picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	picking.SetID(idShader, first)
+	original := r.shader
+	r.shader = picking.LayoutShader{IDShader: idShader, Textured: original.HasTexture()}
+	r.DrawWithUniforms(view, projection)
+	r.shader = original
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking/pickingtest"
 	"github.com/akosgarai/opengl_playground/pkg/vao"
 )
 
@@ -470,3 +472,132 @@ func TestDrawWithUniformsTexturedLight(t *testing.T) {
 		t.Errorf("Vao should be 48 long. Instead of it, it's '%d'", len(square.vao.Get()))
 	}
 }
+func TestAlpha(t *testing.T) {
+	shader.HasTextureValue = false
+	square := New(DefaultCoordinates, DefaultColors, shader)
+	if square.GetAlpha() != 1 || square.IsTransparent() {
+		t.Error("New rectangle should be opaque")
+	}
+	square.SetAlpha(0.5)
+	if square.GetAlpha() != 0.5 || !square.IsTransparent() {
+		t.Error("Rectangle should be transparent")
+	}
+	square.buildVaoWithoutTexture()
+	if len(square.vao.Get()) != 42 {
+		t.Errorf("Invalid number of items in the vao. Instead of '42', we have '%d'.", len(square.vao.Get()))
+	}
+	if square.vao.Get()[6] != 0.5 {
+		t.Error("The alpha should be the 7. element of the vao.")
+	}
+	square.DrawMode(DRAW_MODE_LIGHT)
+	square.buildVaoWithoutTexture()
+	if len(square.vao.Get()) != 36 {
+		t.Errorf("Invalid number of items in the vao. Instead of '36', we have '%d'.", len(square.vao.Get()))
+	}
+}
+func TestRenderKeys(t *testing.T) {
+	shader.HasTextureValue = false
+	square := New(DefaultCoordinates, DefaultColors, shader)
+	s, m, tex, mesh := square.GetRenderKeys()
+	if s != shader || m != nil || tex != nil || mesh != nil {
+		t.Error("Invalid render keys")
+	}
+	if square.GetTransform().Col(3).Vec3() != (mgl32.Vec3{0.5, 0.5, 0}) {
+		t.Errorf("Invalid transform '%v'.", square.GetTransform())
+	}
+	// the light draw modes have material, it depends on the alpha.
+	square.DrawMode(DRAW_MODE_LIGHT)
+	_, opaque, _, _ := square.GetRenderKeys()
+	square.SetAlpha(0.5)
+	_, glass, _, _ := square.GetRenderKeys()
+	if opaque == nil || glass == nil || opaque == glass {
+		t.Errorf("Invalid material keys '%v', '%v'.", opaque, glass)
+	}
+	other := New(DefaultCoordinates, DefaultColors, shader)
+	other.DrawMode(DRAW_MODE_LIGHT)
+	other.SetAlpha(0.5)
+	if _, m, _, _ := other.GetRenderKeys(); m != glass {
+		t.Error("The rectangles with the same alpha should have the same material key")
+	}
+	// the texture set is provided by the shader.
+	keyed := New(DefaultCoordinates, DefaultColors, keyedShader{testShader: shader, key: "1,2"})
+	if _, _, tex, _ := keyed.GetRenderKeys(); tex != "1,2" {
+		t.Errorf("Invalid texture key '%v'.", tex)
+	}
+}
+
+// keyedShader exposes the identity of its texture set.
+type keyedShader struct {
+	testShader
+	key string
+}
+
+func (k keyedShader) TextureKey() interface{} {
+	return k.key
+}
+func TestRectangleBounds(t *testing.T) {
+	rect := New(DefaultCoordinates, DefaultColors, shader)
+	box := rect.GetBoundingBox()
+	if box.Min != (mgl32.Vec3{0, 0, 0}) || box.Max != (mgl32.Vec3{1, 1, 0}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+	rect.SetAngle(mgl32.DegToRad(90))
+	rect.SetAxis(mgl32.Vec3{0, 0, 1})
+	box = rect.GetBoundingBox()
+	if box.Min.Sub(mgl32.Vec3{-1, 0, 0}).Len() > 1e-5 || box.Max.Sub(mgl32.Vec3{0, 1, 0}).Len() > 1e-5 {
+		t.Errorf("Invalid rotated bounding box '%v'.", box)
+	}
+	sphere := rect.GetBoundingSphere()
+	if sphere.Center.Sub(mgl32.Vec3{-0.5, 0.5, 0}).Len() > 1e-5 {
+		t.Errorf("Invalid bounding sphere '%v'.", sphere)
+	}
+}
+func TestRectangleLOD(t *testing.T) {
+	shader.HasTextureValue = false
+	square := New(DefaultCoordinates, DefaultColors, shader)
+	square.SetLOD(lod.NewSelector(lod.Level{MinSize: 100, Precision: 4}, lod.Level{MinSize: 0, Precision: 1}))
+	square.SelectLOD(lod.View{Position: mgl32.Vec3{0.5, 0.5, 1}, Fov: 90, Height: 600})
+	if square.precision != 4 {
+		t.Errorf("Invalid precision of the near rectangle '%d'.", square.precision)
+	}
+	square.buildVaoWithoutTexture()
+	if len(square.vao.Get()) != 16*36 {
+		t.Errorf("Invalid number of items in the vao '%d'.", len(square.vao.Get()))
+	}
+	near := square.vao
+	square.buildVaoWithoutTexture()
+	if square.vao != near {
+		t.Error("The vao should be reused from the cache")
+	}
+	square.SetSpeed(1)
+	square.SetDirection(mgl32.Vec3{1, 0, 0})
+	square.Update(1)
+	if square.cache.Len() != 0 {
+		t.Error("The cache should be cleared after the movement")
+	}
+}
+func TestRectangleTriangles(t *testing.T) {
+	square := New(DefaultCoordinates, DefaultColors, shader)
+	square.SetAngle(mgl32.DegToRad(90))
+	square.SetAxis(mgl32.Vec3{0, 0, 1})
+	triangles := square.GetTriangles()
+	if len(triangles) != 2 {
+		t.Fatalf("Invalid number of triangles '%d'.", len(triangles))
+	}
+	if triangles[0][1].Sub(mgl32.Vec3{0, 1, 0}).Len() > 1e-5 || triangles[1][2].Sub(mgl32.Vec3{-1, 0, 0}).Len() > 1e-5 {
+		t.Errorf("Invalid rotated triangles '%v'.", triangles)
+	}
+}
+
+func TestDrawIDs(t *testing.T) {
+	textured := testShader{HasTextureValue: true}
+	rect := New(DefaultCoordinates, DefaultColors, textured)
+	ids := pickingtest.DrawSingleID(t, rect, 3)
+	// the textured layout is kept.
+	if len(ids.Data) != 6*8 {
+		t.Errorf("Invalid vao length '%d'.", len(ids.Data))
+	}
+	if rect.shader != textured {
+		t.Error("The shader should be restored")
+	}
+}
@@ -0,0 +1,218 @@
+package sphere
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	"github.com/akosgarai/opengl_playground/pkg/vao"
+)
+
+// The attribute locations of the instanced sphere. The model matrix takes
+// 4 locations, one column per location.
+const (
+	INSTANCE_MODEL_LOCATION = 2
+	INSTANCE_COLOR_LOCATION = 6
+)
+
+// instanceSize is the number of float32 values of an instance: the model
+// matrix and the rgba color.
+const instanceSize = 16 + 4
+
+// InstanceShader is a shader that could draw instanced geometry.
+type InstanceShader interface {
+	Shader
+	VertexAttribDivisor(uint32, uint32)
+	DrawTrianglesInstanced(int32, int32)
+}
+
+// Instance is a copy of the instanced sphere.
+type Instance struct {
+	Center mgl32.Vec3
+	Radius float32
+	Color  mgl32.Vec4
+}
+
+// InstancedSphere draws every instance with the same unit sphere geometry in
+// one draw call. The vertex attributes are the position (0) and the normal
+// vector (1), the per instance attributes are the model matrix (2-5) and the
+// color (6).
+type InstancedSphere struct {
+	precision int
+	// geometry is the unit sphere, it's built only once.
+	geometry *vao.VAO
+	shader   InstanceShader
+
+	instances []Instance
+	material  *material.Material
+}
+
+// NewInstanced returns an instanced sphere without instances.
+func NewInstanced(shader InstanceShader) *InstancedSphere {
+	color := mgl32.Vec3{1, 1, 1}
+	return &InstancedSphere{
+		precision: 10,
+		geometry:  vao.NewVAO(),
+		shader:    shader,
+		instances: []Instance{},
+		material:  material.New(color, color, color, 36.0),
+	}
+}
+
+// Log returns the string representation of the instanced sphere.
+func (s *InstancedSphere) Log() string {
+	logString := "InstancedSphere:\n"
+	logString += " - Instances : " + trans.IntegerToString(len(s.instances)) + ", precision: " + trans.IntegerToString(s.precision) + "\n"
+	logString += s.material.Log() + "\n"
+	return logString
+}
+
+// SetPrecision updates the precision of the geometry.
+func (s *InstancedSphere) SetPrecision(p int) {
+	s.precision = p
+	s.geometry.Clear()
+}
+
+// SetMaterial updates the material of the instances. The instance colors are
+// also passed to the shader.
+func (s *InstancedSphere) SetMaterial(mat *material.Material) {
+	s.material = mat
+}
+
+// AddInstance inserts a new instance and returns its index.
+func (s *InstancedSphere) AddInstance(center mgl32.Vec3, radius float32, color mgl32.Vec4) int {
+	s.instances = append(s.instances, Instance{Center: center, Radius: radius, Color: color})
+	return len(s.instances) - 1
+}
+
+// SetInstance updates the instance of the given index.
+func (s *InstancedSphere) SetInstance(index int, instance Instance) {
+	s.instances[index] = instance
+}
+
+// GetInstance returns the instance of the given index.
+func (s *InstancedSphere) GetInstance(index int) Instance {
+	return s.instances[index]
+}
+
+// InstanceCount returns the number of the instances.
+func (s *InstancedSphere) InstanceCount() int {
+	return len(s.instances)
+}
+
+// ClearInstances removes every instance.
+func (s *InstancedSphere) ClearInstances() {
+	s.instances = s.instances[:0]
+}
+
+// setupGeometry builds the unit sphere with the normal vectors.
+func (s *InstancedSphere) setupGeometry() {
+	s.geometry.Clear()
+	unitSphere(s.precision, func(pa, pb, pc mgl32.Vec3) {
+		// the normal vector of a point of the unit sphere is the point itself.
+		s.geometry.AppendVectors(pa, pa)
+		s.geometry.AppendVectors(pb, pb)
+		s.geometry.AppendVectors(pc, pc)
+	})
+}
+
+// instanceData returns the model matrices and the colors of the instances.
+func (s *InstancedSphere) instanceData() []float32 {
+	data := make([]float32, 0, len(s.instances)*instanceSize)
+	for _, instance := range s.instances {
+		model := mgl32.Translate3D(instance.Center.X(), instance.Center.Y(), instance.Center.Z()).Mul4(
+			mgl32.Scale3D(instance.Radius, instance.Radius, instance.Radius))
+		data = append(data, model[:]...)
+		data = append(data, instance.Color[:]...)
+	}
+	return data
+}
+func (s *InstancedSphere) buildVao() {
+	if len(s.geometry.Get()) == 0 {
+		s.setupGeometry()
+	}
+	s.shader.BindBufferData(s.geometry.Get())
+
+	s.shader.BindVertexArray()
+	// setup points
+	s.shader.VertexAttribPointer(0, 3, 4*6, 0)
+	// setup normal vectors
+	s.shader.VertexAttribPointer(1, 3, 4*6, 4*3)
+
+	s.shader.BindBufferData(s.instanceData())
+	// setup the columns of the model matrix
+	for i := 0; i < 4; i++ {
+		s.shader.VertexAttribPointer(uint32(INSTANCE_MODEL_LOCATION+i), 4, 4*instanceSize, 4*4*i)
+		s.shader.VertexAttribDivisor(uint32(INSTANCE_MODEL_LOCATION+i), 1)
+	}
+	// setup color
+	s.shader.VertexAttribPointer(INSTANCE_COLOR_LOCATION, 4, 4*instanceSize, 4*16)
+	s.shader.VertexAttribDivisor(INSTANCE_COLOR_LOCATION, 1)
+}
+func (s *InstancedSphere) setupMaterialUniform() {
+	diffuse := s.material.GetDiffuse()
+	ambient := s.material.GetAmbient()
+	specular := s.material.GetSpecular()
+	s.shader.SetUniform3f("material.diffuse", diffuse.X(), diffuse.Y(), diffuse.Z())
+	s.shader.SetUniform3f("material.ambient", ambient.X(), ambient.Y(), ambient.Z())
+	s.shader.SetUniform3f("material.specular", specular.X(), specular.Y(), specular.Z())
+	s.shader.SetUniform1f("material.shininess", s.material.GetShininess())
+	s.shader.SetUniform1f("material.alpha", s.material.GetAlpha())
+}
+
+// DrawWithUniforms sets the view, projection uniforms and draws the instances.
+func (s *InstancedSphere) DrawWithUniforms(view, projection mgl32.Mat4) {
+	s.shader.Use()
+	s.shader.SetUniformMat4("view", view)
+	s.shader.SetUniformMat4("projection", projection)
+	s.setupMaterialUniform()
+	s.draw()
+}
+
+// Draw draws the instances without the view and projection uniforms.
+func (s *InstancedSphere) Draw() {
+	s.shader.Use()
+	s.setupMaterialUniform()
+	s.draw()
+}
+func (s *InstancedSphere) draw() {
+	if len(s.instances) == 0 {
+		return
+	}
+	s.buildVao()
+	s.shader.DrawTrianglesInstanced(int32(len(s.geometry.Get())/6), int32(len(s.instances)))
+	// the vertex array is shared by the draws of the shader, the divisors are reset.
+	for i := INSTANCE_MODEL_LOCATION; i <= INSTANCE_COLOR_LOCATION; i++ {
+		s.shader.VertexAttribDivisor(uint32(i), 0)
+	}
+	s.shader.Close(INSTANCE_COLOR_LOCATION + 1)
+}
+
+// Update does nothing, the instances could be moved with the SetInstance function.
+func (s *InstancedSphere) Update(dt float64) {
+}
+
+// GetBoundingSphere returns the sphere that contains every instance.
+func (s *InstancedSphere) GetBoundingSphere() bounds.Sphere {
+	if len(s.instances) == 0 {
+		return bounds.Sphere{}
+	}
+	result := bounds.Sphere{Center: s.instances[0].Center, Radius: s.instances[0].Radius}
+	for _, instance := range s.instances[1:] {
+		result = result.Union(bounds.Sphere{Center: instance.Center, Radius: instance.Radius})
+	}
+	return result
+}
+
+// GetBoundingBox returns the axis aligned box that contains every instance.
+func (s *InstancedSphere) GetBoundingBox() bounds.AABB {
+	if len(s.instances) == 0 {
+		return bounds.AABB{}
+	}
+	result := bounds.Sphere{Center: s.instances[0].Center, Radius: s.instances[0].Radius}.BoundingBox()
+	for _, instance := range s.instances[1:] {
+		result = result.Union(bounds.Sphere{Center: instance.Center, Radius: instance.Radius}.BoundingBox())
+	}
+	return result
+}
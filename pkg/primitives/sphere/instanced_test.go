@@ -0,0 +1,120 @@
+package sphere
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+)
+
+// instanceTestShader records the instanced calls.
+type instanceTestShader struct {
+	testShader
+	divisors map[uint32]uint32
+	// the divisors at the time of the draw call.
+	drawDivisors map[uint32]uint32
+	buffers      [][]float32
+	vertices     int32
+	instances    int32
+}
+
+func newInstanceTestShader() *instanceTestShader {
+	return &instanceTestShader{divisors: make(map[uint32]uint32)}
+}
+func (t *instanceTestShader) BindBufferData(d []float32) {
+	t.buffers = append(t.buffers, d)
+}
+func (t *instanceTestShader) VertexAttribDivisor(index, divisor uint32) {
+	t.divisors[index] = divisor
+}
+func (t *instanceTestShader) DrawTrianglesInstanced(vertices, instances int32) {
+	t.vertices = vertices
+	t.instances = instances
+	t.drawDivisors = make(map[uint32]uint32)
+	for index, divisor := range t.divisors {
+		t.drawDivisors[index] = divisor
+	}
+}
+
+func TestInstancedSphere(t *testing.T) {
+	shader := newInstanceTestShader()
+	s := NewInstanced(shader)
+	s.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+	if shader.instances != 0 || len(shader.buffers) != 0 {
+		t.Error("Nothing should be drawn without instances")
+	}
+	s.AddInstance(mgl32.Vec3{1, 2, 3}, 2, mgl32.Vec4{1, 0, 0, 1})
+	index := s.AddInstance(mgl32.Vec3{0, 0, 0}, 1, mgl32.Vec4{0, 1, 0, 1})
+	if index != 1 || s.InstanceCount() != 2 {
+		t.Errorf("Invalid instance index '%d'.", index)
+	}
+	s.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+	if shader.instances != 2 {
+		t.Errorf("Invalid number of instances '%d'.", shader.instances)
+	}
+	if shader.vertices == 0 || int(shader.vertices)*6 != len(shader.buffers[0]) {
+		t.Errorf("Invalid number of vertices '%d'.", shader.vertices)
+	}
+	data := shader.buffers[1]
+	if len(data) != 2*instanceSize {
+		t.Fatalf("Invalid instance data length '%d'.", len(data))
+	}
+	// the translation is the 4. column of the model matrix, the scale is in the diagonal.
+	if data[12] != 1 || data[13] != 2 || data[14] != 3 || data[0] != 2 || data[16] != 1 || data[instanceSize+17] != 1 {
+		t.Errorf("Invalid instance data '%v'.", data)
+	}
+	for i := uint32(INSTANCE_MODEL_LOCATION); i <= INSTANCE_COLOR_LOCATION; i++ {
+		if shader.drawDivisors[i] != 1 {
+			t.Errorf("Invalid divisor of location '%d'.", i)
+		}
+		// the vertex array of the shader is shared, the divisors are reset after the draw.
+		if shader.divisors[i] != 0 {
+			t.Errorf("The divisor of location '%d' should be reset.", i)
+		}
+	}
+	if shader.drawDivisors[0] != 0 || shader.drawDivisors[1] != 0 {
+		t.Error("The vertex attributes shouldn't have divisor")
+	}
+}
+func TestInstancedSphereInstances(t *testing.T) {
+	s := NewInstanced(newInstanceTestShader())
+	s.AddInstance(mgl32.Vec3{1, 2, 3}, 2, mgl32.Vec4{1, 0, 0, 1})
+	instance := Instance{Center: mgl32.Vec3{4, 5, 6}, Radius: 3, Color: mgl32.Vec4{0, 0, 1, 1}}
+	s.SetInstance(0, instance)
+	if s.GetInstance(0) != instance {
+		t.Error("Invalid instance")
+	}
+	s.ClearInstances()
+	if s.InstanceCount() != 0 {
+		t.Error("Instances should be removed")
+	}
+	s.SetPrecision(4)
+	s.setupGeometry()
+	// the first row has 1, the others have 2 triangles per column.
+	if len(s.geometry.Get()) != (4+3*4*2)*3*6 {
+		t.Errorf("Invalid geometry size '%d'.", len(s.geometry.Get()))
+	}
+	if len(s.Log()) < 10 {
+		t.Error("Log too short")
+	}
+}
+func TestInstancedSphereBounds(t *testing.T) {
+	s := NewInstanced(newInstanceTestShader())
+	if (s.GetBoundingBox() != bounds.AABB{}) || (s.GetBoundingSphere() != bounds.Sphere{}) {
+		t.Error("The bounds without instances should be empty")
+	}
+	s.AddInstance(mgl32.Vec3{-2, 0, 0}, 1, mgl32.Vec4{1, 1, 1, 1})
+	s.AddInstance(mgl32.Vec3{2, 1, 0}, 1, mgl32.Vec4{1, 1, 1, 1})
+	box := s.GetBoundingBox()
+	if box.Min != (mgl32.Vec3{-3, -1, -1}) || box.Max != (mgl32.Vec3{3, 2, 1}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+	sphere := s.GetBoundingSphere()
+	for i := 0; i < s.InstanceCount(); i++ {
+		instance := s.GetInstance(i)
+		if instance.Center.Sub(sphere.Center).Len()+instance.Radius > sphere.Radius+1e-5 {
+			t.Errorf("The instance '%d' is outside of the bounding sphere '%v'.", i, sphere)
+		}
+	}
+}
@@ -3,8 +3,12 @@ package sphere
 import (
 	"github.com/go-gl/mathgl/mgl32"
 
+	"github.com/akosgarai/opengl_playground/pkg/bounds"
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	sh "github.com/akosgarai/opengl_playground/pkg/shader"
 	"github.com/akosgarai/opengl_playground/pkg/vao"
 )
 
@@ -29,6 +33,9 @@ type Sphere struct {
 	precision int
 	vao       *vao.VAO
 	shader    Shader
+	// the generated vao of the precisions and the level of detail selector.
+	cache  *lod.Cache
+	detail *lod.Selector
 
 	center mgl32.Vec3
 	radius float32
@@ -50,6 +57,7 @@ func New(center, color mgl32.Vec3, radius float32, shader Shader) *Sphere {
 		precision: 10,
 		vao:       vao.NewVAO(),
 		shader:    shader,
+		cache:     lod.NewCache(),
 
 		center: center,
 		radius: radius,
@@ -98,6 +106,7 @@ func (s *Sphere) GetCenter() mgl32.Vec3 {
 // SetColor updates the color of the sphere
 func (s *Sphere) SetColor(c mgl32.Vec3) {
 	s.color = c
+	s.cache.Clear()
 }
 
 // GetColor returns the color of the sphere
@@ -135,6 +144,11 @@ func (s *Sphere) SetSpeed(speed float32) {
 	s.speed = speed
 }
 
+// GetSpeed returns the speed of the sphere.
+func (s *Sphere) GetSpeed() float32 {
+	return s.speed
+}
+
 // GetAngle returns the angle of the sphere
 func (s *Sphere) GetAngle() float32 {
 	return s.angle
@@ -160,11 +174,19 @@ func (s *Sphere) SetMaterial(mat *material.Material) {
 	s.material = mat
 }
 
+// GetMaterial returns the material of the sphere.
+func (s *Sphere) GetMaterial() *material.Material {
+	return s.material
+}
+
 // DrawMode updates the draw mode after validation. If it fails, it keeps the original value.
 func (s *Sphere) DrawMode(mode int) {
 	if mode != DRAW_MODE_COLOR && mode != DRAW_MODE_LIGHT {
 		return
 	}
+	if s.drawMode != mode {
+		s.cache.Clear()
+	}
 	s.drawMode = mode
 }
 func (s *Sphere) triangleToVao(pa, pb, pc mgl32.Vec3) {
@@ -179,36 +201,47 @@ func (s *Sphere) triangleToVao(pa, pb, pc mgl32.Vec3) {
 	}
 }
 func (s *Sphere) setupVao() {
+	unitSphere(s.precision, s.triangleToVao)
+}
+
+// unitSphere calls the triangle function with the triangles of the sphere
+// with origo as center and 1 as radius.
+func unitSphere(precision int, triangle func(mgl32.Vec3, mgl32.Vec3, mgl32.Vec3)) {
 	// the coordinates will be set as a following: origo as center, 1 as radius, for drawing, the translation and scale could be done later in the model transformation.
 	// Sphere top: center + v{0,radius,0}, bottom: center + v{0,-radius,0}, left: center + v{-radius,0,0}, right: center + v{radius,0,0}
 	// Idea : start drawing triangles from both direction (top, bottom). step the coordinates and calculate the triangles, add them to vao.
 	// - step for y coord, : radius * 2 / numOfRows
 	RefPoint := mgl32.Vec3{0, 1, 0}
-	step := -mgl32.DegToRad(float32(360.0) / float32(s.precision))
-	for i := 0; i < s.precision; i++ {
+	step := -mgl32.DegToRad(float32(360.0) / float32(precision))
+	for i := 0; i < precision; i++ {
 		i_Rotation := mgl32.HomogRotate3DZ(float32(i) * step)
 		i1_Rotation := mgl32.HomogRotate3DZ(float32(i+1) * step)
-		for j := 0; j < s.precision; j++ {
+		for j := 0; j < precision; j++ {
 			j1_Rotation := mgl32.HomogRotate3DY(float32(j+1) * step)
 			j_Rotation := mgl32.HomogRotate3DY(float32(j) * step)
 			if i == 0 {
 				p2 := mgl32.TransformCoordinate(RefPoint, j_Rotation.Mul4(i1_Rotation))
 				p3 := mgl32.TransformCoordinate(RefPoint, j1_Rotation.Mul4(i1_Rotation))
-				s.triangleToVao(RefPoint, p2, p3)
+				triangle(RefPoint, p2, p3)
 			} else {
 				p1 := mgl32.TransformCoordinate(RefPoint, j_Rotation.Mul4(i_Rotation))
 				p2 := mgl32.TransformCoordinate(RefPoint, j1_Rotation.Mul4(i_Rotation))
 				p3 := mgl32.TransformCoordinate(RefPoint, j1_Rotation.Mul4(i1_Rotation))
 				p4 := mgl32.TransformCoordinate(RefPoint, j_Rotation.Mul4(i1_Rotation))
-				s.triangleToVao(p1, p2, p3)
-				s.triangleToVao(p1, p3, p4)
+				triangle(p1, p2, p3)
+				triangle(p1, p3, p4)
 			}
 		}
 	}
 }
+
+// buildVao uses the cached vao of the precision. It's generated only for the
+// first time.
 func (s *Sphere) buildVao() {
-	s.vao.Clear()
-	s.setupVao()
+	s.vao = s.cache.Get(s.precision, func(v *vao.VAO) {
+		s.vao = v
+		s.setupVao()
+	})
 
 	s.shader.BindBufferData(s.vao.Get())
 
@@ -238,6 +271,7 @@ func (s *Sphere) setupColorUniform() {
 		s.shader.SetUniform3f("material.ambient", ambient.X(), ambient.Y(), ambient.Z())
 		s.shader.SetUniform3f("material.specular", specular.X(), specular.Y(), specular.Z())
 		s.shader.SetUniform1f("material.shininess", shininess)
+		s.shader.SetUniform1f("material.alpha", s.material.GetAlpha())
 	}
 }
 func (s *Sphere) DrawWithUniforms(view, projection mgl32.Mat4) {
@@ -259,7 +293,23 @@ func (s *Sphere) Draw() {
 func (s *Sphere) draw() {
 	s.buildVao()
 	s.shader.DrawTriangles(int32(len(s.vao.Get()) / 6))
-	s.shader.Close(1)
+	s.shader.Close(2)
+}
+
+// GetRenderKeys returns the shader, the material and the texture set of the
+// shader for the render queue.
+func (s *Sphere) GetRenderKeys() (interface{}, interface{}, interface{}, interface{}) {
+	return s.shader, s.material, sh.TextureKeyOf(s.shader), nil
+}
+
+// GetTransform returns the model transformation of the sphere.
+func (s *Sphere) GetTransform() mgl32.Mat4 {
+	return s.modelTransformation()
+}
+
+// IsTransparent returns true if the material of the sphere is transparent.
+func (s *Sphere) IsTransparent() bool {
+	return s.material.IsTransparent()
 }
 func (s *Sphere) Update(dt float64) {
 	delta := float32(dt)
@@ -269,3 +319,58 @@ func (s *Sphere) Update(dt float64) {
 	}
 	s.center = (s.center).Add(motionVector)
 }
+
+// GetBoundingSphere returns the bounding sphere of the sphere.
+func (s *Sphere) GetBoundingSphere() bounds.Sphere {
+	return bounds.Sphere{Center: s.center, Radius: s.radius}
+}
+
+// GetBoundingBox returns the axis aligned bounding box of the sphere.
+func (s *Sphere) GetBoundingBox() bounds.AABB {
+	return s.GetBoundingSphere().BoundingBox()
+}
+
+// SetLOD sets the level of detail selector. The precision of the sphere is
+// updated to the precision of the selected level in the SelectLOD calls.
+func (s *Sphere) SetLOD(selector *lod.Selector) {
+	s.detail = selector
+}
+
+// SelectLOD selects the level of detail based on the projected size of the
+// sphere. It does nothing without selector.
+func (s *Sphere) SelectLOD(view lod.View) {
+	if s.detail == nil {
+		return
+	}
+	s.detail.Select(view.ProjectedSize(s.GetBoundingSphere()))
+	s.precision = s.detail.Current().Precision
+}
+
+// GetTriangles returns the triangles of the sphere in world coordinates with
+// the current precision.
+func (s *Sphere) GetTriangles() [][3]mgl32.Vec3 {
+	M := s.modelTransformation()
+	var triangles [][3]mgl32.Vec3
+	unitSphere(s.precision, func(pa, pb, pc mgl32.Vec3) {
+		triangles = append(triangles, [3]mgl32.Vec3{
+			mgl32.TransformCoordinate(pa, M),
+			mgl32.TransformCoordinate(pb, M),
+			mgl32.TransformCoordinate(pc, M),
+		})
+	})
+	return triangles
+}
+
+// IDCount returns the number of the ids of the sphere. It's 1.
+func (s *Sphere) IDCount() int {
+	return 1
+}
+
+// DrawIDs draws the sphere with the id shader and the color of the first id.
+func (s *Sphere) DrawIDs(idShader picking.IDShader, view, projection mgl32.Mat4, first uint32) {
+	picking.SetID(idShader, first)
+	original := s.shader
+	s.shader = idShader
+	s.DrawWithUniforms(view, projection)
+	s.shader = original
+}
@@ -3,6 +3,8 @@ package sphere
 import (
 	"testing"
 
+	"github.com/akosgarai/opengl_playground/pkg/lod"
+	"github.com/akosgarai/opengl_playground/pkg/picking/pickingtest"
 	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
 	"github.com/go-gl/mathgl/mgl32"
 )
@@ -185,6 +187,26 @@ func TestDrawWithUniforms(t *testing.T) {
 		t.Error("Vao is empty after the first setup.")
 	}
 }
+func TestRenderKeys(t *testing.T) {
+	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
+	s, m, tex, mesh := sphere.GetRenderKeys()
+	if s != shader || m != sphere.material || tex != nil || mesh != nil {
+		t.Error("Invalid render keys")
+	}
+	sphere.SetAxis(mgl32.Vec3{0, 1, 0})
+	if sphere.GetTransform().Col(3).Vec3() != DefaultCenter {
+		t.Error("Invalid transform")
+	}
+	if sphere.IsTransparent() {
+		t.Error("Sphere should be opaque")
+	}
+	glass := material.New(DefaultColor, DefaultColor, DefaultColor, 36.0)
+	glass.SetAlpha(0.5)
+	sphere.SetMaterial(glass)
+	if !sphere.IsTransparent() {
+		t.Error("Sphere with transparent material should be transparent")
+	}
+}
 func TestDraw(t *testing.T) {
 	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
 	if len(sphere.vao.Get()) != 0 {
@@ -225,6 +247,14 @@ func TestSetSpeed(t *testing.T) {
 		t.Error("Speed mismatch")
 	}
 }
+func TestGetSpeed(t *testing.T) {
+	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
+	speed := float32(5.0)
+	sphere.SetSpeed(speed)
+	if sphere.GetSpeed() != speed {
+		t.Error("Speed mismatch")
+	}
+}
 func TestUpdate(t *testing.T) {
 	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
 	direction := mgl32.Vec3{1, 0, 0}
@@ -287,3 +317,71 @@ func TestDrawWithLight(t *testing.T) {
 		t.Error("Vao is empty after the first setup.")
 	}
 }
+func TestSphereBounds(t *testing.T) {
+	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
+	s := sphere.GetBoundingSphere()
+	if s.Center != DefaultCenter || s.Radius != DefaultRadius {
+		t.Errorf("Invalid bounding sphere '%v'.", s)
+	}
+	box := sphere.GetBoundingBox()
+	if box.Min != (mgl32.Vec3{1, 1, 3}) || box.Max != (mgl32.Vec3{5, 5, 7}) {
+		t.Errorf("Invalid bounding box '%v'.", box)
+	}
+}
+func TestSphereLOD(t *testing.T) {
+	sphere := New(mgl32.Vec3{0, 0, -10}, DefaultColor, 1, shader)
+	// without selector the precision is kept.
+	sphere.SelectLOD(lod.View{Fov: 90, Height: 600})
+	if sphere.precision != 10 {
+		t.Error("The precision shouldn't be changed without selector")
+	}
+	sphere.SetLOD(lod.NewSelector(lod.Level{MinSize: 100, Precision: 20}, lod.Level{MinSize: 0, Precision: 5}))
+	sphere.SelectLOD(lod.View{Fov: 90, Height: 600})
+	if sphere.precision != 5 {
+		t.Errorf("Invalid precision of the far sphere '%d'.", sphere.precision)
+	}
+	sphere.buildVao()
+	far := sphere.vao
+	sphere.SetCenter(mgl32.Vec3{0, 0, -2})
+	sphere.SelectLOD(lod.View{Fov: 90, Height: 600})
+	if sphere.precision != 20 {
+		t.Errorf("Invalid precision of the near sphere '%d'.", sphere.precision)
+	}
+	sphere.buildVao()
+	if sphere.vao == far || len(sphere.vao.Get()) <= len(far.Get()) {
+		t.Error("The near sphere should be more detailed")
+	}
+	sphere.SetCenter(mgl32.Vec3{0, 0, -10})
+	sphere.SelectLOD(lod.View{Fov: 90, Height: 600})
+	sphere.buildVao()
+	if sphere.vao != far || sphere.cache.Len() != 2 {
+		t.Error("The far level should be reused from the cache")
+	}
+	sphere.SetColor(mgl32.Vec3{1, 0, 0})
+	if sphere.cache.Len() != 0 {
+		t.Error("The cache should be cleared after the color change")
+	}
+}
+func TestSphereTriangles(t *testing.T) {
+	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
+	sphere.SetPrecision(8)
+	triangles := sphere.GetTriangles()
+	if len(triangles) == 0 {
+		t.Fatal("The sphere should have triangles")
+	}
+	for _, triangle := range triangles {
+		for _, p := range triangle {
+			if d := p.Sub(DefaultCenter).Len(); !mgl32.FloatEqualThreshold(d, DefaultRadius, 1e-4) {
+				t.Fatalf("The point '%v' isn't on the sphere, distance '%f'.", p, d)
+			}
+		}
+	}
+}
+
+func TestDrawIDs(t *testing.T) {
+	sphere := New(DefaultCenter, DefaultColor, DefaultRadius, shader)
+	pickingtest.DrawSingleID(t, sphere, 3)
+	if sphere.shader != shader {
+		t.Error("The shader should be restored")
+	}
+}
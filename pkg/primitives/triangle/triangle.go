@@ -39,6 +39,16 @@ func New(points, colors [3]mgl32.Vec3, shader Shader) *Triangle {
 	}
 }
 
+// Coordinates returns the points of the triangle.
+func (t *Triangle) Coordinates() [3]mgl32.Vec3 {
+	return t.points
+}
+
+// Colors returns the colors of the triangle.
+func (t *Triangle) Colors() [3]mgl32.Vec3 {
+	return t.colors
+}
+
 // SetColor updates every color with the given one.
 func (t *Triangle) SetColor(color mgl32.Vec3) {
 	for i := 0; i < 3; i++ {
@@ -66,6 +76,16 @@ func (t *Triangle) SetSpeed(speed float32) {
 	t.speed = speed
 }
 
+// GetDirection returns the direction of the triangle.
+func (t *Triangle) GetDirection() mgl32.Vec3 {
+	return t.direction
+}
+
+// GetSpeed returns the speed of the triangle.
+func (t *Triangle) GetSpeed() float32 {
+	return t.speed
+}
+
 // Log returns the string representation of this object.
 func (t *Triangle) Log() string {
 	logString := "Triangle:\n"
@@ -103,7 +123,7 @@ func (t *Triangle) Draw() {
 func (t *Triangle) draw() {
 	t.buildVao()
 	t.shader.DrawTriangles(3)
-	t.shader.Close(1)
+	t.shader.Close(2)
 }
 
 // DrawWithUniforms is for drawing the rectangle to the screen. It setups the
@@ -57,6 +57,18 @@ func TestNewTriangle(t *testing.T) {
 		}
 	}
 }
+func TestCoordinates(t *testing.T) {
+	triangle := New(DefaultCoordinates, DefaultColors, shader)
+	if triangle.Coordinates() != DefaultCoordinates {
+		t.Error("Invalid coordinates")
+	}
+}
+func TestColors(t *testing.T) {
+	triangle := New(DefaultCoordinates, DefaultColors, shader)
+	if triangle.Colors() != DefaultColors {
+		t.Error("Invalid colors")
+	}
+}
 func TestSetColor(t *testing.T) {
 	triangle := New(DefaultCoordinates, DefaultColors, shader)
 	newColor := mgl32.Vec3{1, 1, 0}
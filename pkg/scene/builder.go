@@ -0,0 +1,439 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/application"
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	"github.com/akosgarai/opengl_playground/pkg/model"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/camera"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/light"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/point"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/rectangle"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/sphere"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/triangle"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+)
+
+// ShaderProgram is the union of the shader interfaces of the primitives
+// extended with the texture and light source setup functions.
+type ShaderProgram interface {
+	Use()
+	SetUniformMat4(string, mgl32.Mat4)
+	SetUniform3f(string, float32, float32, float32)
+	SetUniform1f(string, float32)
+	DrawTriangles(int32)
+	DrawPoints(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+	HasTexture() bool
+	AddTexture(string, int32, int32, int32, int32, string)
+	AddDirectionalLightSource(shader.DirectionalLight, [4]string)
+	AddPointLightSource(shader.PointLight, [7]string)
+	AddSpotLightSource(shader.SpotLight, [10]string)
+	SetViewPosition(mgl32.Vec3, string)
+}
+
+// ShaderFactory returns a shader program from the given vertex and fragment shader paths.
+type ShaderFactory func(vertexPath, fragmentPath string) ShaderProgram
+
+// NewShader is the default ShaderFactory. It compiles the shaders with the shader package.
+func NewShader(vertexPath, fragmentPath string) ShaderProgram {
+	return shader.NewShader(vertexPath, fragmentPath)
+}
+
+var drawModes = map[string]int{
+	"":               rectangle.DRAW_MODE_COLOR,
+	"color":          rectangle.DRAW_MODE_COLOR,
+	"light":          rectangle.DRAW_MODE_LIGHT,
+	"textured_light": rectangle.DRAW_MODE_TEXTURED_LIGHT,
+}
+
+var modelDrawModes = map[string]int{
+	"":       model.DRAW_MODE_COLOR,
+	"color":  model.DRAW_MODE_COLOR,
+	"light":  model.DRAW_MODE_LIGHT,
+	"normal": model.DRAW_MODE_NORMAL,
+}
+
+var textureParams = map[string]int32{
+	"clamp_to_edge": wrapper.CLAMP_TO_EDGE,
+	"repeat":        wrapper.REPEAT,
+	"linear":        wrapper.LINEAR,
+	"nearest":       wrapper.NEAREST,
+}
+
+type builtScene struct {
+	camera     *camera.Camera
+	shaders    map[string]ShaderProgram
+	lights     []*light.Light
+	primitives []application.Drawable
+}
+
+// Build creates the shaders, lights, camera and primitives of the scene and
+// returns an application that contains them. The window of the application
+// has to be set by the caller. The shaders are created with the given factory,
+// so it has to be called after the gl initialization.
+func (s *Scene) Build(newShader ShaderFactory) (*application.Application, error) {
+	built := &builtScene{
+		shaders: make(map[string]ShaderProgram),
+	}
+	app := application.New()
+	if s.Camera != nil {
+		c := camera.NewCamera(s.Camera.Position, s.Camera.WorldUp, s.Camera.Yaw, s.Camera.Pitch)
+		c.SetupProjection(s.Camera.Fov, s.Camera.AspectRatio, s.Camera.Near, s.Camera.Far)
+		app.SetCamera(c)
+		built.camera = c
+	}
+	for _, sh := range s.Shaders {
+		if _, ok := built.shaders[sh.Name]; ok {
+			return nil, fmt.Errorf("Duplicated shader name '%s'.", sh.Name)
+		}
+		program := newShader(sh.Vertex, sh.Fragment)
+		for _, t := range sh.Textures {
+			params, err := textureParameters(t)
+			if err != nil {
+				return nil, err
+			}
+			program.AddTexture(t.Path, params[0], params[1], params[2], params[3], t.Uniform)
+		}
+		built.shaders[sh.Name] = program
+	}
+	for i, l := range s.Lights {
+		lightSource, err := buildLight(l, built.shaders)
+		if err != nil {
+			return nil, fmt.Errorf("Light %d: %s", i, err.Error())
+		}
+		built.lights = append(built.lights, lightSource)
+	}
+	for i, p := range s.Primitives {
+		item, err := buildPrimitive(p, built.shaders)
+		if err != nil {
+			return nil, fmt.Errorf("Primitive %d: %s", i, err.Error())
+		}
+		app.AddItem(item)
+		built.primitives = append(built.primitives, item)
+	}
+	s.built = built
+	s.UpdateViewPosition()
+	return app, nil
+}
+
+// GetItems returns the built primitives in the order of the description. It's
+// empty before the Build.
+func (s *Scene) GetItems() []application.Drawable {
+	if s.built == nil {
+		return nil
+	}
+	return append([]application.Drawable{}, s.built.primitives...)
+}
+
+// UpdateViewPosition sets the current camera position to the shaders
+// that have view position uniform name. It has to be called after the
+// camera movement.
+func (s *Scene) UpdateViewPosition() {
+	if s.built == nil || s.built.camera == nil {
+		return
+	}
+	for _, sh := range s.Shaders {
+		if sh.ViewPositionUniform == "" {
+			continue
+		}
+		s.built.shaders[sh.Name].SetViewPosition(s.built.camera.GetPosition(), sh.ViewPositionUniform)
+	}
+}
+
+func textureParameters(t Texture) ([4]int32, error) {
+	var result [4]int32
+	for i, name := range []string{t.WrapR, t.WrapS, t.MinFilter, t.MagFilter} {
+		value, ok := textureParams[strings.ToLower(name)]
+		if !ok {
+			return result, fmt.Errorf("Invalid texture parameter '%s'.", name)
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+func buildLight(l Light, shaders map[string]ShaderProgram) (*light.Light, error) {
+	var lightSource *light.Light
+	var expectedUniforms int
+	switch l.Type {
+	case "directional":
+		lightSource = light.NewDirectionalLight([4]mgl32.Vec3{l.Direction, l.Ambient, l.Diffuse, l.Specular})
+		expectedUniforms = 4
+	case "point":
+		lightSource = light.NewPointLight([4]mgl32.Vec3{l.Position, l.Ambient, l.Diffuse, l.Specular},
+			[3]float32{l.ConstantTerm, l.LinearTerm, l.QuadraticTerm})
+		expectedUniforms = 7
+	case "spot":
+		lightSource = light.NewSpotLight([5]mgl32.Vec3{l.Position, l.Direction, l.Ambient, l.Diffuse, l.Specular},
+			[5]float32{l.ConstantTerm, l.LinearTerm, l.QuadraticTerm, l.Cutoff, l.OuterCutoff})
+		expectedUniforms = 10
+	default:
+		return nil, fmt.Errorf("Invalid light type '%s'.", l.Type)
+	}
+	if len(l.Uniforms) != expectedUniforms {
+		return nil, fmt.Errorf("Invalid number of uniforms. Instead of '%d', we have '%d'.", expectedUniforms, len(l.Uniforms))
+	}
+	for _, name := range l.Shaders {
+		program, ok := shaders[name]
+		if !ok {
+			return nil, fmt.Errorf("Missing shader '%s'.", name)
+		}
+		switch expectedUniforms {
+		case 4:
+			var uniforms [4]string
+			copy(uniforms[:], l.Uniforms)
+			program.AddDirectionalLightSource(lightSource, uniforms)
+		case 7:
+			var uniforms [7]string
+			copy(uniforms[:], l.Uniforms)
+			program.AddPointLightSource(lightSource, uniforms)
+		case 10:
+			var uniforms [10]string
+			copy(uniforms[:], l.Uniforms)
+			program.AddSpotLightSource(lightSource, uniforms)
+		}
+	}
+	return lightSource, nil
+}
+
+func buildMaterial(m *Material) (*material.Material, error) {
+	if m.Preset != "" {
+		mat, ok := material.ByName(m.Preset)
+		if !ok {
+			return nil, fmt.Errorf("Invalid material preset '%s'.", m.Preset)
+		}
+		return mat, nil
+	}
+	return material.New(m.Ambient, m.Diffuse, m.Specular, m.Shininess), nil
+}
+
+// loadMesh is the mesh loader of the model primitives. It's a variable, so
+// that the tests could replace it.
+var loadMesh = model.LoadFile
+
+func buildPrimitive(p Primitive, shaders map[string]ShaderProgram) (application.Drawable, error) {
+	program, ok := shaders[p.Shader]
+	if !ok {
+		return nil, fmt.Errorf("Missing shader '%s'.", p.Shader)
+	}
+	modes := drawModes
+	if p.Type == "model" {
+		modes = modelDrawModes
+	}
+	mode, ok := modes[p.DrawMode]
+	if !ok {
+		return nil, fmt.Errorf("Invalid draw mode '%s'.", p.DrawMode)
+	}
+	var mat *material.Material
+	if p.Material != nil {
+		var err error
+		if mat, err = buildMaterial(p.Material); err != nil {
+			return nil, err
+		}
+	}
+	switch p.Type {
+	case "triangle":
+		if len(p.Coordinates) != 3 || len(p.Colors) != 3 {
+			return nil, fmt.Errorf("Triangle needs 3 coordinates and 3 colors.")
+		}
+		var coordinates, colors [3]mgl32.Vec3
+		copy(coordinates[:], p.Coordinates)
+		copy(colors[:], p.Colors)
+		t := triangle.New(coordinates, colors, program)
+		t.SetDirection(p.Direction)
+		t.SetSpeed(p.Speed)
+		return t, nil
+	case "rectangle", "square":
+		var r *rectangle.Rectangle
+		if p.Type == "square" {
+			if len(p.Coordinates) != 2 {
+				return nil, fmt.Errorf("Square needs 2 diagonal coordinates.")
+			}
+			r = rectangle.NewSquare(p.Coordinates[0], p.Coordinates[1], p.Normal, p.Color, program)
+		} else {
+			if len(p.Coordinates) != 4 || len(p.Colors) != 4 {
+				return nil, fmt.Errorf("Rectangle needs 4 coordinates and 4 colors.")
+			}
+			var coordinates, colors [4]mgl32.Vec3
+			copy(coordinates[:], p.Coordinates)
+			copy(colors[:], p.Colors)
+			r = rectangle.New(coordinates, colors, program)
+		}
+		if p.Precision > 0 {
+			r.SetPrecision(p.Precision)
+		}
+		r.SetDirection(p.Direction)
+		r.SetSpeed(p.Speed)
+		r.SetAngle(p.Angle)
+		r.SetAxis(p.Axis)
+		r.DrawMode(mode)
+		return r, nil
+	case "cuboid":
+		if len(p.Coordinates) != 4 || len(p.Colors) != 4 {
+			return nil, fmt.Errorf("Cuboid needs 4 coordinates and 4 colors.")
+		}
+		var coordinates, colors [4]mgl32.Vec3
+		copy(coordinates[:], p.Coordinates)
+		copy(colors[:], p.Colors)
+		c := cuboid.New(rectangle.New(coordinates, colors, program), p.Height, program)
+		if p.Precision > 0 {
+			c.SetPrecision(p.Precision)
+		}
+		if mat != nil {
+			c.SetMaterial(mat)
+		}
+		c.SetDirection(p.Direction)
+		c.SetSpeed(p.Speed)
+		c.SetAngle(p.Angle)
+		c.SetAxis(p.Axis)
+		c.DrawMode(mode)
+		return c, nil
+	case "sphere":
+		s := sphere.New(p.Center, p.Color, p.Radius, program)
+		if p.Precision > 0 {
+			s.SetPrecision(p.Precision)
+		}
+		if mat != nil {
+			s.SetMaterial(mat)
+		}
+		s.SetDirection(p.Direction)
+		s.SetSpeed(p.Speed)
+		s.SetAngle(p.Angle)
+		s.SetAxis(p.Axis)
+		s.DrawMode(mode)
+		return s, nil
+	case "points":
+		points := point.New(program)
+		for _, pt := range p.Points {
+			item := points.Add(pt.Position, pt.Color, pt.Size)
+			item.SetDirection(p.Direction)
+			item.SetSpeed(p.Speed)
+		}
+		return points, nil
+	case "model":
+		mesh, err := loadMesh(p.Path)
+		if err != nil {
+			return nil, err
+		}
+		m := model.New(mesh, program)
+		m.SetPosition(p.Position)
+		if p.Scale != 0 {
+			m.SetScale(p.Scale)
+		}
+		if p.Color != (mgl32.Vec3{}) {
+			m.SetColor(p.Color)
+		}
+		if mat != nil {
+			m.SetMaterial(mat)
+		}
+		m.SetDirection(p.Direction)
+		m.SetSpeed(p.Speed)
+		m.SetAngle(p.Angle)
+		m.SetAxis(p.Axis)
+		m.DrawMode(mode)
+		return m, nil
+	}
+	return nil, fmt.Errorf("Invalid primitive type '%s'.", p.Type)
+}
+
+// sync updates the description with the current state of the built objects.
+func (s *Scene) sync() {
+	if s.built == nil {
+		return
+	}
+	if s.built.camera != nil && s.Camera != nil {
+		c := s.built.camera
+		s.Camera.Position = c.GetPosition()
+		s.Camera.WorldUp = c.GetWorldUp()
+		s.Camera.Yaw = c.GetYaw()
+		s.Camera.Pitch = c.GetPitch()
+		s.Camera.Fov, s.Camera.AspectRatio, s.Camera.Near, s.Camera.Far = c.GetProjection()
+	}
+	for i, l := range s.built.lights {
+		d := &s.Lights[i]
+		d.Position = l.GetPosition()
+		d.Direction = l.GetDirection()
+		d.Ambient = l.GetAmbient()
+		d.Diffuse = l.GetDiffuse()
+		d.Specular = l.GetSpecular()
+		d.ConstantTerm = l.GetConstantTerm()
+		d.LinearTerm = l.GetLinearTerm()
+		d.QuadraticTerm = l.GetQuadraticTerm()
+		d.Cutoff = l.GetCutoff()
+		d.OuterCutoff = l.GetOuterCutoff()
+	}
+	for i, item := range s.built.primitives {
+		p := &s.Primitives[i]
+		switch v := item.(type) {
+		case *triangle.Triangle:
+			coordinates := v.Coordinates()
+			p.Coordinates = coordinates[:]
+			p.Direction = v.GetDirection()
+			p.Speed = v.GetSpeed()
+		case *rectangle.Rectangle:
+			coordinates := v.Coordinates()
+			if p.Type == "square" {
+				p.Coordinates = []mgl32.Vec3{coordinates[1], coordinates[3]}
+			} else {
+				p.Coordinates = coordinates[:]
+			}
+			p.Direction = v.GetDirection()
+			p.Speed = v.GetSpeed()
+			p.Angle = v.GetAngle()
+			p.Axis = v.GetAxis()
+		case *cuboid.Cuboid:
+			coordinates := v.Coordinates()
+			p.Coordinates = coordinates[:]
+			p.Direction = v.GetDirection()
+			p.Speed = v.GetSpeed()
+			p.Angle = v.GetAngle()
+			p.Axis = v.GetAxis()
+			p.Material = dumpMaterial(v.GetMaterial())
+		case *sphere.Sphere:
+			p.Center = v.GetCenter()
+			p.Radius = v.GetRadius()
+			p.Color = v.GetColor()
+			p.Direction = v.GetDirection()
+			p.Speed = v.GetSpeed()
+			p.Angle = v.GetAngle()
+			p.Axis = v.GetAxis()
+			p.Material = dumpMaterial(v.GetMaterial())
+		case *point.Points:
+			for j := range p.Points {
+				p.Points[j].Position = v.Get(j).GetCoordinate()
+			}
+		case *model.Model:
+			p.Position = v.GetPosition()
+			p.Scale = v.GetScale()
+			p.Direction = v.GetDirection()
+			p.Speed = v.GetSpeed()
+			p.Angle = v.GetAngle()
+			p.Axis = v.GetAxis()
+			p.Material = dumpMaterial(v.GetMaterial())
+		}
+	}
+}
+
+// dumpMaterial returns the description of the given material. The predefined
+// materials are described with their preset names.
+func dumpMaterial(m *material.Material) *Material {
+	if name := material.PresetName(m); name != "" {
+		return &Material{Preset: name}
+	}
+	return &Material{
+		Ambient:   m.GetAmbient(),
+		Diffuse:   m.GetDiffuse(),
+		Specular:  m.GetSpecular(),
+		Shininess: m.GetShininess(),
+	}
+}
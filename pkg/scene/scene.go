@@ -0,0 +1,170 @@
+package scene
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Scene is the description of an application. It could be loaded from
+// a json file, and it could be saved to a json file. The Build function
+// creates the application based on the description.
+type Scene struct {
+	Camera     *Camera     `json:"camera,omitempty"`
+	Shaders    []Shader    `json:"shaders"`
+	Lights     []Light     `json:"lights"`
+	Primitives []Primitive `json:"primitives"`
+
+	// the built objects. They are used for dumping the current state.
+	built *builtScene
+}
+
+// Camera describes the camera setup. The fields are the inputs of the
+// camera.NewCamera and the camera.SetupProjection functions.
+type Camera struct {
+	Position    mgl32.Vec3 `json:"position"`
+	WorldUp     mgl32.Vec3 `json:"worldUp"`
+	Yaw         float32    `json:"yaw"`
+	Pitch       float32    `json:"pitch"`
+	Fov         float32    `json:"fov"`
+	AspectRatio float32    `json:"aspectRatio"`
+	Near        float32    `json:"near"`
+	Far         float32    `json:"far"`
+}
+
+// Shader describes a shader program. The Name is used as reference in the
+// lights and primitives.
+type Shader struct {
+	Name     string `json:"name"`
+	Vertex   string `json:"vertex"`
+	Fragment string `json:"fragment"`
+	// ViewPositionUniform is the name of the uniform that gets the camera position.
+	// If it's empty, the position isn't set.
+	ViewPositionUniform string    `json:"viewPositionUniform,omitempty"`
+	Textures            []Texture `json:"textures,omitempty"`
+}
+
+// Texture describes a texture of a shader. The wrap and filter values are
+// the lowercase names of the gl constants, eg: 'clamp_to_edge', 'linear'.
+type Texture struct {
+	Path      string `json:"path"`
+	Uniform   string `json:"uniform"`
+	WrapR     string `json:"wrapR"`
+	WrapS     string `json:"wrapS"`
+	MinFilter string `json:"minFilter"`
+	MagFilter string `json:"magFilter"`
+}
+
+// Light describes a light source. The Type could be 'directional', 'point' or 'spot'.
+// The light is attached to the shaders listed in Shaders with the given uniform names.
+// The number of the uniform names depends on the type (4 - directional, 7 - point, 10 - spot),
+// the order is the same as the order of the AddLightSource functions of the shader.
+type Light struct {
+	Type          string     `json:"type"`
+	Position      mgl32.Vec3 `json:"position"`
+	Direction     mgl32.Vec3 `json:"direction"`
+	Ambient       mgl32.Vec3 `json:"ambient"`
+	Diffuse       mgl32.Vec3 `json:"diffuse"`
+	Specular      mgl32.Vec3 `json:"specular"`
+	ConstantTerm  float32    `json:"constantTerm,omitempty"`
+	LinearTerm    float32    `json:"linearTerm,omitempty"`
+	QuadraticTerm float32    `json:"quadraticTerm,omitempty"`
+	Cutoff        float32    `json:"cutoff,omitempty"`
+	OuterCutoff   float32    `json:"outerCutoff,omitempty"`
+	Shaders       []string   `json:"shaders"`
+	Uniforms      []string   `json:"uniforms"`
+}
+
+// Material describes a material. If the Preset is set, the predefined
+// material is used (eg: 'emerald'), otherwise the inline components.
+type Material struct {
+	Preset    string     `json:"preset,omitempty"`
+	Ambient   mgl32.Vec3 `json:"ambient"`
+	Diffuse   mgl32.Vec3 `json:"diffuse"`
+	Specular  mgl32.Vec3 `json:"specular"`
+	Shininess float32    `json:"shininess"`
+}
+
+// Point describes one point of a 'points' primitive.
+type Point struct {
+	Position mgl32.Vec3 `json:"position"`
+	Color    mgl32.Vec3 `json:"color"`
+	Size     float32    `json:"size"`
+}
+
+// Primitive describes a drawable item. The Type could be 'triangle', 'rectangle',
+// 'square', 'cuboid', 'sphere', 'points' or 'model'. The used fields depend on the type:
+// - triangle: Coordinates (3), Colors (3)
+// - rectangle: Coordinates (4), Colors (4), Precision
+// - square: Coordinates (2 diagonal points), Normal, Color, Precision
+// - cuboid: Coordinates (4, the bottom side), Colors (4), Height, Precision, Material
+// - sphere: Center, Radius, Color, Precision, Material
+// - points: Points
+// - model: Path (obj, stl, gltf or glb file), Position, Scale (default 1), Color (default white), Material
+// The DrawMode could be 'color', 'light' or 'textured_light', the model supports
+// 'color', 'light' and 'normal'. The movement
+// (Direction, Speed) and the rotation (Angle in radian, Axis) are applied, where
+// the primitive supports them.
+type Primitive struct {
+	Type        string       `json:"type"`
+	Shader      string       `json:"shader"`
+	DrawMode    string       `json:"drawMode,omitempty"`
+	Coordinates []mgl32.Vec3 `json:"coordinates,omitempty"`
+	Colors      []mgl32.Vec3 `json:"colors,omitempty"`
+	Normal      mgl32.Vec3   `json:"normal"`
+	Color       mgl32.Vec3   `json:"color"`
+	Center      mgl32.Vec3   `json:"center"`
+	Radius      float32      `json:"radius,omitempty"`
+	Height      float32      `json:"height,omitempty"`
+	Precision   int          `json:"precision,omitempty"`
+	Material    *Material    `json:"material,omitempty"`
+	Points      []Point      `json:"points,omitempty"`
+	Path        string       `json:"path,omitempty"`
+	Position    mgl32.Vec3   `json:"position"`
+	Scale       float32      `json:"scale,omitempty"`
+	Direction   mgl32.Vec3   `json:"direction"`
+	Speed       float32      `json:"speed,omitempty"`
+	Angle       float32      `json:"angle,omitempty"`
+	Axis        mgl32.Vec3   `json:"axis"`
+}
+
+// Load decodes the scene from the given reader.
+func Load(r io.Reader) (*Scene, error) {
+	var s Scene
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LoadFile opens the given file and decodes the scene from it.
+func LoadFile(path string) (*Scene, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Save writes the scene to the given writer in json format. If the scene
+// has already been built, the description is updated with the current
+// state of the built objects (camera, light and primitive positions) first.
+func (s *Scene) Save(w io.Writer) error {
+	s.sync()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// SaveFile creates the given file and writes the scene to it.
+func (s *Scene) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.Save(f)
+}
@@ -0,0 +1,268 @@
+package scene
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/akosgarai/opengl_playground/pkg/model"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/cuboid"
+	"github.com/akosgarai/opengl_playground/pkg/primitives/material"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+)
+
+type testShader struct {
+	textures          int
+	directionalLights int
+	pointLights       int
+	spotLights        int
+	viewPosition      mgl32.Vec3
+}
+
+func (t *testShader) Use() {
+}
+func (t *testShader) SetUniformMat4(s string, m mgl32.Mat4) {
+}
+func (t *testShader) SetUniform3f(s string, f1, f2, f3 float32) {
+}
+func (t *testShader) SetUniform1f(s string, f1 float32) {
+}
+func (t *testShader) DrawTriangles(i int32) {
+}
+func (t *testShader) DrawPoints(i int32) {
+}
+func (t *testShader) Close(i int) {
+}
+func (t *testShader) VertexAttribPointer(i uint32, c int32, s int32, o int) {
+}
+func (t *testShader) BindVertexArray() {
+}
+func (t *testShader) BindBufferData(d []float32) {
+}
+func (t *testShader) HasTexture() bool {
+	return t.textures > 0
+}
+func (t *testShader) AddTexture(path string, wrapR, wrapS, minFilter, magFilter int32, uniform string) {
+	t.textures++
+}
+func (t *testShader) AddDirectionalLightSource(l shader.DirectionalLight, u [4]string) {
+	t.directionalLights++
+}
+func (t *testShader) AddPointLightSource(l shader.PointLight, u [7]string) {
+	t.pointLights++
+}
+func (t *testShader) AddSpotLightSource(l shader.SpotLight, u [10]string) {
+	t.spotLights++
+}
+func (t *testShader) SetViewPosition(p mgl32.Vec3, u string) {
+	t.viewPosition = p
+}
+
+var shaders map[string]*testShader
+
+func testFactory(vertexPath, fragmentPath string) ShaderProgram {
+	s := &testShader{}
+	shaders[vertexPath] = s
+	return s
+}
+
+const testScene = `{
+  "camera": {"position": [0, 0, -10], "worldUp": [0, 1, 0], "yaw": -90, "pitch": 0, "fov": 45, "aspectRatio": 1, "near": 0.1, "far": 100},
+  "shaders": [
+    {"name": "light", "vertex": "light.vert", "fragment": "light.frag", "viewPositionUniform": "viewPosition"},
+    {"name": "texture", "vertex": "texture.vert", "fragment": "texture.frag",
+     "textures": [{"path": "image.jpg", "uniform": "material.diffuse", "wrapR": "clamp_to_edge", "wrapS": "clamp_to_edge", "minFilter": "linear", "magFilter": "linear"}]}
+  ],
+  "lights": [
+    {"type": "point", "position": [1, 1, 1], "ambient": [1, 1, 1], "diffuse": [1, 1, 1], "specular": [1, 1, 1],
+     "constantTerm": 1, "linearTerm": 0.14, "quadraticTerm": 0.07, "shaders": ["light", "texture"],
+     "uniforms": ["pl.position", "pl.ambient", "pl.diffuse", "pl.specular", "pl.constant", "pl.linear", "pl.quadratic"]}
+  ],
+  "primitives": [
+    {"type": "sphere", "shader": "light", "drawMode": "light", "center": [0, 0, 0], "color": [1, 0, 0], "radius": 2, "material": {"preset": "emerald"},
+     "direction": [1, 0, 0], "speed": 1},
+    {"type": "cuboid", "shader": "texture", "drawMode": "textured_light", "height": 1,
+     "coordinates": [[-1, 0, -1], [1, 0, -1], [1, 0, 1], [-1, 0, 1]], "colors": [[1, 1, 1], [1, 1, 1], [1, 1, 1], [1, 1, 1]]},
+    {"type": "square", "shader": "light", "coordinates": [[-1, 0, -1], [1, 0, 1]], "normal": [0, 1, 0], "color": [0, 1, 0]},
+    {"type": "points", "shader": "light", "points": [{"position": [0, 1, 0], "color": [1, 1, 1], "size": 3}]}
+  ]
+}`
+
+func TestLoad(t *testing.T) {
+	s, err := Load(strings.NewReader(testScene))
+	if err != nil {
+		t.Fatalf("Load failed: %s", err.Error())
+	}
+	if s.Camera == nil || s.Camera.Position != (mgl32.Vec3{0, 0, -10}) {
+		t.Error("Invalid camera")
+	}
+	if len(s.Shaders) != 2 || len(s.Lights) != 1 || len(s.Primitives) != 4 {
+		t.Error("Invalid number of items")
+	}
+	if s.Primitives[0].Material.Preset != "emerald" {
+		t.Error("Invalid material")
+	}
+	if s.GetItems() != nil {
+		t.Error("The items should be empty before the build")
+	}
+	if _, err := Load(strings.NewReader("{")); err == nil {
+		t.Error("Invalid json should fail")
+	}
+}
+func TestBuild(t *testing.T) {
+	shaders = make(map[string]*testShader)
+	s, _ := Load(strings.NewReader(testScene))
+	app, err := s.Build(testFactory)
+	if err != nil {
+		t.Fatalf("Build failed: %s", err.Error())
+	}
+	if app.GetCamera() == nil {
+		t.Error("Missing camera")
+	}
+	if len(s.built.primitives) != 4 {
+		t.Errorf("Invalid number of primitives. Instead of '4', we have '%d'.", len(s.built.primitives))
+	}
+	if items := s.GetItems(); len(items) != 4 || items[0] != s.built.primitives[0] {
+		t.Error("Invalid items")
+	}
+	if shaders["texture.vert"].textures != 1 {
+		t.Error("Missing texture")
+	}
+	if shaders["light.vert"].pointLights != 1 || shaders["texture.vert"].pointLights != 1 {
+		t.Error("Missing point light")
+	}
+	if shaders["light.vert"].viewPosition != (mgl32.Vec3{0, 0, -10}) {
+		t.Error("View position hasn't been set")
+	}
+}
+func TestBuildErrors(t *testing.T) {
+	invalid := []string{
+		`{"shaders": [{"name": "a"}, {"name": "a"}]}`,
+		`{"shaders": [{"name": "a", "textures": [{"wrapR": "invalid"}]}]}`,
+		`{"lights": [{"type": "invalid"}]}`,
+		`{"shaders": [{"name": "a"}], "lights": [{"type": "directional", "shaders": ["a"], "uniforms": ["a"]}]}`,
+		`{"lights": [{"type": "directional", "shaders": ["missing"], "uniforms": ["a", "b", "c", "d"]}]}`,
+		`{"primitives": [{"type": "sphere", "shader": "missing"}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "invalid", "shader": "a"}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "sphere", "shader": "a", "drawMode": "invalid"}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "triangle", "shader": "a"}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "sphere", "shader": "a", "material": {"preset": "invalid"}}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "model", "shader": "a", "path": "missing.obj"}]}`,
+		`{"shaders": [{"name": "a"}], "primitives": [{"type": "model", "shader": "a", "path": "a.obj", "drawMode": "textured_light"}]}`,
+	}
+	for _, input := range invalid {
+		shaders = make(map[string]*testShader)
+		s, err := Load(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Load failed: %s", err.Error())
+		}
+		if _, err := s.Build(testFactory); err == nil {
+			t.Errorf("Build should fail for '%s'.", input)
+		}
+	}
+}
+func TestSave(t *testing.T) {
+	shaders = make(map[string]*testShader)
+	s, _ := Load(strings.NewReader(testScene))
+	app, _ := s.Build(testFactory)
+	app.Update(2)
+	app.GetCamera().Walk(5)
+	s.built.primitives[1].(*cuboid.Cuboid).SetMaterial(material.Ruby)
+	s.built.lights[0].SetDiffuse(mgl32.Vec3{0.5, 0.5, 0.5})
+	s.built.lights[0].SetLinearTerm(0.09)
+
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %s", err.Error())
+	}
+	saved, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load of the saved scene failed: %s", err.Error())
+	}
+	if saved.Primitives[0].Center != (mgl32.Vec3{2, 0, 0}) {
+		t.Errorf("Invalid sphere center '%v'.", saved.Primitives[0].Center)
+	}
+	if saved.Camera.Position == (mgl32.Vec3{0, 0, -10}) {
+		t.Error("Camera position hasn't been updated")
+	}
+	if len(saved.Primitives[2].Coordinates) != 2 {
+		t.Error("Square should be saved with 2 diagonal points")
+	}
+	if saved.Primitives[0].Material.Preset != "emerald" {
+		t.Error("Material preset has been lost")
+	}
+	if saved.Primitives[1].Material == nil || saved.Primitives[1].Material.Preset != "ruby" {
+		t.Error("Material preset hasn't been updated")
+	}
+	if l := saved.Lights[0]; l.Diffuse != (mgl32.Vec3{0.5, 0.5, 0.5}) || l.LinearTerm != 0.09 || l.QuadraticTerm != 0.07 {
+		t.Errorf("The light hasn't been updated '%v'.", l)
+	}
+}
+
+const movementScene = `{
+  "shaders": [{"name": "color", "vertex": "color.vert", "fragment": "color.frag"}],
+  "primitives": [
+    {"type": "model", "shader": "color", "drawMode": "normal", "path": "model.obj", "position": [1, 0, 0], "scale": 2,
+     "direction": [0, 1, 0], "speed": 1, "angle": 0.5, "axis": [0, 0, 1]},
+    {"type": "triangle", "shader": "color", "coordinates": [[0, 0, 0], [1, 0, 0], [0, 1, 0]], "colors": [[1, 1, 1], [1, 1, 1], [1, 1, 1]],
+     "direction": [1, 0, 0], "speed": 2},
+    {"type": "rectangle", "shader": "color", "coordinates": [[0, 0, 0], [1, 0, 0], [1, 1, 0], [0, 1, 0]],
+     "colors": [[1, 1, 1], [1, 1, 1], [1, 1, 1], [1, 1, 1]], "direction": [0, 0, 1], "speed": 3, "angle": 1, "axis": [0, 1, 0]}
+  ]
+}`
+
+func TestSaveMovement(t *testing.T) {
+	origLoadMesh := loadMesh
+	defer func() { loadMesh = origLoadMesh }()
+	var loadedPath string
+	loadMesh = func(path string) (*model.Mesh, error) {
+		loadedPath = path
+		return &model.Mesh{
+			Vertices: []mgl32.Vec3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Normals:  []mgl32.Vec3{{0, 0, 1}, {0, 0, 1}, {0, 0, 1}},
+		}, nil
+	}
+	shaders = make(map[string]*testShader)
+	s, _ := Load(strings.NewReader(movementScene))
+	app, err := s.Build(testFactory)
+	if err != nil {
+		t.Fatalf("Build failed: %s", err.Error())
+	}
+	if loadedPath != "model.obj" {
+		t.Errorf("Invalid model path '%s'.", loadedPath)
+	}
+	m := s.built.primitives[0].(*model.Model)
+	if m.GetDrawMode() != model.DRAW_MODE_NORMAL {
+		t.Error("Invalid model draw mode")
+	}
+	app.Update(2)
+
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %s", err.Error())
+	}
+	saved, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load of the saved scene failed: %s", err.Error())
+	}
+	mp := saved.Primitives[0]
+	if mp.Path != "model.obj" || mp.Position != (mgl32.Vec3{1, 2, 0}) || mp.Scale != 2 {
+		t.Errorf("Invalid model '%s', '%v', '%f'.", mp.Path, mp.Position, mp.Scale)
+	}
+	if mp.Direction != (mgl32.Vec3{0, 1, 0}) || mp.Speed != 1 || mp.Angle != 0.5 || mp.Axis != (mgl32.Vec3{0, 0, 1}) {
+		t.Error("Invalid model movement or rotation")
+	}
+	if mp.Material == nil || mp.Material.Preset != "" || mp.Material.Shininess != 36 {
+		t.Error("The inline material of the model should be saved")
+	}
+	tp := saved.Primitives[1]
+	if tp.Direction != (mgl32.Vec3{1, 0, 0}) || tp.Speed != 2 {
+		t.Error("Invalid triangle movement")
+	}
+	rp := saved.Primitives[2]
+	if rp.Direction != (mgl32.Vec3{0, 0, 1}) || rp.Speed != 3 || rp.Angle != 1 || rp.Axis != (mgl32.Vec3{0, 1, 0}) {
+		t.Error("Invalid rectangle movement or rotation")
+	}
+}
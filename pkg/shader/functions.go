@@ -36,6 +36,14 @@ func LoadShaderFromFile(path string) (string, error) {
 	return result, nil
 }
 
+// terminate returns the source with '\x00' ending.
+func terminate(source string) string {
+	if strings.HasSuffix(source, "\x00") {
+		return source
+	}
+	return source + "\x00"
+}
+
 // LoadImageFromFile takes a filepath string argument.
 // It loads the file, decodes it as PNG or jpg, and returns the image and error
 func loadImageFromFile(path string) (image.Image, error) {
@@ -5,6 +5,7 @@ import (
 	"image/draw"
 	_ "image/jpeg"
 	_ "image/png"
+	"strconv"
 
 	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
 	"github.com/go-gl/mathgl/mgl32"
@@ -18,6 +19,10 @@ type Shader struct {
 	spotLightSources        []SpotLightSource
 	viewPosition            mgl32.Vec3
 	viewPositionUniformName string
+	// the vertex array and the vertex buffers are reused by the draws.
+	vertexArrayObject   uint32
+	vertexBufferObjects []uint32
+	nextBuffer          int
 }
 
 // NewShader returns a Shader. It's inputs are the filenames of the shaders.
@@ -27,15 +32,22 @@ func NewShader(vertexShaderPath, fragmentShaderPath string) *Shader {
 	if err != nil {
 		panic(err)
 	}
-	vertexShader, err := CompileShader(vertexShaderSource, wrapper.VERTEX_SHADER)
+	fragmentShaderSource, err := LoadShaderFromFile(fragmentShaderPath)
 	if err != nil {
 		panic(err)
 	}
-	fragmentShaderSource, err := LoadShaderFromFile(fragmentShaderPath)
+	return NewShaderFromSource(vertexShaderSource, fragmentShaderSource)
+}
+
+// NewShaderFromSource returns a Shader. It's inputs are the source codes of the shaders.
+// The '\x00' terminator is appended to the sources if it's missing. It compiles the sources
+// and attaches them to the shader program.
+func NewShaderFromSource(vertexShaderSource, fragmentShaderSource string) *Shader {
+	vertexShader, err := CompileShader(terminate(vertexShaderSource), wrapper.VERTEX_SHADER)
 	if err != nil {
 		panic(err)
 	}
-	fragmentShader, err := CompileShader(fragmentShaderSource, wrapper.FRAGMENT_SHADER)
+	fragmentShader, err := CompileShader(terminate(fragmentShaderSource), wrapper.FRAGMENT_SHADER)
 	if err != nil {
 		panic(err)
 	}
@@ -61,7 +73,12 @@ func (s *Shader) AddTexture(filePath string, wrapR, wrapS, minificationFilter, m
 	if err != nil {
 		panic(err)
 	}
+	s.AddTextureFromImage(img, wrapR, wrapS, minificationFilter, magnificationFilter, uniformName)
+}
 
+// AddTextureFromImage sets up a texture from the given image. It could be used
+// for the generated images (eg: font atlas).
+func (s *Shader) AddTextureFromImage(img image.Image, wrapR, wrapS, minificationFilter, magnificationFilter int32, uniformName string) {
 	rgba := image.NewRGBA(img.Bounds())
 	draw.Draw(rgba, rgba.Bounds(), img, image.Pt(0, 0), draw.Src)
 	if rgba.Stride != rgba.Rect.Size().X*4 {
@@ -163,6 +180,38 @@ func (s *Shader) HasTexture() bool {
 	return false
 }
 
+// TextureKey returns the identity of the texture set of the shader for the
+// render queue. It's the list of the texture names, or nil if the shader
+// doesn't have texture.
+func (s *Shader) TextureKey() interface{} {
+	if !s.HasTexture() {
+		return nil
+	}
+	key := ""
+	for i, t := range s.textures {
+		if i > 0 {
+			key += ","
+		}
+		key += strconv.FormatUint(uint64(t.textureId), 10)
+	}
+	return key
+}
+
+// TextureKeyer is implemented by the shaders that expose the identity of their
+// texture set.
+type TextureKeyer interface {
+	TextureKey() interface{}
+}
+
+// TextureKeyOf returns the texture key of the given shader. It's nil if the
+// shader doesn't implement the TextureKeyer interface.
+func TextureKeyOf(s interface{}) interface{} {
+	if k, ok := s.(TextureKeyer); ok {
+		return k.TextureKey()
+	}
+	return nil
+}
+
 // Use is a wrapper for gl.UseProgram
 func (s *Shader) Use() {
 	wrapper.UseProgram(s.shaderProgramId)
@@ -196,18 +245,27 @@ func (s *Shader) SetUniform1f(uniformName string, v1 float32) {
 	wrapper.Uniform1f(location, v1)
 }
 
-// BindBufferData gets a float array as an input, generates a buffer
-// binds it as array buffer, and sets the input as buffer data.
+// BindBufferData gets a float array as an input, binds the next vertex
+// buffer of the shader as array buffer, and sets the input as buffer data.
+// The buffers are generated only for the first draw that needs them (eg: the
+// instanced draws use two buffers), the next draws upload their data to the
+// same buffers. The Close starts the buffers from the first one again.
 func (s *Shader) BindBufferData(bufferData []float32) {
-	vertexBufferObject := wrapper.GenBuffers()
-	wrapper.BindBuffer(wrapper.ARRAY_BUFFER, vertexBufferObject)
+	if s.nextBuffer == len(s.vertexBufferObjects) {
+		s.vertexBufferObjects = append(s.vertexBufferObjects, wrapper.GenBuffers())
+	}
+	wrapper.BindBuffer(wrapper.ARRAY_BUFFER, s.vertexBufferObjects[s.nextBuffer])
 	wrapper.ArrayBufferData(bufferData)
+	s.nextBuffer++
 }
 
-// BindVertexArray generates a vertex array and binds it.
+// BindVertexArray binds the vertex array of the shader. It's generated for
+// the first call. The attribute pointers have to be set after the binding.
 func (s *Shader) BindVertexArray() {
-	vertexArrayObject := wrapper.GenVertexArrays()
-	wrapper.BindVertexArray(vertexArrayObject)
+	if s.vertexArrayObject == 0 {
+		s.vertexArrayObject = wrapper.GenVertexArrays()
+	}
+	wrapper.BindVertexArray(s.vertexArrayObject)
 }
 
 // VertexAttribPointer sets the pointer.
@@ -215,16 +273,42 @@ func (s *Shader) VertexAttribPointer(index uint32, size, stride int32, offset in
 	wrapper.VertexAttribPointer(index, size, wrapper.FLOAT, false, stride, wrapper.PtrOffset(offset))
 }
 
-// Close disables the vertexarraypointers and the vertex array.
+// VertexAttribDivisor sets the divisor of the attribute. The attributes with
+// divisor 1 are per instance attributes.
+func (s *Shader) VertexAttribDivisor(index, divisor uint32) {
+	wrapper.VertexAttribDivisor(index, divisor)
+}
+
+// Close disables the vertexarraypointers from 0 to numOfVertexAttributes-1.
+// The vertex array is shared by the draws of the shader, so that every
+// enabled attribute has to be disabled. The vertex array and the textures
+// stay bound, the next draw binds its own ones, and the state cache of the
+// wrapper skips the redundant binds. The next draw uploads its data to the
+// first vertex buffer.
 func (s *Shader) Close(numOfVertexAttributes int) {
+	s.nextBuffer = 0
 	for i := 0; i < numOfVertexAttributes; i++ {
 		index := uint32(i)
 		wrapper.DisableVertexAttribArray(index)
 	}
-	for index, _ := range s.textures {
-		s.textures[index].UnBind()
+}
+
+// Delete deletes the vertex array, the vertex buffers and the textures of the
+// shader. The next draw generates a new vertex array and new buffers.
+func (s *Shader) Delete() {
+	if s.vertexArrayObject != 0 {
+		wrapper.DeleteVertexArrays(s.vertexArrayObject)
+		s.vertexArrayObject = 0
 	}
-	wrapper.BindVertexArray(0)
+	for _, buffer := range s.vertexBufferObjects {
+		wrapper.DeleteBuffers(buffer)
+	}
+	s.vertexBufferObjects = nil
+	s.nextBuffer = 0
+	for _, t := range s.textures {
+		wrapper.DeleteTextures(t.textureId)
+	}
+	s.textures = []texture{}
 }
 
 // Setup light related uniforms.
@@ -338,14 +422,33 @@ func (s *Shader) DrawPoints(numberOfPoints int32) {
 	wrapper.DrawArrays(wrapper.POINTS, 0, numberOfPoints)
 }
 
+// DrawLines is the draw function for line segments. Every 2 vertices form a segment.
+func (s *Shader) DrawLines(numberOfPoints int32) {
+	s.lightHandler()
+	wrapper.DrawArrays(wrapper.LINES, 0, numberOfPoints)
+}
+
 // DrawTriangles is the draw function for triangles
 func (s *Shader) DrawTriangles(numberOfPoints int32) {
+	s.bindTextures()
+	s.lightHandler()
+	wrapper.DrawArrays(wrapper.TRIANGLES, 0, numberOfPoints)
+}
+
+// bindTextures binds the textures to the texture units and sets the sampler uniforms.
+func (s *Shader) bindTextures() {
 	for index, _ := range s.textures {
 		s.textures[index].Bind(textureMap(index))
 		wrapper.Uniform1i(wrapper.GetUniformLocation(s.shaderProgramId, s.textures[index].uniformName), int32(s.textures[index].texUnitId-wrapper.TEXTURE0))
 	}
+}
+
+// DrawTrianglesInstanced is the draw function for instanced triangles. The
+// numberOfPoints triangle vertices are drawn instances times in one draw call.
+func (s *Shader) DrawTrianglesInstanced(numberOfPoints, instances int32) {
+	s.bindTextures()
 	s.lightHandler()
-	wrapper.DrawArrays(wrapper.TRIANGLES, 0, numberOfPoints)
+	wrapper.DrawArraysInstanced(wrapper.TRIANGLES, 0, numberOfPoints, instances)
 }
 
 // TexParameteri is a wrapper function for gl.TexParameteri
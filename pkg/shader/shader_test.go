@@ -335,6 +335,27 @@ func TestNewShaderPanicOnVertexFile(t *testing.T) {
 		NewShader(VertexShaderFileName, FragmentShaderFileName)
 	}()
 }
+func TestTerminate(t *testing.T) {
+	if terminate("source") != "source\x00" {
+		t.Error("Missing terminator")
+	}
+	if terminate("source\x00") != "source\x00" {
+		t.Error("Duplicated terminator")
+	}
+}
+func TestNewShaderFromSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping it in short mode")
+	}
+	runtime.LockOSThread()
+	InitGlfw()
+	defer glfw.Terminate()
+	wrapper.InitOpenGL()
+	shader := NewShaderFromSource(ValidVertexShaderWithUniformsString, ValidFragmentShaderString)
+	if shader.shaderProgramId == 0 {
+		t.Error("Invalid shader program id")
+	}
+}
 func TestNewShader(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping it in short mode")
@@ -502,6 +523,29 @@ func TestBindVertexArray(t *testing.T) {
 	shader.BindBufferData(bufferData)
 	shader.BindVertexArray()
 }
+func TestBufferReuse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping it in short mode")
+	}
+	runtime.LockOSThread()
+	shader := NewTestShader(t, ValidTextureFragmentShader, ValidTextureVertexShader)
+	defer glfw.Terminate()
+	bufferData := []float32{0, 0, 0, 1, 1, 1}
+	for i := 0; i < 3; i++ {
+		shader.BindBufferData(bufferData)
+		shader.BindVertexArray()
+		shader.BindBufferData(bufferData)
+		shader.Close(1)
+	}
+	vao := shader.vertexArrayObject
+	if vao == 0 || len(shader.vertexBufferObjects) != 2 {
+		t.Errorf("Invalid number of buffers '%d'.", len(shader.vertexBufferObjects))
+	}
+	shader.Delete()
+	if shader.vertexArrayObject != 0 || len(shader.vertexBufferObjects) != 0 {
+		t.Error("The vertex array and the buffers should be deleted")
+	}
+}
 func TestVertexAttribPointer(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping it in short mode")
@@ -544,6 +588,21 @@ func TestDrawPoints(t *testing.T) {
 	shader.DrawPoints(1)
 	shader.Close(2)
 }
+func TestDrawLines(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping it in short mode")
+	}
+	runtime.LockOSThread()
+	shader := NewTestShader(t, ValidTextureFragmentShader, ValidTextureVertexShader)
+	defer glfw.Terminate()
+	bufferData := []float32{0, 0, 0, 1, 1, 1, 1, 0, 0, 1, 1, 1}
+	shader.BindBufferData(bufferData)
+	shader.BindVertexArray()
+	shader.VertexAttribPointer(uint32(0), int32(3), int32(6*4), 0)
+	shader.VertexAttribPointer(uint32(1), int32(3), int32(6*4), 3*4)
+	shader.DrawLines(2)
+	shader.Close(2)
+}
 func TestDrawPointsLightColor(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping it in short mode")
@@ -577,6 +636,24 @@ func TestDrawTriangles(t *testing.T) {
 	shader.DrawTriangles(1)
 	shader.Close(1)
 }
+func TestDrawTrianglesInstanced(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping it in short mode")
+	}
+	runtime.LockOSThread()
+	shader := NewTestShader(t, ValidTextureFragmentShader, ValidTextureVertexShader)
+	defer glfw.Terminate()
+	bufferData := []float32{0, 0, 0, 1, 1, 1, 1, 0, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1}
+	shader.BindBufferData(bufferData)
+	shader.BindVertexArray()
+	shader.VertexAttribPointer(uint32(0), int32(3), int32(6*4), 0)
+	instanceData := []float32{1, 0, 0, 0, 1, 0}
+	shader.BindBufferData(instanceData)
+	shader.VertexAttribPointer(uint32(1), int32(3), int32(3*4), 0)
+	shader.VertexAttribDivisor(uint32(1), uint32(1))
+	shader.DrawTrianglesInstanced(3, 2)
+	shader.Close(2)
+}
 func TestDrawTrianglesLight(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping it in short mode")
@@ -726,6 +803,19 @@ func TestHasTexture(t *testing.T) {
 		t.Error("it has texture")
 	}
 }
+func TestTextureKey(t *testing.T) {
+	s := &Shader{}
+	if s.TextureKey() != nil || TextureKeyOf(s) != nil {
+		t.Error("Shader without texture should have nil key")
+	}
+	s.textures = []texture{{textureId: 3}, {textureId: 5}}
+	if s.TextureKey() != "3,5" || TextureKeyOf(s) != "3,5" {
+		t.Errorf("Invalid texture key '%v'.", s.TextureKey())
+	}
+	if TextureKeyOf(struct{}{}) != nil {
+		t.Error("The key of the other shaders should be nil")
+	}
+}
 func TestLightDiffuseColor(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping it in short mode")
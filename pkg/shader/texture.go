@@ -22,7 +22,13 @@ func (t *texture) IsBinded() bool {
 	}
 	return true
 }
+
+// UnBind binds the default texture (name 0) to the texture unit of the
+// texture. The unit id isn't a texture name, it can't be passed to BindTexture.
 func (t *texture) UnBind() {
+	if t.texUnitId != 0 {
+		wrapper.ActiveTexture(t.texUnitId)
+	}
+	wrapper.BindTexture(t.targetId, 0)
 	t.texUnitId = 0
-	wrapper.BindTexture(t.targetId, t.texUnitId)
 }
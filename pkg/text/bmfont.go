@@ -0,0 +1,207 @@
+package text
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PageLoader returns the page image of the given file name.
+type PageLoader func(string) (image.Image, error)
+
+// bmLine is a parsed line of the text format BMFont descriptor.
+type bmLine struct {
+	tag    string
+	values map[string]string
+}
+
+func (l bmLine) int(key string) int {
+	v, _ := strconv.Atoi(l.values[key])
+	return v
+}
+func (l bmLine) float(key string) float32 {
+	v, _ := strconv.ParseFloat(l.values[key], 32)
+	return float32(v)
+}
+
+// parseBMLine splits the line to the tag and the key=value pairs. The
+// values could be quoted, the quoted values could contain spaces.
+func parseBMLine(line string) bmLine {
+	result := bmLine{values: make(map[string]string)}
+	line = strings.TrimSpace(line)
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		result.tag = line[:i]
+		line = line[i+1:]
+	} else {
+		result.tag = line
+		return result
+	}
+	for len(line) > 0 {
+		line = strings.TrimLeft(line, " \t")
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			break
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+		var value string
+		if strings.HasPrefix(line, "\"") {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				value, line = line[1:], ""
+			} else {
+				value, line = line[1:end+1], line[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				value, line = line, ""
+			} else {
+				value, line = line[:end], line[end:]
+			}
+		}
+		result.values[key] = value
+	}
+	return result
+}
+
+// LoadBMFont reads a text format BMFont descriptor (.fnt). The pages are
+// loaded with the loader. The pages are merged to a single atlas, they are
+// placed under each other.
+func LoadBMFont(r io.Reader, loader PageLoader) (*Font, error) {
+	var font *Font
+	pageFiles := make(map[int]string)
+	var chars []bmLine
+	var kernings []bmLine
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := parseBMLine(scanner.Text())
+		switch line.tag {
+		case "common":
+			font = newFont(line.float("lineHeight"), line.float("base"))
+		case "page":
+			pageFiles[line.int("id")] = line.values["file"]
+		case "char":
+			chars = append(chars, line)
+		case "kerning":
+			kernings = append(kernings, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if font == nil {
+		return nil, fmt.Errorf("Missing 'common' line.")
+	}
+	if len(pageFiles) == 0 {
+		return nil, fmt.Errorf("Missing 'page' line.")
+	}
+	// the y offsets of the pages in the atlas.
+	pageOffsets := make(map[int]int)
+	var pages []image.Image
+	width, height := 0, 0
+	for id := 0; id < len(pageFiles); id++ {
+		file, ok := pageFiles[id]
+		if !ok {
+			return nil, fmt.Errorf("Missing page '%d'.", id)
+		}
+		page, err := loader(file)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+		pageOffsets[id] = height
+		height += page.Bounds().Dy()
+		if page.Bounds().Dx() > width {
+			width = page.Bounds().Dx()
+		}
+	}
+	font.atlas = image.NewRGBA(image.Rect(0, 0, width, height))
+	for id, page := range pages {
+		mask := pageMask(page)
+		b := page.Bounds()
+		for y := 0; y < b.Dy(); y++ {
+			for x := 0; x < b.Dx(); x++ {
+				font.atlas.Set(x, pageOffsets[id]+y, color.NRGBA{255, 255, 255, mask.AlphaAt(x, y).A})
+			}
+		}
+	}
+	for _, c := range chars {
+		offset, ok := pageOffsets[c.int("page")]
+		if !ok {
+			return nil, fmt.Errorf("Invalid page of the character '%d'.", c.int("id"))
+		}
+		font.glyphs[rune(c.int("id"))] = Glyph{
+			X:        c.int("x"),
+			Y:        c.int("y") + offset,
+			Width:    c.int("width"),
+			Height:   c.int("height"),
+			XOffset:  c.float("xoffset"),
+			YOffset:  c.float("yoffset"),
+			XAdvance: c.float("xadvance"),
+		}
+	}
+	for _, k := range kernings {
+		font.kerning[[2]rune{rune(k.int("first")), rune(k.int("second"))}] = k.float("amount")
+	}
+	return font, nil
+}
+
+// LoadBMFontFile reads the BMFont descriptor file. The pages are loaded
+// from the directory of the descriptor.
+func LoadBMFontFile(path string) (*Font, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dir := filepath.Dir(path)
+	return LoadBMFont(f, func(file string) (image.Image, error) {
+		pageFile, err := os.Open(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		defer pageFile.Close()
+		img, _, err := image.Decode(pageFile)
+		return img, err
+	})
+}
+
+// pageMask returns the coverage of the page. If the page has transparent
+// pixels, the alpha channel is the coverage (white glyphs on transparent
+// background), otherwise the luminance (white glyphs on black background).
+func pageMask(page image.Image) *image.Alpha {
+	b := page.Bounds()
+	mask := image.NewAlpha(image.Rect(0, 0, b.Dx(), b.Dy()))
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y && opaque; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := page.At(x, y).RGBA(); a != 0xffff {
+				opaque = false
+				break
+			}
+		}
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := page.At(x, y)
+			var coverage uint8
+			if opaque {
+				coverage = color.GrayModel.Convert(c).(color.Gray).Y
+			} else {
+				_, _, _, a := c.RGBA()
+				coverage = uint8(a >> 8)
+			}
+			mask.SetAlpha(x-b.Min.X, y-b.Min.Y, color.Alpha{coverage})
+		}
+	}
+	return mask
+}
@@ -0,0 +1,181 @@
+package text
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Glyph stores the atlas position and the metrics of a character in pixels.
+type Glyph struct {
+	// the rectangle of the glyph in the atlas.
+	X, Y, Width, Height int
+	// the offset of the glyph image from the pen position. The y offset
+	// is measured from the top of the line.
+	XOffset, YOffset float32
+	// the distance of the pen movement after the glyph.
+	XAdvance float32
+}
+
+// Font is a bitmap font. The glyphs are stored in a single atlas image.
+type Font struct {
+	glyphs  map[rune]Glyph
+	kerning map[[2]rune]float32
+	// the distance between the lines and the distance of the baseline
+	// from the top of the line in pixels.
+	lineHeight float32
+	base       float32
+	atlas      *image.RGBA
+}
+
+// Quad is the screen rectangle of a glyph, relative to the origin of the
+// text (top left corner, y axis goes down), and its texture coordinates.
+type Quad struct {
+	X, Y, Width, Height float32
+	U0, V0, U1, V1      float32
+}
+
+func newFont(lineHeight, base float32) *Font {
+	return &Font{
+		glyphs:     make(map[rune]Glyph),
+		kerning:    make(map[[2]rune]float32),
+		lineHeight: lineHeight,
+		base:       base,
+	}
+}
+
+// GetLineHeight returns the distance between the lines in pixels.
+func (f *Font) GetLineHeight() float32 {
+	return f.lineHeight
+}
+
+// GetBase returns the distance of the baseline from the top of the line in pixels.
+func (f *Font) GetBase() float32 {
+	return f.base
+}
+
+// GetAtlas returns the atlas image. The glyphs are white, the coverage is stored in the alpha channel.
+func (f *Font) GetAtlas() *image.RGBA {
+	return f.atlas
+}
+
+// GetGlyph returns the glyph of the given character.
+func (f *Font) GetGlyph(r rune) (Glyph, bool) {
+	g, ok := f.glyphs[r]
+	return g, ok
+}
+
+// GetKerning returns the extra distance between the given characters in pixels.
+func (f *Font) GetKerning(first, second rune) float32 {
+	return f.kerning[[2]rune{first, second}]
+}
+
+// glyph returns the glyph of the character. The missing characters are replaced
+// with '?' if it's in the font.
+func (f *Font) glyph(r rune) (Glyph, rune, bool) {
+	if g, ok := f.glyphs[r]; ok {
+		return g, r, true
+	}
+	g, ok := f.glyphs['?']
+	return g, '?', ok
+}
+
+// Layout returns the quads of the text. The kerning is applied between the
+// characters, the '\n' starts a new line.
+func (f *Font) Layout(text string) []Quad {
+	var quads []Quad
+	var penX, penY float32
+	var previous rune
+	atlasWidth := float32(f.atlas.Bounds().Dx())
+	atlasHeight := float32(f.atlas.Bounds().Dy())
+	for _, r := range text {
+		if r == '\n' {
+			penX = 0
+			penY += f.lineHeight
+			previous = 0
+			continue
+		}
+		g, current, ok := f.glyph(r)
+		if !ok {
+			continue
+		}
+		if previous != 0 {
+			penX += f.GetKerning(previous, current)
+		}
+		if g.Width > 0 && g.Height > 0 {
+			quads = append(quads, Quad{
+				X:      penX + g.XOffset,
+				Y:      penY + g.YOffset,
+				Width:  float32(g.Width),
+				Height: float32(g.Height),
+				U0:     float32(g.X) / atlasWidth,
+				V0:     float32(g.Y) / atlasHeight,
+				U1:     float32(g.X+g.Width) / atlasWidth,
+				V1:     float32(g.Y+g.Height) / atlasHeight,
+			})
+		}
+		penX += g.XAdvance
+		previous = current
+	}
+	return quads
+}
+
+// Measure returns the width and the height of the text in pixels.
+func (f *Font) Measure(text string) (float32, float32) {
+	var width, lineWidth float32
+	lines := 1
+	var previous rune
+	for _, r := range text {
+		if r == '\n' {
+			lines++
+			lineWidth = 0
+			previous = 0
+			continue
+		}
+		g, current, ok := f.glyph(r)
+		if !ok {
+			continue
+		}
+		if previous != 0 {
+			lineWidth += f.GetKerning(previous, current)
+		}
+		lineWidth += g.XAdvance
+		if lineWidth > width {
+			width = lineWidth
+		}
+		previous = current
+	}
+	return width, float32(lines) * f.lineHeight
+}
+
+// atlasPacker places the images to rows (shelves) of the atlas.
+type atlasPacker struct {
+	width, padding  int
+	x, y, rowHeight int
+}
+
+// place returns the position of an image with the given size.
+func (p *atlasPacker) place(width, height int) (int, int) {
+	if p.x+width+p.padding > p.width {
+		p.x = 0
+		p.y += p.rowHeight + p.padding
+		p.rowHeight = 0
+	}
+	x, y := p.x, p.y
+	p.x += width + p.padding
+	if height > p.rowHeight {
+		p.rowHeight = height
+	}
+	return x, y
+}
+
+// height returns the used height of the atlas.
+func (p *atlasPacker) height() int {
+	return p.y + p.rowHeight
+}
+
+// whiteMask returns an RGBA image with white color and the alpha of the mask.
+func whiteMask(mask image.Image, size image.Point) *image.RGBA {
+	img := image.NewRGBA(image.Rectangle{Max: size})
+	draw.DrawMask(img, img.Bounds(), image.White, image.Point{}, mask, image.Point{}, draw.Src)
+	return img
+}
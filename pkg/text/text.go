@@ -0,0 +1,238 @@
+package text
+
+import (
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+	trans "github.com/akosgarai/opengl_playground/pkg/primitives/transformations"
+	"github.com/akosgarai/opengl_playground/pkg/shader"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// DRAW_MODE_SCREEN draws the text in screen space with orthographic
+	// projection. The position is in pixels, from the top left corner.
+	DRAW_MODE_SCREEN = 0
+	// DRAW_MODE_BILLBOARD draws the text in world space, it always faces the camera.
+	DRAW_MODE_BILLBOARD = 1
+
+	// the depth of the screen mode text. It's the nearest depth of the
+	// orthographic projection, so that the text is drawn on top of the scene.
+	screenDepth = float32(1.0)
+
+	VERTEX_SHADER = `
+#version 410
+layout(location = 0) in vec3 vVertex;
+layout(location = 1) in vec2 vTexCoord;
+out vec2 texCoord;
+uniform mat4 model;
+uniform mat4 view;
+uniform mat4 projection;
+void main()
+{
+    gl_Position = projection * view * model * vec4(vVertex, 1.0);
+    texCoord = vTexCoord;
+}
+`
+	FRAGMENT_SHADER = `
+#version 410
+in vec2 texCoord;
+out vec4 FragColor;
+uniform sampler2D fontAtlas;
+uniform vec3 textColor;
+void main()
+{
+    float alpha = texture(fontAtlas, texCoord).a;
+    if (alpha < 0.5) {
+        discard;
+    }
+    FragColor = vec4(textColor, 1.0);
+}
+`
+)
+
+type Shader interface {
+	Use()
+	SetUniformMat4(string, mgl32.Mat4)
+	SetUniform3f(string, float32, float32, float32)
+	DrawTriangles(int32)
+	Close(int)
+	VertexAttribPointer(uint32, int32, int32, int)
+	BindVertexArray()
+	BindBufferData([]float32)
+}
+
+// Text is a drawable string.
+type Text struct {
+	font   *Font
+	shader Shader
+
+	text     string
+	position mgl32.Vec3
+	color    mgl32.Vec3
+	// scale is the size of a font pixel. In screen mode it's in screen
+	// pixels, in billboard mode it's in world units.
+	scale    float32
+	drawMode int
+	// the size of the screen for the orthographic projection.
+	screenWidth  float32
+	screenHeight float32
+
+	vertices []float32
+}
+
+// NewShader returns the shader of the text with the atlas texture of the font.
+// It has to be called after the gl initialization.
+func NewShader(font *Font) *shader.Shader {
+	s := shader.NewShaderFromSource(VERTEX_SHADER, FRAGMENT_SHADER)
+	s.AddTextureFromImage(font.GetAtlas(), wrapper.CLAMP_TO_EDGE, wrapper.CLAMP_TO_EDGE, wrapper.LINEAR, wrapper.LINEAR, "fontAtlas")
+	return s
+}
+
+// New returns a white screen mode text with 800x800 screen size.
+func New(text string, font *Font, shader Shader) *Text {
+	return &Text{
+		font:         font,
+		shader:       shader,
+		text:         text,
+		position:     mgl32.Vec3{0, 0, 0},
+		color:        mgl32.Vec3{1, 1, 1},
+		scale:        1,
+		drawMode:     DRAW_MODE_SCREEN,
+		screenWidth:  800,
+		screenHeight: 800,
+	}
+}
+
+// Log returns the string representation of the text.
+func (t *Text) Log() string {
+	logString := "Text:\n"
+	logString += " - Text: '" + t.text + "'\n"
+	logString += " - Position: Vector{" + trans.Vec3ToString(t.position) + "}\n"
+	logString += " - Color: Vector{" + trans.Vec3ToString(t.color) + "}\n"
+	logString += " - Scale: " + trans.Float32ToString(t.scale) + "\n"
+	return logString
+}
+
+// SetText updates the displayed string.
+func (t *Text) SetText(text string) {
+	t.text = text
+}
+
+// GetText returns the displayed string.
+func (t *Text) GetText() string {
+	return t.text
+}
+
+// SetPosition updates the origin (top left corner) of the text. In screen
+// mode it's in pixels, in billboard mode it's in world coordinates.
+func (t *Text) SetPosition(position mgl32.Vec3) {
+	t.position = position
+}
+
+// GetPosition returns the origin of the text.
+func (t *Text) GetPosition() mgl32.Vec3 {
+	return t.position
+}
+
+// SetColor updates the color of the text.
+func (t *Text) SetColor(color mgl32.Vec3) {
+	t.color = color
+}
+
+// SetScale updates the size of a font pixel.
+func (t *Text) SetScale(scale float32) {
+	t.scale = scale
+}
+
+// DrawMode updates the draw mode of the text.
+func (t *Text) DrawMode(mode int) {
+	t.drawMode = mode
+}
+
+// SetScreenSize updates the screen size of the orthographic projection.
+func (t *Text) SetScreenSize(width, height float32) {
+	t.screenWidth = width
+	t.screenHeight = height
+}
+
+// Update does nothing, the text is static.
+func (t *Text) Update(dt float64) {
+}
+
+// buildVertices calculates the vertices (position, texture coordinates) of
+// the glyph quads. The right and down vectors are the directions of the
+// layout axes.
+func (t *Text) buildVertices(origin, right, down mgl32.Vec3) {
+	t.vertices = t.vertices[:0]
+	for _, q := range t.font.Layout(t.text) {
+		topLeft := origin.Add(right.Mul(q.X * t.scale)).Add(down.Mul(q.Y * t.scale))
+		width := right.Mul(q.Width * t.scale)
+		height := down.Mul(q.Height * t.scale)
+		corners := [6]struct {
+			p    mgl32.Vec3
+			u, v float32
+		}{
+			{topLeft, q.U0, q.V0},
+			{topLeft.Add(height), q.U0, q.V1},
+			{topLeft.Add(width).Add(height), q.U1, q.V1},
+			{topLeft, q.U0, q.V0},
+			{topLeft.Add(width).Add(height), q.U1, q.V1},
+			{topLeft.Add(width), q.U1, q.V0},
+		}
+		for _, c := range corners {
+			t.vertices = append(t.vertices, c.p.X(), c.p.Y(), c.p.Z(), c.u, c.v)
+		}
+	}
+}
+
+// draw sets the uniforms and draws the triangles.
+func (t *Text) draw(view, projection mgl32.Mat4) {
+	if len(t.vertices) == 0 {
+		return
+	}
+	t.shader.Use()
+	t.shader.SetUniformMat4("model", mgl32.Ident4())
+	t.shader.SetUniformMat4("view", view)
+	t.shader.SetUniformMat4("projection", projection)
+	t.shader.SetUniform3f("textColor", t.color.X(), t.color.Y(), t.color.Z())
+	t.shader.BindBufferData(t.vertices)
+	t.shader.BindVertexArray()
+	// setup points
+	t.shader.VertexAttribPointer(0, 3, 4*5, 0)
+	// setup texture coordinates
+	t.shader.VertexAttribPointer(1, 2, 4*5, 4*3)
+	t.shader.DrawTriangles(int32(len(t.vertices) / 5))
+	t.shader.Close(2)
+}
+
+// drawScreen draws the text with orthographic projection. The y axis goes down.
+func (t *Text) drawScreen() {
+	origin := mgl32.Vec3{t.position.X(), t.position.Y(), screenDepth}
+	t.buildVertices(origin, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, 1, 0})
+	t.draw(mgl32.Ident4(), mgl32.Ortho(0, t.screenWidth, t.screenHeight, 0, -1, 1))
+}
+
+// Draw draws the text. In billboard mode the view and the projection are identity matrices.
+func (t *Text) Draw() {
+	if t.drawMode == DRAW_MODE_SCREEN {
+		t.drawScreen()
+		return
+	}
+	t.buildVertices(t.position, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, -1, 0})
+	t.draw(mgl32.Ident4(), mgl32.Ident4())
+}
+
+// DrawWithUniforms draws the text. In screen mode the V & P matrices are
+// ignored. In billboard mode the layout axes are the right and the down
+// vectors of the camera, that are calculated from the view matrix.
+func (t *Text) DrawWithUniforms(view, projection mgl32.Mat4) {
+	if t.drawMode == DRAW_MODE_SCREEN {
+		t.drawScreen()
+		return
+	}
+	// the rows of the view matrix are the camera axes in world space.
+	right := mgl32.Vec3{view.At(0, 0), view.At(0, 1), view.At(0, 2)}
+	up := mgl32.Vec3{view.At(1, 0), view.At(1, 1), view.At(1, 2)}
+	t.buildVertices(t.position, right, up.Mul(-1))
+	t.draw(view, projection)
+}
@@ -0,0 +1,162 @@
+package text
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const testBMFont = `info face="Test" size=16 bold=0 italic=0 charset="" unicode=1 stretchH=100 smooth=1 aa=1 padding=0,0,0,0 spacing=1,1
+common lineHeight=16 base=12 scaleW=32 scaleH=16 pages=2 packed=0
+page id=0 file="test_0.png"
+page id=1 file="test 1.png"
+chars count=3
+char id=65   x=0     y=0     width=8     height=10    xoffset=0     yoffset=2     xadvance=9     page=0  chnl=15
+char id=86   x=8     y=0     width=8     height=10    xoffset=0     yoffset=2     xadvance=9     page=0  chnl=15
+char id=63   x=0     y=0     width=6     height=10    xoffset=1     yoffset=2     xadvance=8     page=1  chnl=15
+kernings count=1
+kerning first=65  second=86  amount=-2
+`
+
+type testShader struct {
+	drawnPoints *int32
+}
+
+func (t testShader) Use() {
+}
+func (t testShader) SetUniformMat4(s string, m mgl32.Mat4) {
+}
+func (t testShader) SetUniform3f(s string, f1, f2, f3 float32) {
+}
+func (t testShader) DrawTriangles(i int32) {
+	*t.drawnPoints = i
+}
+func (t testShader) Close(i int) {
+}
+func (t testShader) VertexAttribPointer(i uint32, c int32, s int32, o int) {
+}
+func (t testShader) BindVertexArray() {
+}
+func (t testShader) BindBufferData(d []float32) {
+}
+
+// testPages returns opaque pages, the glyphs are white, the background is black.
+func testPages(file string) (image.Image, error) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, color.Black)
+		}
+	}
+	img.Set(1, 1, color.White)
+	return img, nil
+}
+func testFont(t *testing.T) *Font {
+	font, err := LoadBMFont(strings.NewReader(testBMFont), testPages)
+	if err != nil {
+		t.Fatalf("LoadBMFont failed: %s", err.Error())
+	}
+	return font
+}
+func TestParseBMLine(t *testing.T) {
+	line := parseBMLine(`page id=1 file="test 1.png"`)
+	if line.tag != "page" || line.int("id") != 1 || line.values["file"] != "test 1.png" {
+		t.Errorf("Invalid line '%v'.", line)
+	}
+}
+func TestLoadBMFont(t *testing.T) {
+	font := testFont(t)
+	if font.GetLineHeight() != 16 || font.GetBase() != 12 {
+		t.Error("Invalid common values")
+	}
+	if font.GetAtlas().Bounds().Dy() != 32 {
+		t.Error("The pages should be merged")
+	}
+	if g, _ := font.GetGlyph('?'); g.Y != 16 {
+		t.Error("The glyph of the second page should be moved")
+	}
+	if font.GetKerning('A', 'V') != -2 {
+		t.Error("Invalid kerning")
+	}
+	if font.GetAtlas().RGBAAt(1, 1).A != 255 || font.GetAtlas().RGBAAt(2, 2).A != 0 {
+		t.Error("The luminance of the opaque page should be the coverage")
+	}
+	if _, err := LoadBMFont(strings.NewReader("info face=\"Test\"\n"), testPages); err == nil {
+		t.Error("Missing common line should be an error")
+	}
+}
+func TestLayout(t *testing.T) {
+	font := testFont(t)
+	quads := font.Layout("AV\nA")
+	if len(quads) != 3 {
+		t.Fatalf("Invalid number of quads '%d'.", len(quads))
+	}
+	// the kerning moves the V to left.
+	if quads[1].X != 7 {
+		t.Errorf("Invalid kerning, x: '%f'.", quads[1].X)
+	}
+	if quads[2].X != 0 || quads[2].Y != 18 {
+		t.Error("Invalid new line")
+	}
+	if quads[0].U1 != 0.25 || quads[0].V1 != 10.0/32.0 {
+		t.Error("Invalid texture coordinates")
+	}
+	// the missing characters are replaced with '?'.
+	if quads := font.Layout("x"); len(quads) != 1 || quads[0].X != 1 {
+		t.Error("Missing character should be replaced")
+	}
+	width, height := font.Measure("AV\nA")
+	if width != 16 || height != 32 {
+		t.Errorf("Invalid size '%f x %f'.", width, height)
+	}
+}
+func TestLoadTTF(t *testing.T) {
+	font, err := LoadTTF(goregular.TTF, 24, DEFAULT_CHARSET)
+	if err != nil {
+		t.Fatalf("LoadTTF failed: %s", err.Error())
+	}
+	g, ok := font.GetGlyph('A')
+	if !ok || g.Width == 0 || g.Height == 0 || g.XAdvance == 0 {
+		t.Error("Invalid glyph")
+	}
+	if g.YOffset+float32(g.Height) > font.GetBase()+1 {
+		t.Error("The 'A' should be on the baseline")
+	}
+	if space, _ := font.GetGlyph(' '); space.Width != 0 || space.XAdvance == 0 {
+		t.Error("Invalid space glyph")
+	}
+	if font.GetAtlas().Bounds().Dx() != ATLAS_WIDTH {
+		t.Error("Invalid atlas width")
+	}
+	if _, err := LoadTTF([]byte("invalid"), 24, DEFAULT_CHARSET); err == nil {
+		t.Error("Invalid font data should be an error")
+	}
+}
+func TestDrawWithUniforms(t *testing.T) {
+	var points int32
+	text := New("AV", testFont(t), testShader{drawnPoints: &points})
+	text.SetPosition(mgl32.Vec3{10, 20, 0})
+	text.DrawWithUniforms(mgl32.Ident4(), mgl32.Ident4())
+	if points != 12 {
+		t.Errorf("Invalid number of points '%d'.", points)
+	}
+	// screen mode: the first vertex is the top left corner of the first glyph.
+	if text.vertices[0] != 10 || text.vertices[1] != 22 || text.vertices[2] != screenDepth {
+		t.Error("Invalid screen position")
+	}
+	text.DrawMode(DRAW_MODE_BILLBOARD)
+	text.SetScale(0.1)
+	// camera that looks from the +x axis: its right vector is -z.
+	view := mgl32.LookAtV(mgl32.Vec3{10, 0, 0}, mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 1, 0})
+	text.SetPosition(mgl32.Vec3{0, 0, 0})
+	text.DrawWithUniforms(view, mgl32.Ident4())
+	// the third vertex is the bottom right corner of the first glyph.
+	x, y, z := text.vertices[10], text.vertices[11], text.vertices[12]
+	if x != 0 || y > -1.19 || y < -1.21 || z > -0.79 || z < -0.81 {
+		t.Errorf("Invalid billboard position '%f, %f, %f'.", x, y, z)
+	}
+}
@@ -0,0 +1,177 @@
+package text
+
+import (
+	"image"
+	"image/draw"
+	"io/ioutil"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// DEFAULT_CHARSET is the printable ascii characters.
+	DEFAULT_CHARSET = " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+	// ATLAS_WIDTH is the width of the generated atlas in pixels.
+	ATLAS_WIDTH = 512
+)
+
+// rasterizedGlyph is a glyph image before the atlas packing.
+type rasterizedGlyph struct {
+	r     rune
+	glyph Glyph
+	mask  *image.Alpha
+}
+
+// LoadTTF rasterizes the characters of the charset from the given TrueType
+// or OpenType font data with the given size (pixels per em) to a glyph atlas.
+// The kerning is calculated for every pair of the charset.
+func LoadTTF(data []byte, size float64, charset string) (*Font, error) {
+	f, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf sfnt.Buffer
+	ppem := fixed.Int26_6(size * 64)
+	metrics, err := f.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	result := newFont(fixedToFloat(metrics.Height), fixedToFloat(metrics.Ascent))
+
+	var glyphs []rasterizedGlyph
+	indices := make(map[rune]sfnt.GlyphIndex)
+	for _, r := range charset {
+		if _, ok := indices[r]; ok {
+			continue
+		}
+		index, err := f.GlyphIndex(&buf, r)
+		if err != nil || index == 0 {
+			continue
+		}
+		advance, err := f.GlyphAdvance(&buf, index, ppem, font.HintingNone)
+		if err != nil {
+			return nil, err
+		}
+		segments, err := f.LoadGlyph(&buf, index, ppem, nil)
+		if err != nil {
+			return nil, err
+		}
+		indices[r] = index
+		g := rasterizeGlyph(segments)
+		g.r = r
+		g.glyph.XAdvance = fixedToFloat(advance)
+		g.glyph.YOffset += result.base
+		glyphs = append(glyphs, g)
+	}
+
+	packer := atlasPacker{width: ATLAS_WIDTH, padding: 1}
+	for i := range glyphs {
+		glyphs[i].glyph.X, glyphs[i].glyph.Y = packer.place(glyphs[i].glyph.Width, glyphs[i].glyph.Height)
+	}
+	height := packer.height()
+	if height == 0 {
+		height = 1
+	}
+	result.atlas = image.NewRGBA(image.Rect(0, 0, ATLAS_WIDTH, height))
+	for _, g := range glyphs {
+		if g.mask != nil {
+			r := image.Rect(g.glyph.X, g.glyph.Y, g.glyph.X+g.glyph.Width, g.glyph.Y+g.glyph.Height)
+			draw.Draw(result.atlas, r, whiteMask(g.mask, r.Size()), image.Point{}, draw.Src)
+		}
+		result.glyphs[g.r] = g.glyph
+	}
+
+	for first, firstIndex := range indices {
+		for second, secondIndex := range indices {
+			kern, err := f.Kern(&buf, firstIndex, secondIndex, ppem, font.HintingNone)
+			if err == nil && kern != 0 {
+				result.kerning[[2]rune{first, second}] = fixedToFloat(kern)
+			}
+		}
+	}
+	return result, nil
+}
+
+// LoadTTFFile reads the font file and calls LoadTTF.
+func LoadTTFFile(path string, size float64, charset string) (*Font, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadTTF(data, size, charset)
+}
+
+func fixedToFloat(v fixed.Int26_6) float32 {
+	return float32(v) / 64
+}
+
+// rasterizeGlyph draws the segments to an alpha image. The size and the
+// offset of the glyph is calculated from the bounds of the segments. The
+// y offset is relative to the baseline.
+func rasterizeGlyph(segments []sfnt.Segment) rasterizedGlyph {
+	var g rasterizedGlyph
+	if len(segments) == 0 {
+		return g
+	}
+	minX, minY := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	maxX, maxY := -minX, -minY
+	for _, s := range segments {
+		for i := 0; i < segmentPoints(s.Op); i++ {
+			x, y := fixedToFloat(s.Args[i].X), fixedToFloat(s.Args[i].Y)
+			minX = float32(math.Min(float64(minX), float64(x)))
+			minY = float32(math.Min(float64(minY), float64(y)))
+			maxX = float32(math.Max(float64(maxX), float64(x)))
+			maxY = float32(math.Max(float64(maxY), float64(y)))
+		}
+	}
+	left, top := float32(math.Floor(float64(minX))), float32(math.Floor(float64(minY)))
+	width := int(math.Ceil(float64(maxX))) - int(left)
+	height := int(math.Ceil(float64(maxY))) - int(top)
+	if width <= 0 || height <= 0 {
+		return g
+	}
+	r := vector.NewRasterizer(width, height)
+	r.DrawOp = draw.Src
+	p := func(i int, s sfnt.Segment) (float32, float32) {
+		return fixedToFloat(s.Args[i].X) - left, fixedToFloat(s.Args[i].Y) - top
+	}
+	for _, s := range segments {
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			r.MoveTo(p(0, s))
+		case sfnt.SegmentOpLineTo:
+			r.LineTo(p(0, s))
+		case sfnt.SegmentOpQuadTo:
+			bx, by := p(0, s)
+			cx, cy := p(1, s)
+			r.QuadTo(bx, by, cx, cy)
+		case sfnt.SegmentOpCubeTo:
+			bx, by := p(0, s)
+			cx, cy := p(1, s)
+			dx, dy := p(2, s)
+			r.CubeTo(bx, by, cx, cy, dx, dy)
+		}
+	}
+	g.mask = image.NewAlpha(image.Rect(0, 0, width, height))
+	r.Draw(g.mask, g.mask.Bounds(), image.Opaque, image.Point{})
+	g.glyph.Width = width
+	g.glyph.Height = height
+	g.glyph.XOffset = left
+	g.glyph.YOffset = top
+	return g
+}
+
+// segmentPoints returns the number of the used points of the segment.
+func segmentPoints(op sfnt.SegmentOp) int {
+	switch op {
+	case sfnt.SegmentOpQuadTo:
+		return 2
+	case sfnt.SegmentOpCubeTo:
+		return 3
+	}
+	return 1
+}
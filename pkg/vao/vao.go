@@ -32,6 +32,13 @@ func (v *VAO) AppendVectors(v1, v2 mgl32.Vec3) {
 	v.appendVector(v2)
 }
 
+// AppendColorAlpha gets a vec3 coordinate and a vec4 rgba color input and
+// appends them to the vao. In other words it appends 7 float32.
+func (v *VAO) AppendColorAlpha(v1 mgl32.Vec3, color mgl32.Vec4) {
+	v.appendVector(v1)
+	v.vao = append(v.vao, color.X(), color.Y(), color.Z(), color.W())
+}
+
 // AppendTextureVectors gets two vec3 and a vec2 input and appends them to the vao.
 // In other words it appends 8 float32. It can be used for coordinate & color & texture corrdinates.
 func (v *VAO) AppendTextureVectors(v1, v2 mgl32.Vec3, tex mgl32.Vec2) {
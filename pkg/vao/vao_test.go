@@ -163,3 +163,16 @@ func TestAppendTextureVectors(t *testing.T) {
 		t.Error("AppendTextureVectors should add 0 as 8. element to the vao.")
 	}
 }
+func TestAppendColorAlpha(t *testing.T) {
+	vao := NewVAO()
+	vao.AppendColorAlpha(mgl32.Vec3{1, 2, 3}, mgl32.Vec4{4, 5, 6, 0.5})
+	expected := []float32{1, 2, 3, 4, 5, 6, 0.5}
+	if len(vao.vao) != 7 {
+		t.Fatal("AppendColorAlpha should add 7 element to the vao.")
+	}
+	for i := range expected {
+		if vao.vao[i] != expected[i] {
+			t.Errorf("Invalid element '%d'.", i)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package window
+
+import (
+	"fmt"
+	"strings"
+
+	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// Version is a requested gl context version with profile (eg: glfw.OpenGLCoreProfile).
+type Version struct {
+	Major   int
+	Minor   int
+	Profile int
+}
+
+// String returns the version as '4.1 core'.
+func (v Version) String() string {
+	profile := "any"
+	switch v.Profile {
+	case glfw.OpenGLCoreProfile:
+		profile = "core"
+	case glfw.OpenGLCompatProfile:
+		profile = "compat"
+	}
+	return fmt.Sprintf("%d.%d %s", v.Major, v.Minor, profile)
+}
+
+var (
+	// DEFAULT_VERSIONS is the fallback chain of the default config. The first
+	// one is the version of the gl wrapper.
+	DEFAULT_VERSIONS = []Version{
+		{wrapper.GL_MAJOR_VERSION, wrapper.GL_MINOR_VERSION, glfw.OpenGLCoreProfile},
+		{3, 3, glfw.OpenGLCoreProfile},
+	}
+)
+
+// Config is the setup of the window and its gl context.
+type Config struct {
+	// the size in screen coordinates. In fullscreen mode the 0 size means the
+	// size of the video mode of the monitor.
+	Width  int
+	Height int
+	Title  string
+	// Fullscreen changes the video mode of the monitor, Borderless creates a
+	// window with the current video mode of the monitor (windowed fullscreen).
+	// The Monitor is the index of the monitor in the glfw.GetMonitors list.
+	Fullscreen bool
+	Borderless bool
+	Monitor    int
+	Resizable  bool
+	Hidden     bool
+	// VSync is the swap interval, 0 disables the vsync.
+	VSync int
+	// Samples is the number of the MSAA samples, 0 disables the multisampling.
+	Samples int
+	// SRGB requests sRGB capable framebuffer. The wrapper.FRAMEBUFFER_SRGB
+	// has to be enabled after the gl initialization.
+	SRGB  bool
+	Debug bool
+	// Versions is the fallback chain of the gl versions. The first version
+	// that is available is used.
+	Versions []Version
+}
+
+// DefaultConfig returns a resizable window config with vsync and the default gl versions.
+func DefaultConfig(width, height int, title string) Config {
+	return Config{
+		Width:     width,
+		Height:    height,
+		Title:     title,
+		Resizable: true,
+		VSync:     1,
+		Versions:  DEFAULT_VERSIONS,
+	}
+}
+
+// The glfw functions are variables, so that the window creation could be tested without display.
+var (
+	initGlfw           = glfw.Init
+	defaultWindowHints = glfw.DefaultWindowHints
+	windowHint         = glfw.WindowHint
+	createWindow       = func(width, height int, title string, monitor *glfw.Monitor) (*glfw.Window, error) {
+		return glfw.CreateWindow(width, height, title, monitor, nil)
+	}
+	getMonitors = glfw.GetMonitors
+	videoMode   = func(monitor *glfw.Monitor) *glfw.VidMode {
+		return monitor.GetVideoMode()
+	}
+	setupContext = func(window *glfw.Window, vsync int) {
+		window.MakeContextCurrent()
+		glfw.SwapInterval(vsync)
+	}
+)
+
+// Validate returns error if the config is invalid.
+func (c Config) Validate() error {
+	if !c.Fullscreen && !c.Borderless && (c.Width <= 0 || c.Height <= 0) {
+		return fmt.Errorf("Invalid window size '%dx%d'.", c.Width, c.Height)
+	}
+	if c.Fullscreen && c.Borderless {
+		return fmt.Errorf("The fullscreen and the borderless modes are exclusive.")
+	}
+	if c.Samples < 0 || c.VSync < 0 || c.Monitor < 0 {
+		return fmt.Errorf("The samples '%d', the vsync '%d' and the monitor '%d' have to be non negative.", c.Samples, c.VSync, c.Monitor)
+	}
+	if len(c.Versions) == 0 {
+		return fmt.Errorf("Missing gl version.")
+	}
+	return nil
+}
+
+// Create initializes glfw and returns a window with the config. The gl
+// versions are tried in order, it returns error if none of them is available.
+// The context of the window is the current one.
+func Create(c Config) (*glfw.Window, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := initGlfw(); err != nil {
+		return nil, fmt.Errorf("Could not initialize glfw: %s", err.Error())
+	}
+	var monitor *glfw.Monitor
+	width, height := c.Width, c.Height
+	var mode *glfw.VidMode
+	if c.Fullscreen || c.Borderless {
+		monitors := getMonitors()
+		if c.Monitor >= len(monitors) {
+			return nil, fmt.Errorf("Invalid monitor '%d', the number of the monitors is '%d'.", c.Monitor, len(monitors))
+		}
+		monitor = monitors[c.Monitor]
+		mode = videoMode(monitor)
+		if c.Borderless || width <= 0 || height <= 0 {
+			width, height = mode.Width, mode.Height
+		}
+	}
+	var errors []string
+	for _, version := range c.Versions {
+		defaultWindowHints()
+		for hint, value := range c.hints(version, mode) {
+			windowHint(hint, value)
+		}
+		window, err := createWindow(width, height, c.Title, monitor)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %s", version, err.Error()))
+			continue
+		}
+		setupContext(window, c.VSync)
+		return window, nil
+	}
+	return nil, fmt.Errorf("Could not create window with the gl versions: %s", strings.Join(errors, ", "))
+}
+
+// hints returns the window hints of the config with the given gl version. The
+// video mode of the borderless window is set from the mode of the monitor.
+func (c Config) hints(version Version, mode *glfw.VidMode) map[glfw.Hint]int {
+	hints := map[glfw.Hint]int{
+		glfw.ContextVersionMajor: version.Major,
+		glfw.ContextVersionMinor: version.Minor,
+		glfw.OpenGLProfile:       version.Profile,
+		glfw.Resizable:           glfwBool(c.Resizable),
+		glfw.Visible:             glfwBool(!c.Hidden),
+		glfw.Samples:             c.Samples,
+		glfw.SRGBCapable:         glfwBool(c.SRGB),
+		glfw.OpenGLDebugContext:  glfwBool(c.Debug),
+	}
+	// the forward compatible context is necessary for the core profile on macOS.
+	if version.Profile == glfw.OpenGLCoreProfile {
+		hints[glfw.OpenGLForwardCompatible] = glfw.True
+	}
+	if c.Borderless && mode != nil {
+		hints[glfw.RedBits] = mode.RedBits
+		hints[glfw.GreenBits] = mode.GreenBits
+		hints[glfw.BlueBits] = mode.BlueBits
+		hints[glfw.RefreshRate] = mode.RefreshRate
+		hints[glfw.Decorated] = glfw.False
+	}
+	return hints
+}
+
+func glfwBool(b bool) int {
+	if b {
+		return glfw.True
+	}
+	return glfw.False
+}
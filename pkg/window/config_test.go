@@ -0,0 +1,110 @@
+package window
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// fakeGlfw replaces the glfw functions. The window creation succeeds with
+// the available versions, the hints of the tries are stored. The returned
+// function restores the original glfw functions.
+func fakeGlfw(available ...Version) (*[]map[glfw.Hint]int, func()) {
+	originalInit, originalDefaultHints, originalHint := initGlfw, defaultWindowHints, windowHint
+	originalCreate, originalMonitors, originalSetup := createWindow, getMonitors, setupContext
+	restore := func() {
+		initGlfw, defaultWindowHints, windowHint = originalInit, originalDefaultHints, originalHint
+		createWindow, getMonitors, setupContext = originalCreate, originalMonitors, originalSetup
+	}
+	var tries []map[glfw.Hint]int
+	initGlfw = func() error { return nil }
+	defaultWindowHints = func() {
+		tries = append(tries, make(map[glfw.Hint]int))
+	}
+	windowHint = func(hint glfw.Hint, value int) {
+		tries[len(tries)-1][hint] = value
+	}
+	createWindow = func(width, height int, title string, monitor *glfw.Monitor) (*glfw.Window, error) {
+		hints := tries[len(tries)-1]
+		for _, v := range available {
+			if hints[glfw.ContextVersionMajor] == v.Major && hints[glfw.ContextVersionMinor] == v.Minor {
+				return &glfw.Window{}, nil
+			}
+		}
+		return nil, fmt.Errorf("Version unavailable.")
+	}
+	getMonitors = func() []*glfw.Monitor { return nil }
+	setupContext = func(window *glfw.Window, vsync int) {}
+	return &tries, restore
+}
+
+func TestValidate(t *testing.T) {
+	valid := DefaultConfig(800, 600, "test")
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Valid config should be valid: %s", err.Error())
+	}
+	fullscreen := DefaultConfig(0, 0, "test")
+	fullscreen.Fullscreen = true
+	if err := fullscreen.Validate(); err != nil {
+		t.Error("The fullscreen window could have 0 size")
+	}
+	for i, c := range []Config{
+		DefaultConfig(0, 600, "test"),
+		{Width: 1, Height: 1, Fullscreen: true, Borderless: true, Versions: DEFAULT_VERSIONS},
+		{Width: 1, Height: 1, Samples: -1, Versions: DEFAULT_VERSIONS},
+		{Width: 1, Height: 1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Invalid config '%d' should be an error.", i)
+		}
+	}
+}
+func TestCreateFallback(t *testing.T) {
+	tries, restore := fakeGlfw(Version{3, 3, glfw.OpenGLCoreProfile})
+	defer restore()
+	c := DefaultConfig(800, 600, "test")
+	c.Samples = 4
+	c.SRGB = true
+	c.Debug = true
+	if _, err := Create(c); err != nil {
+		t.Fatalf("Create failed: %s", err.Error())
+	}
+	if len(*tries) != 2 || (*tries)[0][glfw.ContextVersionMajor] != 4 || (*tries)[1][glfw.ContextVersionMajor] != 3 {
+		t.Errorf("Invalid fallback chain '%v'.", *tries)
+	}
+	hints := (*tries)[1]
+	if hints[glfw.Samples] != 4 || hints[glfw.SRGBCapable] != glfw.True || hints[glfw.OpenGLDebugContext] != glfw.True || hints[glfw.Resizable] != glfw.True {
+		t.Errorf("Invalid hints '%v'.", hints)
+	}
+	// no version is available.
+	_, restoreUnavailable := fakeGlfw()
+	defer restoreUnavailable()
+	_, err := Create(c)
+	if err == nil || !strings.Contains(err.Error(), "4.1 core") || !strings.Contains(err.Error(), "3.3 core") {
+		t.Errorf("Invalid fallback error '%v'.", err)
+	}
+}
+func TestCreateMonitor(t *testing.T) {
+	_, restore := fakeGlfw(DEFAULT_VERSIONS...)
+	defer restore()
+	c := DefaultConfig(0, 0, "test")
+	c.Borderless = true
+	c.Monitor = 1
+	if _, err := Create(c); err == nil {
+		t.Error("Missing monitor should be an error")
+	}
+}
+func TestBorderlessHints(t *testing.T) {
+	c := DefaultConfig(0, 0, "test")
+	c.Borderless = true
+	mode := &glfw.VidMode{Width: 1920, Height: 1080, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 60}
+	hints := c.hints(Version{3, 3, glfw.OpenGLCompatProfile}, mode)
+	if hints[glfw.RefreshRate] != 60 || hints[glfw.RedBits] != 8 || hints[glfw.Decorated] != glfw.False {
+		t.Errorf("Invalid borderless hints '%v'.", hints)
+	}
+	if _, ok := hints[glfw.OpenGLForwardCompatible]; ok {
+		t.Error("The compat profile shouldn't be forward compatible")
+	}
+}
@@ -3,31 +3,31 @@ package window
 import (
 	"fmt"
 
-	wrapper "github.com/akosgarai/opengl_playground/pkg/glwrapper"
-
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
 
-// InitGlfw returns a *glfw.Windows instance.
+// InitGlfw returns a resizable *glfw.Windows instance. The application
+// handles the resize (see the FramebufferSizeEvent function of the application).
+// It panics if the window couldn't be created, the Create function returns the error.
 func InitGlfw(windowWidth, windowHeight int, windowTitle string) *glfw.Window {
-	if err := glfw.Init(); err != nil {
-		panic(fmt.Errorf("could not initialize glfw: %v", err))
+	window, err := Create(DefaultConfig(windowWidth, windowHeight, windowTitle))
+	if err != nil {
+		panic(err)
 	}
+	return window
+}
 
-	glfw.WindowHint(glfw.ContextVersionMajor, wrapper.GL_MAJOR_VERSION)
-	glfw.WindowHint(glfw.ContextVersionMinor, wrapper.GL_MINOR_VERSION)
-	glfw.WindowHint(glfw.Resizable, glfw.False)
-	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
-	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
-
-	window, err := glfw.CreateWindow(windowWidth, windowHeight, windowTitle, nil, nil)
-
+// InitHiddenGlfw returns an invisible *glfw.Window instance. It could be used
+// for offscreen rendering, where only the gl context is necessary.
+func InitHiddenGlfw(windowWidth, windowHeight int, windowTitle string) *glfw.Window {
+	c := DefaultConfig(windowWidth, windowHeight, windowTitle)
+	c.Resizable = false
+	c.Hidden = true
+	c.VSync = 0
+	window, err := Create(c)
 	if err != nil {
-		panic(fmt.Errorf("could not create opengl renderer: %v", err))
+		panic(err)
 	}
-
-	window.MakeContextCurrent()
-
 	return window
 }
 
@@ -7,6 +7,9 @@ import (
 func TestInitGlfw(t *testing.T) {
 	t.Skip("Unimplemented")
 }
+func TestInitHiddenGlfw(t *testing.T) {
+	t.Skip("Unimplemented")
+}
 func TestDummyKeyCallback(t *testing.T) {
 	t.Skip("Unimplemented")
 }